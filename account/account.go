@@ -0,0 +1,265 @@
+// Package account keeps account balances next to a FIX order entry session.
+//
+// Binance FIX has no balance endpoint, so confirmed balances are loaded through
+// a user supplied Fetcher (REST or WebSocket API) and projected forward locally
+// from execution report fills until the next refresh.
+package account
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ljm2ya/binance_fix_api/handlers"
+)
+
+// Balance is the amount of a single asset.
+type Balance struct {
+	Asset  string
+	Free   float64
+	Locked float64
+}
+
+// BalanceView pairs the exchange confirmed balance of an asset with the
+// locally projected one, which includes fills seen since the last refresh.
+type BalanceView struct {
+	Asset     string
+	Confirmed Balance
+	Projected Balance
+}
+
+// Fetcher loads exchange confirmed balances, e.g. from the REST account endpoint.
+type Fetcher interface {
+	FetchBalances(ctx context.Context) ([]Balance, error)
+}
+
+// FetcherFunc adapts a function to the Fetcher interface.
+type FetcherFunc func(ctx context.Context) ([]Balance, error)
+
+func (f FetcherFunc) FetchBalances(ctx context.Context) ([]Balance, error) {
+	return f(ctx)
+}
+
+// SymbolResolver splits a symbol into its base and quote asset.
+type SymbolResolver func(symbol string) (base, quote string, ok bool)
+
+// DefaultQuoteAssets are the quote assets recognised by SuffixResolver.
+var DefaultQuoteAssets = []string{
+	"FDUSD", "USDT", "USDC", "TUSD", "BUSD", "DAI", "BTC", "ETH", "BNB", "EUR", "TRY", "BRL", "JPY",
+}
+
+// SuffixResolver resolves symbols by matching known quote asset suffixes.
+func SuffixResolver(quoteAssets []string) SymbolResolver {
+	return func(symbol string) (string, string, bool) {
+		for _, quote := range quoteAssets {
+			if len(symbol) > len(quote) && strings.HasSuffix(symbol, quote) {
+				return strings.TrimSuffix(symbol, quote), quote, true
+			}
+		}
+		return "", "", false
+	}
+}
+
+type Option func(b *Book)
+
+// WithSymbolResolver overrides how symbols are split into base and quote assets.
+func WithSymbolResolver(resolver SymbolResolver) Option {
+	return func(b *Book) {
+		b.resolve = resolver
+	}
+}
+
+// fill is a balance change derived from an execution report.
+type fill struct {
+	received time.Time
+	deltas   map[string]float64
+}
+
+// Book holds confirmed balances and the fills applied on top of them.
+type Book struct {
+	mu        sync.RWMutex
+	fetcher   Fetcher
+	resolve   SymbolResolver
+	confirmed map[string]Balance
+	fills     []fill
+	executed  map[string]executed // by orderKey
+	updatedAt time.Time
+}
+
+// executed remembers the cumulative quantities of an order so that only the
+// delta of each new report is applied.
+type executed struct {
+	cumQty      float64
+	cumQuoteQty float64
+}
+
+// New creates a Book fed by fetcher.
+func New(fetcher Fetcher, opts ...Option) *Book {
+	b := &Book{
+		fetcher:   fetcher,
+		resolve:   SuffixResolver(DefaultQuoteAssets),
+		confirmed: make(map[string]Balance),
+		executed:  make(map[string]executed),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// Refresh reloads confirmed balances. Fills received before the fetch started
+// are assumed to be included in the new snapshot and are dropped.
+func (b *Book) Refresh(ctx context.Context) error {
+	started := time.Now()
+	balances, err := b.fetcher.FetchBalances(ctx)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.confirmed = make(map[string]Balance, len(balances))
+	for _, balance := range balances {
+		b.confirmed[balance.Asset] = balance
+	}
+
+	kept := b.fills[:0]
+	for _, f := range b.fills {
+		if f.received.After(started) {
+			kept = append(kept, f)
+		}
+	}
+	b.fills = kept
+	b.updatedAt = started
+
+	return nil
+}
+
+// UpdatedAt returns when the confirmed balances were last fetched.
+func (b *Book) UpdatedAt() time.Time {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.updatedAt
+}
+
+// HandleExecutionReport applies the fill contained in an execution report.
+// Its signature matches fix.ExecutionReportHandler so it can be passed to
+// Client.SubscribeToExecutionReport directly.
+func (b *Book) HandleExecutionReport(o *handlers.Order) {
+	base, quote, ok := b.resolve(o.Symbol)
+	if !ok {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	key := orderKey(o)
+	prev := b.executed[key]
+	qty := o.CumQty - prev.cumQty
+	quoteQty := o.CumQuoteQty - prev.cumQuoteQty
+
+	if isTerminal(o.Status) {
+		delete(b.executed, key)
+	} else {
+		b.executed[key] = executed{cumQty: o.CumQty, cumQuoteQty: o.CumQuoteQty}
+	}
+	if qty <= 0 && quoteQty <= 0 {
+		return
+	}
+
+	deltas := make(map[string]float64, 2)
+	if o.Side == handlers.SideTypeBuy {
+		deltas[base] = qty
+		deltas[quote] = -quoteQty
+	} else {
+		deltas[base] = -qty
+		deltas[quote] = quoteQty
+	}
+	// Fees and the commission are reported per fill, not cumulated. Fees
+	// break the commission down by asset; the commission alone is deducted
+	// from reports without fees.
+	if len(o.Fees) > 0 {
+		for _, fee := range o.Fees {
+			if fee.Asset != "" {
+				deltas[fee.Asset] -= fee.Amount
+			}
+		}
+	} else if o.CommissionAsset != "" {
+		deltas[o.CommissionAsset] -= o.Commission
+	}
+	b.fills = append(b.fills, fill{received: time.Now(), deltas: deltas})
+}
+
+// orderKey identifies the order of a report. Cancels and cancel/replaces are
+// reported under a new ClOrdID, so the OrderID is used, or the OrigClOrdID
+// when the report has none.
+func orderKey(o *handlers.Order) string {
+	switch {
+	case o.OrderID > 0:
+		return o.Symbol + "/" + strconv.FormatInt(o.OrderID, 10)
+	case o.OrigClientOrderID != "":
+		return o.OrigClientOrderID
+	default:
+		return o.ClientOrderID
+	}
+}
+
+func isTerminal(status handlers.OrderStatus) bool {
+	switch status {
+	case handlers.OrderStatusFilled, handlers.OrderStatusCanceled,
+		handlers.OrderStatusRejected, handlers.OrderStatusExpired:
+		return true
+	default:
+		return false
+	}
+}
+
+// Balance returns the view of a single asset.
+func (b *Book) Balance(asset string) BalanceView {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.view(asset)
+}
+
+// Balances returns views of all known assets sorted by asset name.
+func (b *Book) Balances() []BalanceView {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	assets := make(map[string]struct{}, len(b.confirmed))
+	for asset := range b.confirmed {
+		assets[asset] = struct{}{}
+	}
+	for _, f := range b.fills {
+		for asset := range f.deltas {
+			assets[asset] = struct{}{}
+		}
+	}
+
+	views := make([]BalanceView, 0, len(assets))
+	for asset := range assets {
+		views = append(views, b.view(asset))
+	}
+	sort.Slice(views, func(i, j int) bool { return views[i].Asset < views[j].Asset })
+
+	return views
+}
+
+func (b *Book) view(asset string) BalanceView {
+	confirmed, ok := b.confirmed[asset]
+	if !ok {
+		confirmed = Balance{Asset: asset}
+	}
+
+	projected := confirmed
+	for _, f := range b.fills {
+		projected.Free += f.deltas[asset]
+	}
+
+	return BalanceView{Asset: asset, Confirmed: confirmed, Projected: projected}
+}
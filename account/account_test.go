@@ -0,0 +1,75 @@
+package account
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/ljm2ya/binance_fix_api/handlers"
+)
+
+func newTestBook(t *testing.T, balances ...Balance) *Book {
+	t.Helper()
+	b := New(FetcherFunc(func(context.Context) ([]Balance, error) { return balances, nil }))
+	if err := b.Refresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func assertFree(t *testing.T, b *Book, asset string, want float64) {
+	t.Helper()
+	if got := b.Balance(asset).Projected.Free; math.Abs(got-want) > 1e-9 {
+		t.Errorf("%s projected free = %v, want %v", asset, got, want)
+	}
+}
+
+func TestPartialFillThenCancel(t *testing.T) {
+	b := newTestBook(t, Balance{Asset: "BTC", Free: 1}, Balance{Asset: "USDT", Free: 1000})
+
+	order := handlers.Order{
+		Symbol:        "BTCUSDT",
+		OrderID:       42,
+		ClientOrderID: "buy-1",
+		Side:          handlers.SideTypeBuy,
+	}
+
+	b.HandleExecutionReport(&order)
+
+	partial := order
+	partial.Status = handlers.OrderStatusPartiallyFilled
+	partial.CumQty, partial.CumQuoteQty = 0.25, 250
+	partial.Fees = []handlers.Fee{{Asset: "BNB", Amount: 0.01}}
+	b.HandleExecutionReport(&partial)
+
+	// The cancel comes under the ClOrdID of the cancel request and repeats
+	// the cumulative quantities of the order.
+	canceled := partial
+	canceled.ClientOrderID, canceled.OrigClientOrderID = "cancel-1", "buy-1"
+	canceled.Status = handlers.OrderStatusCanceled
+	canceled.Fees = nil
+	b.HandleExecutionReport(&canceled)
+
+	assertFree(t, b, "BTC", 1.25)
+	assertFree(t, b, "USDT", 750)
+	assertFree(t, b, "BNB", -0.01)
+}
+
+func TestCommissionWithoutFees(t *testing.T) {
+	b := newTestBook(t, Balance{Asset: "BTC", Free: 1}, Balance{Asset: "USDT", Free: 0})
+
+	b.HandleExecutionReport(&handlers.Order{
+		Symbol:          "BTCUSDT",
+		OrderID:         7,
+		ClientOrderID:   "sell-1",
+		Side:            handlers.SideTypeSell,
+		Status:          handlers.OrderStatusFilled,
+		CumQty:          0.5,
+		CumQuoteQty:     500,
+		Commission:      0.5,
+		CommissionAsset: "USDT",
+	})
+
+	assertFree(t, b, "BTC", 0.5)
+	assertFree(t, b, "USDT", 499.5)
+}
@@ -0,0 +1,122 @@
+package fix
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ljm2ya/binance_fix_api/handlers"
+)
+
+// aggTradeFlushDelay is how long an aggregate waits for another trade before
+// it is emitted. The trades of one taker order arrive back to back.
+const aggTradeFlushDelay = 100 * time.Millisecond
+
+// SubscribeToAggTrades subscribes to the trades of symbols and aggregates
+// them as Binance's WebSocket aggTrade stream does: consecutive trades of the
+// same taker order at the same price are emitted as one handlers.AggTrade to
+// SubscribeToAggTradeStream listeners. Binance FIX has no aggregated trade
+// entries, so the aggregation is done locally and an aggregate is emitted
+// once the next trade doesn't belong to it or after a short delay. The
+// individual trades are still emitted to SubscribeToTradeStream listeners.
+// UnsubscribeFromTrades ends both.
+func (c *Client) SubscribeToAggTrades(ctx context.Context, symbols []string) error {
+	if err := c.SubscribeToTrades(ctx, symbols); err != nil {
+		return err
+	}
+	c.aggTrades.enable(symbols)
+	return nil
+}
+
+// aggTrades aggregates the trades of the symbols subscribed with
+// SubscribeToAggTrades.
+type aggTrades struct {
+	mu      sync.Mutex
+	symbols map[string]*aggTradeRun
+	emit    func(a *handlers.AggTrade)
+	clock   Clock // times the flushes
+}
+
+// aggTradeRun is the aggregate being built for a symbol.
+type aggTradeRun struct {
+	agg   *handlers.AggTrade
+	timer clockTimer
+}
+
+func (a *aggTrades) enable(symbols []string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.symbols == nil {
+		a.symbols = make(map[string]*aggTradeRun)
+	}
+	for _, symbol := range symbols {
+		if _, ok := a.symbols[symbol]; !ok {
+			a.symbols[symbol] = &aggTradeRun{}
+		}
+	}
+}
+
+// disable stops aggregating symbols and emits their pending aggregates.
+func (a *aggTrades) disable(symbols []string) {
+	a.mu.Lock()
+	var done []*handlers.AggTrade
+	for _, symbol := range symbols {
+		if run, ok := a.symbols[symbol]; ok {
+			if agg := run.take(); agg != nil {
+				done = append(done, agg)
+			}
+			delete(a.symbols, symbol)
+		}
+	}
+	a.mu.Unlock()
+
+	for _, agg := range done {
+		a.emit(agg)
+	}
+}
+
+func (a *aggTrades) observe(trade *handlers.Trade) {
+	a.mu.Lock()
+	run, ok := a.symbols[trade.Symbol]
+	if !ok {
+		a.mu.Unlock()
+		return
+	}
+
+	var done *handlers.AggTrade
+	if run.agg == nil || !run.agg.Add(trade) {
+		done = run.take()
+		agg := handlers.NewAggTrade(trade)
+		run.agg = &agg
+	}
+	if run.timer != nil {
+		run.timer.Stop()
+	}
+	run.timer = afterFunc(a.clock, aggTradeFlushDelay, func() { a.flush(run) })
+	a.mu.Unlock()
+
+	if done != nil {
+		a.emit(done)
+	}
+}
+
+func (a *aggTrades) flush(run *aggTradeRun) {
+	a.mu.Lock()
+	agg := run.take()
+	a.mu.Unlock()
+
+	if agg != nil {
+		a.emit(agg)
+	}
+}
+
+// take removes and returns the pending aggregate of run, if any.
+func (r *aggTradeRun) take() *handlers.AggTrade {
+	agg := r.agg
+	r.agg = nil
+	if r.timer != nil {
+		r.timer.Stop()
+	}
+	return agg
+}
@@ -0,0 +1,348 @@
+// Package algo runs execution algorithms on top of a Client: parent orders
+// worked as a series of child NewOrderSingles.
+//
+// A TWAP slices a parent order evenly over a duration, with randomized jitter
+// on the timing of the slices so they are harder to spot in the tape:
+//
+//	twap, err := algo.StartTWAP(ctx, client, algo.TWAPConfig{
+//		Symbol: "BTCUSDT", Side: enum.Side_BUY, Quantity: 1,
+//		Duration: time.Hour, Slices: 60, Jitter: 0.2, Prefix: "twap1-",
+//	})
+//	<-twap.Done()
+//	fmt.Println(twap.Progress())
+package algo
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/enum"
+
+	fix "github.com/ljm2ya/binance_fix_api"
+	"github.com/ljm2ya/binance_fix_api/handlers"
+)
+
+// ErrCanceled is the error of a TWAP stopped with Cancel.
+var ErrCanceled = errors.New("twap canceled")
+
+// TWAPConfig describes the parent order of a TWAP.
+type TWAPConfig struct {
+	Symbol   string
+	Side     enum.Side
+	Quantity float64
+
+	// Duration is the time the slices are spread over; the first slice is
+	// sent right away, the last one about Duration later.
+	Duration time.Duration
+	Slices   int
+	// Jitter randomizes the interval between slices by up to this fraction
+	// of it, either way, e.g. 0.2 for ±20%.
+	Jitter float64
+
+	// LimitPrice sends the children as limit orders at this price instead of
+	// market orders. TimeInForce is their time in force, IMMEDIATE_OR_CANCEL
+	// by default, so unfilled quantity rolls over into the next slices.
+	LimitPrice  float64
+	TimeInForce enum.TimeInForce
+
+	// LotSize rounds the quantity of the children down to a multiple of it,
+	// the last child taking what is left.
+	LotSize float64
+
+	// Prefix starts the ClOrdIDs of the children; no other orders of the
+	// session may use it.
+	Prefix string
+}
+
+// Progress is the state of a TWAP.
+type Progress struct {
+	Quantity     float64 // of the parent order
+	Filled       float64
+	AveragePrice float64 // of the fills
+	Working      float64 // quantity of children not done yet
+	SlicesSent   int
+	Slices       int
+	Done         bool
+	Err          error // last child that failed, or why the TWAP stopped
+}
+
+// TWAP works a parent order started with StartTWAP.
+type TWAP struct {
+	c      *fix.Client
+	cfg    TWAPConfig
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu       sync.Mutex
+	seq      int
+	filled   float64
+	notional float64
+	sent     int
+	working  map[string]float64 // leaves quantity of open children by ClOrdID
+	changed  chan struct{}      // closed when a child is done
+	err      error
+	canceled bool
+	finished bool
+}
+
+// StartTWAP validates cfg and starts working the parent order until all
+// slices are sent and done, ctx is done or Cancel is called.
+func StartTWAP(ctx context.Context, c *fix.Client, cfg TWAPConfig) (*TWAP, error) {
+	switch {
+	case cfg.Symbol == "":
+		return nil, errors.New("twap: symbol is required")
+	case cfg.Quantity <= 0:
+		return nil, errors.New("twap: positive quantity is required")
+	case cfg.Slices <= 0:
+		return nil, errors.New("twap: positive number of slices is required")
+	case cfg.Duration < 0 || cfg.Jitter < 0 || cfg.Jitter > 1:
+		return nil, errors.New("twap: duration must not be negative and jitter within [0, 1]")
+	case cfg.Prefix == "":
+		return nil, errors.New("twap: ClOrdID prefix is required")
+	}
+	if cfg.LimitPrice > 0 && cfg.TimeInForce == "" {
+		cfg.TimeInForce = enum.TimeInForce_IMMEDIATE_OR_CANCEL
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	t := &TWAP{
+		c:       c,
+		cfg:     cfg,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+		working: make(map[string]float64),
+		changed: make(chan struct{}),
+	}
+	c.SubscribeToExecutionReportForPrefix(cfg.Prefix, t.handleExecutionReport)
+	go t.run(ctx)
+	return t, nil
+}
+
+// Done is closed once the TWAP stopped.
+func (t *TWAP) Done() <-chan struct{} {
+	return t.done
+}
+
+// Cancel stops sending slices and cancels the children still working. It
+// doesn't wait for the TWAP to stop; use Done for that.
+func (t *TWAP) Cancel() {
+	t.mu.Lock()
+	t.canceled = true
+	t.mu.Unlock()
+	t.cancel()
+}
+
+// Progress returns the state of the TWAP.
+func (t *TWAP) Progress() Progress {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p := Progress{
+		Quantity:   t.cfg.Quantity,
+		Filled:     t.filled,
+		SlicesSent: t.sent,
+		Slices:     t.cfg.Slices,
+		Done:       t.finished,
+		Err:        t.err,
+	}
+	if t.filled > 0 {
+		p.AveragePrice = t.notional / t.filled
+	}
+	for _, leaves := range t.working {
+		p.Working += leaves
+	}
+	return p
+}
+
+func (t *TWAP) run(ctx context.Context) {
+	defer close(t.done)
+	defer t.cancel()
+
+	interval := time.Duration(0)
+	if t.cfg.Slices > 1 {
+		interval = t.cfg.Duration / time.Duration(t.cfg.Slices-1)
+	}
+
+	next := time.Now()
+	for i := 0; i < t.cfg.Slices; i++ {
+		if i > 0 {
+			next = next.Add(t.jittered(interval))
+		}
+		select {
+		case <-time.After(time.Until(next)):
+		case <-ctx.Done():
+			t.stop(ctx.Err())
+			return
+		}
+
+		if qty := t.sliceQuantity(t.cfg.Slices - i); qty > 0 {
+			t.sendChild(ctx, qty)
+		}
+		t.mu.Lock()
+		t.sent++
+		t.mu.Unlock()
+	}
+
+	// Resting children are left to fill until ctx is done.
+	for {
+		t.mu.Lock()
+		working, changed := len(t.working), t.changed
+		t.mu.Unlock()
+		if working == 0 {
+			break
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			t.stop(ctx.Err())
+			return
+		}
+	}
+	t.stop(nil)
+}
+
+// jittered returns interval randomized by the configured jitter.
+func (t *TWAP) jittered(interval time.Duration) time.Duration {
+	if t.cfg.Jitter == 0 {
+		return interval
+	}
+	return time.Duration(float64(interval) * (1 + t.cfg.Jitter*(2*rand.Float64()-1)))
+}
+
+// sliceQuantity returns the quantity of the next child with slicesLeft
+// slices to go, rounded down to the lot size.
+func (t *TWAP) sliceQuantity(slicesLeft int) float64 {
+	t.mu.Lock()
+	remaining := t.cfg.Quantity - t.filled
+	for _, leaves := range t.working {
+		remaining -= leaves
+	}
+	t.mu.Unlock()
+
+	qty := remaining / float64(slicesLeft)
+	if slicesLeft == 1 {
+		qty = remaining
+	}
+	if lot := t.cfg.LotSize; lot > 0 {
+		qty = math.Floor(qty/lot+1e-9) * lot
+	}
+	return qty
+}
+
+func (t *TWAP) sendChild(ctx context.Context, qty float64) {
+	t.mu.Lock()
+	t.seq++
+	clOrdID := t.cfg.Prefix + strconv.Itoa(t.seq)
+	t.working[clOrdID] = qty
+	t.mu.Unlock()
+
+	s := t.c.NewOrderSingleService().
+		ClOrdID(clOrdID).
+		Symbol(t.cfg.Symbol).
+		Side(t.cfg.Side).
+		Quantity(qty)
+	if t.cfg.LimitPrice > 0 {
+		s.Type(enum.OrdType_LIMIT).Price(t.cfg.LimitPrice).TimeInForce(t.cfg.TimeInForce)
+	} else {
+		s.Type(enum.OrdType_MARKET)
+	}
+	order, err := s.Do(ctx)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	switch {
+	case err != nil:
+		t.err = err
+		// A child whose fate is unknown still counts as working, so it isn't
+		// sent twice; it is forgotten when ctx is done.
+		if !errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, context.Canceled) {
+			t.childDone(clOrdID)
+		}
+	case isDone(order.Status):
+		t.childDone(clOrdID)
+	}
+}
+
+// childDone stops tracking a child. The caller holds t.mu.
+func (t *TWAP) childDone(clOrdID string) {
+	if _, ok := t.working[clOrdID]; !ok {
+		return
+	}
+	delete(t.working, clOrdID)
+	close(t.changed)
+	t.changed = make(chan struct{})
+}
+
+// stop cancels the children still working and marks the TWAP finished.
+func (t *TWAP) stop(err error) {
+	t.mu.Lock()
+	working := make([]string, 0, len(t.working))
+	for clOrdID := range t.working {
+		working = append(working, clOrdID)
+	}
+	t.mu.Unlock()
+
+	// Fresh context: the TWAP's own is done by now.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for _, clOrdID := range working {
+		_, _ = t.c.NewOrderCancelService().
+			ClOrdID(clOrdID + "-c").
+			Symbol(t.cfg.Symbol).
+			OrigClOrdID(clOrdID).
+			Do(ctx)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.working = make(map[string]float64)
+	switch {
+	case t.canceled:
+		t.err = ErrCanceled
+	case err != nil:
+		t.err = err
+	}
+	t.finished = true
+}
+
+// handleExecutionReport accounts the fills of the children.
+func (t *TWAP) handleExecutionReport(o *handlers.Order) {
+	if o.Symbol != t.cfg.Symbol {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	clOrdID := o.ClientOrderID
+	if _, ok := t.working[clOrdID]; !ok {
+		// Reports of cancels carry the ClOrdID of the cancel request.
+		clOrdID = o.OrigClientOrderID
+	}
+	if _, ok := t.working[clOrdID]; !ok {
+		return
+	}
+	if o.LastQty > 0 {
+		t.filled += o.LastQty
+		t.notional += o.LastQty * o.LastPx
+	}
+	if isDone(o.Status) {
+		t.childDone(clOrdID)
+	} else if o.OrderQty > 0 {
+		t.working[clOrdID] = o.OrderQty - o.CumQty
+	}
+}
+
+func isDone(status handlers.OrderStatus) bool {
+	switch status {
+	case handlers.OrderStatusFilled, handlers.OrderStatusCanceled,
+		handlers.OrderStatusRejected, handlers.OrderStatusExpired:
+		return true
+	}
+	return false
+}
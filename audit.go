@@ -0,0 +1,290 @@
+package fix
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// ErrAuditCorrupt is returned for an audit log whose checksum chain is
+// broken, i.e. that was modified after it was written.
+var ErrAuditCorrupt = errors.New("audit log corrupt")
+
+// AuditEvent is the kind of an audited order message.
+type AuditEvent string
+
+const (
+	AuditNewOrder         AuditEvent = "new_order"
+	AuditNewOrderList     AuditEvent = "new_order_list"
+	AuditCancel           AuditEvent = "cancel"
+	AuditMassCancel       AuditEvent = "mass_cancel"
+	AuditReplace          AuditEvent = "replace"
+	AuditAmend            AuditEvent = "amend"
+	AuditExecutionReport  AuditEvent = "execution_report"
+	AuditListStatus       AuditEvent = "list_status"
+	AuditCancelReject     AuditEvent = "cancel_reject"
+	AuditAmendReject      AuditEvent = "amend_reject"
+	AuditMassCancelReport AuditEvent = "mass_cancel_report"
+	AuditBusinessReject   AuditEvent = "business_reject"
+)
+
+// auditedMsgTypes are the order messages the audit log records.
+var auditedMsgTypes = map[enum.MsgType]AuditEvent{
+	enum.MsgType_ORDER_SINGLE:                 AuditNewOrder,
+	enum.MsgType_ORDER_LIST:                   AuditNewOrderList,
+	enum.MsgType_ORDER_CANCEL_REQUEST:         AuditCancel,
+	enum.MsgType_ORDER_MASS_CANCEL_REQUEST:    AuditMassCancel,
+	enum.MsgType_ORDER_CANCEL_REPLACE_REQUEST: AuditReplace,
+	msgType_ORDER_AMEND_KEEP_PRIORITY_REQUEST: AuditAmend,
+	enum.MsgType_EXECUTION_REPORT:             AuditExecutionReport,
+	enum.MsgType_LIST_STATUS:                  AuditListStatus,
+	enum.MsgType_ORDER_CANCEL_REJECT:          AuditCancelReject,
+	msgType_ORDER_AMEND_REJECT:                AuditAmendReject,
+	enum.MsgType_ORDER_MASS_CANCEL_REPORT:     AuditMassCancelReport,
+	enum.MsgType_BUSINESS_MESSAGE_REJECT:      AuditBusinessReject,
+}
+
+// AuditRecord is an order message recorded by an AuditLog. Checksum is the
+// SHA-256 of the previous record's checksum and this record without its
+// checksum, so records can't be changed, removed or reordered unnoticed.
+type AuditRecord struct {
+	Seq         uint64     `json:"seq"`
+	Time        time.Time  `json:"time"`
+	Direction   Direction  `json:"direction"`
+	Event       AuditEvent `json:"event"`
+	Session     string     `json:"session"`
+	ClOrdID     string     `json:"clOrdId,omitempty"`
+	OrigClOrdID string     `json:"origClOrdId,omitempty"`
+	OrderID     string     `json:"orderId,omitempty"`
+	Symbol      string     `json:"symbol,omitempty"`
+	Message     string     `json:"message"` // raw FIX
+	Checksum    string     `json:"checksum"`
+}
+
+// sum returns the checksum of r chained to prev.
+func (r AuditRecord) sum(prev string) (string, error) {
+	r.Checksum = ""
+	data, err := json.Marshal(r)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	h.Write([]byte(prev))
+	h.Write(data)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// AuditQuery selects audit records. Zero fields match everything.
+type AuditQuery struct {
+	// ClOrdID matches the ClOrdID or OrigClOrdID of a record.
+	ClOrdID string
+	// From and To bound the time of a record, To excluded.
+	From, To time.Time
+}
+
+func (q AuditQuery) matches(r AuditRecord) bool {
+	if q.ClOrdID != "" && r.ClOrdID != q.ClOrdID && r.OrigClOrdID != q.ClOrdID {
+		return false
+	}
+	if !q.From.IsZero() && r.Time.Before(q.From) {
+		return false
+	}
+	if !q.To.IsZero() && !r.Time.Before(q.To) {
+		return false
+	}
+	return true
+}
+
+// AuditLog is an append-only journal of the order requests sent and the
+// execution reports, cancels and rejects received, one JSON AuditRecord per
+// line, for compliance. Unlike WithRecorder it holds order messages only, and
+// each record is chained to the previous one by its checksum. It is safe for
+// concurrent use.
+type AuditLog struct {
+	path string
+
+	mu   sync.Mutex
+	f    *os.File
+	size int64 // bytes of complete records
+	seq  uint64
+	last string // checksum of the last record
+}
+
+// OpenAuditLog opens or creates the audit log at path. The records already in
+// it are verified, and new ones continue their chain.
+func OpenAuditLog(path string) (*AuditLog, error) {
+	a := &AuditLog{path: path}
+	err := a.scan(-1, func(r AuditRecord) bool {
+		a.seq, a.last = r.Seq, r.Checksum
+		return true
+	})
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	a.f, err = os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	info, err := a.f.Stat()
+	if err != nil {
+		a.f.Close()
+		return nil, err
+	}
+	a.size = info.Size()
+	return a, nil
+}
+
+// WithAuditLog records the order messages of the session to a.
+func WithAuditLog(a *AuditLog) NewClientOption {
+	return func(o *Options) {
+		o.auditLog = a
+	}
+}
+
+// Append records msg if it is an order message, and reports whether it was.
+func (a *AuditLog) Append(direction Direction, sessionID string, msg *quickfix.Message) (bool, error) {
+	return a.appendAt(direction, sessionID, msg, time.Now())
+}
+
+// appendAt is Append recording the message at now.
+func (a *AuditLog) appendAt(direction Direction, sessionID string, msg *quickfix.Message, now time.Time) (bool, error) {
+	msgType, rerr := msg.MsgType()
+	if rerr != nil {
+		return false, rerr
+	}
+	event, ok := auditedMsgTypes[enum.MsgType(msgType)]
+	if !ok {
+		return false, nil
+	}
+
+	r := AuditRecord{
+		Time:      now.UTC(),
+		Direction: direction,
+		Event:     event,
+		Session:   sessionID,
+		Message:   msg.String(),
+	}
+	r.ClOrdID, _ = msg.Body.GetString(tag.ClOrdID)
+	r.OrigClOrdID, _ = msg.Body.GetString(tag.OrigClOrdID)
+	r.OrderID, _ = msg.Body.GetString(tag.OrderID)
+	r.Symbol, _ = msg.Body.GetString(tag.Symbol)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	r.Seq = a.seq + 1
+	var err error
+	if r.Checksum, err = r.sum(a.last); err != nil {
+		return true, err
+	}
+	line, err := json.Marshal(r)
+	if err != nil {
+		return true, err
+	}
+	n, err := a.f.Write(append(line, '\n'))
+	if err != nil {
+		return true, err
+	}
+	a.size += int64(n)
+	a.seq, a.last = r.Seq, r.Checksum
+	return true, nil
+}
+
+// Query returns the records matching q, oldest first, verifying the chain of
+// the whole log on the way. Records appended meanwhile are left out.
+func (a *AuditLog) Query(q AuditQuery) ([]AuditRecord, error) {
+	var records []AuditRecord
+	err := a.scan(a.written(), func(r AuditRecord) bool {
+		if q.matches(r) {
+			records = append(records, r)
+		}
+		return true
+	})
+	return records, err
+}
+
+// Verify checks the checksum chain of the whole log.
+func (a *AuditLog) Verify() error {
+	return a.scan(a.written(), func(AuditRecord) bool { return true })
+}
+
+// written returns the size of the records appended so far.
+func (a *AuditLog) written() int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.size
+}
+
+// Sync commits the log to stable storage.
+func (a *AuditLog) Sync() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.f.Sync()
+}
+
+// Close closes the log.
+func (a *AuditLog) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.f.Close()
+}
+
+// scan reads the records in the first size bytes of the log, or all of it
+// for a negative size, in order and verifying their chain, until fn returns
+// false.
+func (a *AuditLog) scan(size int64, fn func(r AuditRecord) bool) error {
+	f, err := os.Open(a.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if size >= 0 {
+		r = io.LimitReader(f, size)
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	var prev string
+	var seq uint64
+	for scanner.Scan() {
+		var r AuditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return fmt.Errorf("%w: record %d: %w", ErrAuditCorrupt, seq+1, err)
+		}
+		sum, err := r.sum(prev)
+		if err != nil {
+			return err
+		}
+		if r.Seq != seq+1 || sum != r.Checksum {
+			return fmt.Errorf("%w: record %d fails its checksum", ErrAuditCorrupt, seq+1)
+		}
+		if !fn(r) {
+			return nil
+		}
+		prev, seq = r.Checksum, r.Seq
+	}
+	return scanner.Err()
+}
+
+// audit records an order message to the audit log, if any.
+func (c *Client) audit(direction Direction, msg *quickfix.Message) {
+	if c.options.auditLog == nil {
+		return
+	}
+	if _, err := c.options.auditLog.appendAt(direction, c.sessionID.String(), msg, c.now()); err != nil {
+		c.logger().Errorw("Failed to append to the audit log", "msg", msg.String(), "err", err)
+	}
+}
@@ -0,0 +1,221 @@
+// Package bridge republishes the execution reports and trades of a Client to a
+// message broker, so the FIX session can feed the rest of a trading
+// infrastructure.
+//
+// Messages are encoded by a pluggable Encoder, JSON by default, and handed to
+// a Publisher such as those of bridge/kafkabridge and bridge/natsbridge.
+// Publishing runs on a goroutine of its own so a slow broker never blocks the
+// FIX session; when the buffer is full, messages are dropped and reported to
+// the error handler.
+package bridge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	fix "github.com/ljm2ya/binance_fix_api"
+	"github.com/ljm2ya/binance_fix_api/handlers"
+)
+
+var (
+	// ErrBufferFull is reported when a message is dropped because the
+	// publisher can't keep up.
+	ErrBufferFull = errors.New("bridge buffer full")
+	// ErrClosed is reported for messages handled after Close.
+	ErrClosed = errors.New("bridge closed")
+)
+
+// Kind is the kind of a published message.
+type Kind string
+
+const (
+	KindExecutionReport Kind = "execution_report"
+	KindTrade           Kind = "trade"
+)
+
+// Publisher sends encoded messages to a broker. Key is the symbol, for
+// brokers partitioning by key.
+type Publisher interface {
+	Publish(ctx context.Context, subject string, key, data []byte) error
+	Close() error
+}
+
+// Encoder encodes a *handlers.Order or *handlers.Trade.
+type Encoder interface {
+	Encode(kind Kind, v any) ([]byte, error)
+}
+
+// EncoderFunc adapts a function to Encoder.
+type EncoderFunc func(kind Kind, v any) ([]byte, error)
+
+func (f EncoderFunc) Encode(kind Kind, v any) ([]byte, error) {
+	return f(kind, v)
+}
+
+// JSON encodes messages with encoding/json, in the Binance conventions of the
+// handlers package.
+var JSON Encoder = EncoderFunc(func(_ Kind, v any) ([]byte, error) {
+	return json.Marshal(v)
+})
+
+// SubjectFunc returns the subject, or topic, a message is published to.
+type SubjectFunc func(kind Kind, symbol string) string
+
+// DefaultSubject publishes to "binance.<kind>.<symbol>", e.g.
+// "binance.trade.BTCUSDT".
+func DefaultSubject(kind Kind, symbol string) string {
+	return "binance." + string(kind) + "." + symbol
+}
+
+type Option func(b *Bridge)
+
+// WithEncoder sets the Encoder, JSON by default.
+func WithEncoder(encoder Encoder) Option {
+	return func(b *Bridge) {
+		b.encoder = encoder
+	}
+}
+
+// WithSubject sets where messages are published, DefaultSubject by default.
+func WithSubject(subject SubjectFunc) Option {
+	return func(b *Bridge) {
+		b.subject = subject
+	}
+}
+
+// WithBuffer sets how many messages wait for the publisher before new ones
+// are dropped, 1024 by default.
+func WithBuffer(n int) Option {
+	return func(b *Bridge) {
+		b.buffer = n
+	}
+}
+
+// WithPublishTimeout bounds every Publish call, 5s by default.
+func WithPublishTimeout(timeout time.Duration) Option {
+	return func(b *Bridge) {
+		b.timeout = timeout
+	}
+}
+
+// WithErrorHandler is called with the messages that failed to encode or
+// publish, or were dropped.
+func WithErrorHandler(onError func(kind Kind, symbol string, err error)) Option {
+	return func(b *Bridge) {
+		b.onError = onError
+	}
+}
+
+type message struct {
+	kind    Kind
+	subject string
+	symbol  string
+	data    []byte
+}
+
+// Bridge republishes messages to a Publisher.
+type Bridge struct {
+	pub     Publisher
+	encoder Encoder
+	subject SubjectFunc
+	buffer  int
+	timeout time.Duration
+	onError func(kind Kind, symbol string, err error)
+
+	mu     sync.RWMutex // guards closed against sends on queue
+	closed bool
+	queue  chan message
+	done   chan struct{}
+}
+
+// New creates a Bridge publishing to pub and starts publishing.
+func New(pub Publisher, opts ...Option) *Bridge {
+	b := &Bridge{
+		pub:     pub,
+		encoder: JSON,
+		subject: DefaultSubject,
+		buffer:  1024,
+		timeout: 5 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	b.queue = make(chan message, b.buffer)
+	b.done = make(chan struct{})
+	go b.run()
+	return b
+}
+
+// Attach subscribes the bridge to the execution reports and trades of c.
+func (b *Bridge) Attach(c *fix.Client) {
+	c.SubscribeToExecutionReport(b.HandleExecutionReport)
+	c.SubscribeToTradeStream(b.HandleTrade)
+}
+
+// HandleExecutionReport publishes an execution report. Its signature matches
+// fix.ExecutionReportHandler.
+func (b *Bridge) HandleExecutionReport(o *handlers.Order) {
+	b.enqueue(KindExecutionReport, o.Symbol, o)
+}
+
+// HandleTrade publishes a trade. Its signature matches fix.TradeStreamHandler.
+func (b *Bridge) HandleTrade(trade *handlers.Trade) {
+	b.enqueue(KindTrade, trade.Symbol, trade)
+}
+
+func (b *Bridge) enqueue(kind Kind, symbol string, v any) {
+	// Encode on the caller's goroutine: v may be reused once we return.
+	data, err := b.encoder.Encode(kind, v)
+	if err != nil {
+		b.fail(kind, symbol, err)
+		return
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		b.fail(kind, symbol, ErrClosed)
+		return
+	}
+	select {
+	case b.queue <- message{kind: kind, subject: b.subject(kind, symbol), symbol: symbol, data: data}:
+	default:
+		b.fail(kind, symbol, ErrBufferFull)
+	}
+}
+
+func (b *Bridge) run() {
+	defer close(b.done)
+	for msg := range b.queue {
+		ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+		err := b.pub.Publish(ctx, msg.subject, []byte(msg.symbol), msg.data)
+		cancel()
+		if err != nil {
+			b.fail(msg.kind, msg.symbol, err)
+		}
+	}
+}
+
+func (b *Bridge) fail(kind Kind, symbol string, err error) {
+	if b.onError != nil {
+		b.onError(kind, symbol, err)
+	}
+}
+
+// Close publishes the messages still buffered and closes the Publisher.
+func (b *Bridge) Close() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	close(b.queue)
+	b.mu.Unlock()
+
+	<-b.done
+	return b.pub.Close()
+}
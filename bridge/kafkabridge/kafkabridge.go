@@ -0,0 +1,46 @@
+// Package kafkabridge publishes the messages of a bridge.Bridge to Kafka.
+package kafkabridge
+
+import (
+	"context"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Publisher is a bridge.Publisher writing to Kafka topics named after the
+// bridge subjects, keyed by symbol so every symbol stays in order.
+type Publisher struct {
+	w *kafka.Writer
+}
+
+// New creates a Publisher for the cluster of brokers. Messages are
+// acknowledged by the partition leader and sent without waiting to fill a
+// batch.
+func New(brokers ...string) *Publisher {
+	return NewWithWriter(&kafka.Writer{
+		Addr:         kafka.TCP(brokers...),
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireOne,
+		BatchTimeout: 10 * time.Millisecond,
+	})
+}
+
+// NewWithWriter creates a Publisher using w. When w has a Topic, every message
+// is written to it and the bridge subjects are ignored.
+func NewWithWriter(w *kafka.Writer) *Publisher {
+	return &Publisher{w: w}
+}
+
+func (p *Publisher) Publish(ctx context.Context, subject string, key, data []byte) error {
+	msg := kafka.Message{Key: key, Value: data}
+	if p.w.Topic == "" {
+		msg.Topic = subject
+	}
+	return p.w.WriteMessages(ctx, msg)
+}
+
+// Close flushes and closes the writer.
+func (p *Publisher) Close() error {
+	return p.w.Close()
+}
@@ -0,0 +1,43 @@
+// Package natsbridge publishes the messages of a bridge.Bridge to NATS.
+package natsbridge
+
+import (
+	"context"
+
+	"github.com/nats-io/nats.go"
+)
+
+// Publisher is a bridge.Publisher publishing to the NATS subjects of the
+// bridge. NATS has no keys, the symbol is part of the default subjects.
+type Publisher struct {
+	nc    *nats.Conn
+	owned bool
+}
+
+// Connect connects to the NATS server at url. Close drains the connection.
+func Connect(url string, opts ...nats.Option) (*Publisher, error) {
+	nc, err := nats.Connect(url, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Publisher{nc: nc, owned: true}, nil
+}
+
+// New creates a Publisher on a connection the caller owns. Close only flushes
+// it.
+func New(nc *nats.Conn) *Publisher {
+	return &Publisher{nc: nc}
+}
+
+func (p *Publisher) Publish(_ context.Context, subject string, _, data []byte) error {
+	return p.nc.Publish(subject, data)
+}
+
+// Close flushes the messages published, draining the connection when it was
+// opened by Connect.
+func (p *Publisher) Close() error {
+	if p.owned {
+		return p.nc.Drain()
+	}
+	return p.nc.Flush()
+}
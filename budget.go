@@ -0,0 +1,181 @@
+package fix
+
+import (
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// DefaultBudgetWarning is the utilization of a message budget past which a
+// BudgetWarning is emitted, see WithBudgetWarning.
+const DefaultBudgetWarning = 0.8
+
+// MessageBudget is a limit on the messages a session sends within a sliding
+// interval, tracked locally by Usage.
+type MessageBudget struct {
+	Name     string        `json:"name"`
+	Interval time.Duration `json:"interval"`
+	Max      int           `json:"max"`
+	// OrdersOnly counts new orders only: every order of a NewOrderSingle,
+	// NewOrderList or OrderCancelRequestAndNewOrderSingle. Otherwise every
+	// message sent counts, heartbeats included.
+	OrdersOnly bool `json:"orders_only"`
+}
+
+// DefaultMessageBudgets are Binance's default order and message limits of a
+// FIX session when this was written. Accounts may have others; see
+// NewGetLimitService and WithMessageBudgets.
+var DefaultMessageBudgets = []MessageBudget{
+	{Name: "orders/10s", Interval: 10 * time.Second, Max: 100, OrdersOnly: true},
+	{Name: "messages/1m", Interval: time.Minute, Max: 10000},
+}
+
+// BudgetUsage is how much of a MessageBudget the session used.
+type BudgetUsage struct {
+	MessageBudget
+	Count int `json:"count"`
+	// Utilization is Count / Max, 1 when the budget is used up.
+	Utilization float64 `json:"utilization"`
+}
+
+// BudgetWarning is emitted when the usage of a message budget crosses the
+// threshold of WithBudgetWarning. It is emitted again only after the usage
+// fell below the threshold.
+type BudgetWarning struct {
+	BudgetUsage
+	Threshold float64
+}
+
+// WithMessageBudgets replaces DefaultMessageBudgets as the budgets tracked by
+// Usage. The budgets only count; requests are held back by WithRateLimit.
+func WithMessageBudgets(budgets ...MessageBudget) NewClientOption {
+	return func(o *Options) {
+		o.messageBudgets = budgets
+	}
+}
+
+// WithBudgetWarning sets the utilization of a message budget, between 0 and
+// 1, past which a BudgetWarning is emitted, DefaultBudgetWarning by default.
+func WithBudgetWarning(threshold float64) NewClientOption {
+	return func(o *Options) {
+		o.budgetWarning = threshold
+	}
+}
+
+// budgetMeters count the messages sent against each message budget.
+type budgetMeters struct {
+	mu     sync.Mutex
+	meters []*budgetMeter
+}
+
+type budgetMeter struct {
+	budget MessageBudget
+	sent   []time.Time // oldest first, within the interval
+	warned bool
+}
+
+func newBudgetMeters(budgets []MessageBudget) *budgetMeters {
+	m := &budgetMeters{}
+	for _, budget := range budgets {
+		if budget.Max > 0 && budget.Interval > 0 {
+			m.meters = append(m.meters, &budgetMeter{budget: budget})
+		}
+	}
+	return m
+}
+
+// expire forgets the messages sent before the interval ending at now. The
+// caller holds the lock.
+func (b *budgetMeter) expire(now time.Time) {
+	cutoff := now.Add(-b.budget.Interval)
+	i := 0
+	for i < len(b.sent) && !b.sent[i].After(cutoff) {
+		i++
+	}
+	b.sent = b.sent[i:]
+}
+
+func (b *budgetMeter) usage() BudgetUsage {
+	return BudgetUsage{
+		MessageBudget: b.budget,
+		Count:         len(b.sent),
+		Utilization:   float64(len(b.sent)) / float64(b.budget.Max),
+	}
+}
+
+// countSent counts an outgoing message against the message budgets.
+func (c *Client) countSent(msg *quickfix.Message) {
+	if c.budgets == nil || len(c.budgets.meters) == 0 {
+		return
+	}
+	orders := ordersIn(msg)
+	threshold := c.options.budgetWarning
+	now := c.now()
+
+	var warnings []*BudgetWarning
+	c.budgets.mu.Lock()
+	for _, b := range c.budgets.meters {
+		n := 1
+		if b.budget.OrdersOnly {
+			n = orders
+		}
+		for range n {
+			b.sent = append(b.sent, now)
+		}
+		b.expire(now)
+
+		u := b.usage()
+		switch {
+		case u.Utilization >= threshold && !b.warned:
+			b.warned = true
+			warnings = append(warnings, &BudgetWarning{BudgetUsage: u, Threshold: threshold})
+		case u.Utilization < threshold:
+			b.warned = false
+		}
+	}
+	c.budgets.mu.Unlock()
+
+	for _, w := range warnings {
+		c.logger().Warnw("Message budget nearly used up", "budget", w.Name, "count", w.Count, "max", w.Max)
+		c.emit(BudgetWarningTopic, w)
+	}
+}
+
+// ordersIn returns the number of new orders msg places.
+func ordersIn(msg *quickfix.Message) int {
+	msgType, err := msg.MsgType()
+	if err != nil {
+		return 0
+	}
+	switch enum.MsgType(msgType) {
+	case enum.MsgType_ORDER_SINGLE, enum.MsgType_ORDER_CANCEL_REPLACE_REQUEST:
+		return 1
+	case enum.MsgType_ORDER_LIST:
+		if n, err := msg.Body.GetInt(tag.NoOrders); err == nil {
+			return n
+		}
+		return 1
+	}
+	return 0
+}
+
+// Usage returns how much of each message budget the session used within its
+// interval, counted locally as messages are sent. See WithMessageBudgets.
+func (c *Client) Usage() []BudgetUsage {
+	if c.budgets == nil {
+		return nil
+	}
+	now := c.now()
+
+	c.budgets.mu.Lock()
+	defer c.budgets.mu.Unlock()
+	usage := make([]BudgetUsage, 0, len(c.budgets.meters))
+	for _, b := range c.budgets.meters {
+		b.expire(now)
+		usage = append(usage, b.usage())
+	}
+	return usage
+}
@@ -0,0 +1,179 @@
+package fix
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"runtime/debug"
+	"strconv"
+
+	"github.com/ljm2ya/binance_fix_api/handlers"
+)
+
+// callbackQueueSize is how many events wait for a free callback worker before
+// the session's goroutine blocks on the next one.
+const callbackQueueSize = 1024
+
+// CallbackPanic is a panic recovered from a subscribed callback.
+type CallbackPanic struct {
+	Topic string
+	Err   error // the panic value
+	Stack []byte
+}
+
+func (p *CallbackPanic) Error() string {
+	return fmt.Sprintf("callback for %s panicked: %v", p.Topic, p.Err)
+}
+
+// WithCallbackWorkers runs subscribed callbacks on n workers instead of the
+// goroutine processing the session's messages, so a slow callback doesn't hold
+// up the session. Events are taken in order but callbacks of consecutive
+// events may run concurrently with n > 1, except for the execution reports of
+// an order: they are all run by the same worker in the order received, so a
+// FILLED is never observed before its NEW. Workers run from Start to Stop;
+// events emitted while the client is stopped are delivered on the emitting
+// goroutine.
+func WithCallbackWorkers(n int) NewClientOption {
+	return func(o *Options) {
+		o.callbackWorkers = n
+	}
+}
+
+// WithCallbackPanicHandler calls handler with every panic recovered from a
+// callback, which is otherwise only logged. Panics never reach the session.
+func WithCallbackPanicHandler(handler func(p *CallbackPanic)) NewClientOption {
+	return func(o *Options) {
+		o.callbackPanicHandler = handler
+	}
+}
+
+// callbackPool runs the dispatch of events on a fixed number of workers.
+type callbackPool struct {
+	workers int
+	jobs    chan func()
+	// keyed are the queues of the workers for jobs that run in order of
+	// their key, see dispatchKeyed.
+	keyed []chan func()
+}
+
+func newCallbackPool(workers int) *callbackPool {
+	p := &callbackPool{
+		workers: workers,
+		jobs:    make(chan func(), callbackQueueSize),
+		keyed:   make([]chan func(), workers),
+	}
+	for i := range p.keyed {
+		p.keyed[i] = make(chan func(), callbackQueueSize)
+	}
+	return p
+}
+
+// queue returns the queue of the worker running the jobs of key.
+func (p *callbackPool) queue(key string) chan func() {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return p.keyed[h.Sum32()%uint32(len(p.keyed))]
+}
+
+// start runs the workers until ctx is done.
+func (p *callbackPool) start(ctx context.Context) {
+	for i := range p.workers {
+		go p.work(ctx, p.keyed[i])
+	}
+}
+
+func (p *callbackPool) work(ctx context.Context, keyed chan func()) {
+	for {
+		select {
+		case job := <-p.jobs:
+			job()
+		case job := <-keyed:
+			job()
+		case <-ctx.Done():
+			// Events queued before the client stopped are still delivered.
+			for {
+				select {
+				case job := <-p.jobs:
+					job()
+				case job := <-keyed:
+					job()
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// emit notifies the subscribers of topic, on the callback workers if any.
+func (c *Client) emit(topic string, args ...interface{}) {
+	c.dispatch(topic, func() { c.emitter.Emit(topic, args...) })
+}
+
+// emitKeyed is emit for events that must reach subscribers in the order
+// emitted among the events of the same key.
+func (c *Client) emitKeyed(topic, key string, args ...interface{}) {
+	c.dispatchKeyed(topic, key, func() { c.emitter.Emit(topic, args...) })
+}
+
+// dispatch runs fn, which calls the callbacks of topic, on the callback
+// workers if any, and recovers from their panics.
+func (c *Client) dispatch(topic string, fn func()) {
+	c.dispatchKeyed(topic, "", fn)
+}
+
+// dispatchKeyed is dispatch running the jobs of a non-empty key one after the
+// other on the same worker, in the order dispatched.
+func (c *Client) dispatchKeyed(topic, key string, fn func()) {
+	job := func() {
+		defer func() {
+			if r := recover(); r != nil {
+				c.reportPanic(topic, fmt.Errorf("%v", r))
+			}
+		}()
+		fn()
+	}
+
+	if c.callbacks == nil {
+		job()
+		return
+	}
+	root := c.Context()
+	if root.Err() != nil {
+		job()
+		return
+	}
+	jobs := c.callbacks.jobs
+	if key != "" {
+		jobs = c.callbacks.queue(key)
+	}
+	select {
+	case jobs <- job:
+	case <-root.Done():
+		job()
+	}
+}
+
+// recoverListener is the emitter's RecoveryListener. It runs in the deferred
+// recovery of the panicking listener, so the stack still shows the panic.
+func (c *Client) recoverListener(event, _ interface{}, err error) {
+	c.reportPanic(fmt.Sprint(event), err)
+}
+
+func (c *Client) reportPanic(topic string, err error) {
+	p := &CallbackPanic{Topic: topic, Err: err, Stack: debug.Stack()}
+	c.logger().Errorw("Callback panicked", "topic", topic, "err", err, "stack", string(p.Stack))
+	if handler := c.options.callbackPanicHandler; handler != nil {
+		handler(p)
+	}
+}
+
+// orderKey identifies the order of an execution report for dispatchKeyed: its
+// OrderID, which stays the same when the order is canceled or amended under a
+// new ClOrdID, or its ClOrdID when it has none, e.g. when rejected.
+func orderKey(o *handlers.Order) string {
+	if o.OrderID > 0 {
+		return o.Symbol + "/" + strconv.FormatInt(o.OrderID, 10)
+	}
+	return o.ClientOrderID
+}
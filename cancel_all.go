@@ -0,0 +1,96 @@
+package fix
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+
+	"github.com/ljm2ya/binance_fix_api/handlers"
+)
+
+// MassCancelReject is returned when the exchange rejects an
+// OrderMassCancelRequest <q>.
+type MassCancelReject struct {
+	Symbol    string
+	Reason    string // MassCancelRejectReason <532>
+	ErrorCode string // ErrorCode <25016>
+	Text      string
+	// RetryAfter is how long the exchange asks to wait before sending again,
+	// see handlers.ParseRetryAfter.
+	RetryAfter time.Duration
+}
+
+func (r *MassCancelReject) Error() string {
+	return fmt.Sprintf("mass cancel of %s rejected: %s (reason %s)", r.Symbol, r.Text, r.Reason)
+}
+
+// CancelAllAndWait cancels all open orders of symbol with an
+// OrderMassCancelRequest <q> and waits until every order the client knows to
+// be open reaches a terminal state, e.g. for a clean strategy shutdown. The
+// client learns about open orders from the execution reports of the session.
+// When ctx is done first, the orders still open are returned with ctx.Err().
+func (c *Client) CancelAllAndWait(ctx context.Context, symbol string) ([]handlers.Order, error) {
+	id, err := c.resolveClOrdID("")
+	if err != nil {
+		return nil, err
+	}
+
+	msg := quickfix.NewMessage()
+	msg.Header.Set(field.NewMsgType(enum.MsgType_ORDER_MASS_CANCEL_REQUEST))
+	msg.Body.Set(field.NewClOrdID(id))
+	msg.Body.Set(field.NewSymbol(symbol))
+	msg.Body.Set(field.NewMassCancelRequestType(enum.MassCancelRequestType_CANCEL_ORDERS_FOR_A_SECURITY))
+
+	if _, err := CallAndDecode(ctx, c, id, msg, func(msg *quickfix.Message) (int, error) {
+		return decodeMassCancelReport(msg, c.now())
+	}); err != nil {
+		orders, _ := c.openOrders.open(symbol)
+		return orders, err
+	}
+
+	for {
+		orders, changed := c.openOrders.open(symbol)
+		if len(orders) == 0 {
+			return nil, nil
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return orders, ctx.Err()
+		}
+	}
+}
+
+// decodeMassCancelReport decodes an OrderMassCancelReport <r>, returning a
+// rejection as a *MassCancelReject error with its RetryAfter relative to now.
+func decodeMassCancelReport(msg *quickfix.Message, now time.Time) (int, error) {
+	msgType, err := msg.MsgType()
+	if err != nil {
+		return 0, err
+	}
+	if enum.MsgType(msgType) != enum.MsgType_ORDER_MASS_CANCEL_REPORT {
+		return 0, fmt.Errorf("%w: %s", ErrUnexpectedMsgType, msgType)
+	}
+
+	response, err := msg.Body.GetString(tag.MassCancelResponse)
+	if err != nil {
+		return 0, err
+	}
+	if enum.MassCancelResponse(response) == enum.MassCancelResponse_CANCEL_REQUEST_REJECTED {
+		reject := &MassCancelReject{}
+		reject.Symbol, _ = msg.Body.GetString(tag.Symbol)
+		reject.Reason, _ = msg.Body.GetString(tag.MassCancelRejectReason)
+		reject.ErrorCode, _ = msg.Body.GetString(tagErrorCode)
+		reject.Text, _ = msg.Body.GetString(tag.Text)
+		reject.RetryAfter = handlers.ParseRetryAfter(reject.ErrorCode, reject.Text, now)
+		return 0, reject
+	}
+
+	affected, _ := msg.Body.GetInt(tag.TotalAffectedOrders)
+	return affected, nil
+}
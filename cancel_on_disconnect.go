@@ -0,0 +1,196 @@
+package fix
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+
+	"github.com/ljm2ya/binance_fix_api/handlers"
+)
+
+// WithCancelOnDisconnect purges the open orders of the account when the
+// session drops. Binance's Logon has no cancel-on-disconnect instruction, so
+// the client tracks the symbols with open orders from execution reports and
+// sends an OrderMassCancelRequest <q> for each of them as soon as the session
+// is logged on again, before Start returns or new orders can be placed.
+// Orders stay live while the session is down.
+func WithCancelOnDisconnect() NewClientOption {
+	return func(o *Options) {
+		o.cancelOnDisconnect = true
+	}
+}
+
+// openOrders tracks open orders by symbol and OrderID.
+type openOrders struct {
+	mu       sync.Mutex
+	bySymbol map[string]map[string]handlers.Order
+	purge    []string      // symbols to mass cancel at the next logon
+	changed  chan struct{} // closed and replaced on every update
+}
+
+func newOpenOrders() *openOrders {
+	return &openOrders{
+		bySymbol: make(map[string]map[string]handlers.Order),
+		changed:  make(chan struct{}),
+	}
+}
+
+// openOrderStatus are the statuses of orders that can still fill.
+var openOrderStatus = map[enum.OrdStatus]handlers.OrderStatus{
+	enum.OrdStatus_NEW:              handlers.OrderStatusNew,
+	enum.OrdStatus_PARTIALLY_FILLED: handlers.OrderStatusPartiallyFilled,
+	enum.OrdStatus_PENDING_NEW:      handlers.OrderStatusPendingNew,
+	enum.OrdStatus_PENDING_CANCEL:   handlers.OrderStatusPendingCancel,
+}
+
+// observe updates the open orders from an execution report.
+func (o *openOrders) observe(msg *quickfix.Message) {
+	symbol, err := msg.Body.GetString(tag.Symbol)
+	if err != nil {
+		return
+	}
+	orderID, err := msg.Body.GetString(tag.OrderID)
+	if err != nil {
+		return
+	}
+	status, err := msg.Body.GetString(tag.OrdStatus)
+	if err != nil {
+		return
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if status, ok := openOrderStatus[enum.OrdStatus(status)]; ok {
+		if o.bySymbol[symbol] == nil {
+			o.bySymbol[symbol] = make(map[string]handlers.Order)
+		}
+		order := handlers.Order{Symbol: symbol, Status: status}
+		order.OrderID, _ = strconv.ParseInt(orderID, 10, 64)
+		order.ClientOrderID, _ = msg.Body.GetString(tag.ClOrdID)
+		if side, err := msg.Body.GetString(tag.Side); err == nil {
+			order.Side = handlers.SideType(mappedSideType[enum.Side(side)])
+		}
+		if cumQty, err := msg.Body.GetString(tag.CumQty); err == nil {
+			order.CumQty, _ = strconv.ParseFloat(cumQty, 64)
+		}
+		o.bySymbol[symbol][orderID] = order
+	} else {
+		delete(o.bySymbol[symbol], orderID)
+		if len(o.bySymbol[symbol]) == 0 {
+			delete(o.bySymbol, symbol)
+		}
+	}
+	close(o.changed)
+	o.changed = make(chan struct{})
+}
+
+// open returns the open orders of symbol and a channel closed on the next
+// update.
+func (o *openOrders) open(symbol string) ([]handlers.Order, <-chan struct{}) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	orders := make([]handlers.Order, 0, len(o.bySymbol[symbol]))
+	for _, order := range o.bySymbol[symbol] {
+		orders = append(orders, order)
+	}
+	return orders, o.changed
+}
+
+// all returns every open order.
+func (o *openOrders) all() []handlers.Order {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var orders []handlers.Order
+	for _, bySymbol := range o.bySymbol {
+		for _, order := range bySymbol {
+			orders = append(orders, order)
+		}
+	}
+	return orders
+}
+
+// drop forgets an order the exchange doesn't know.
+func (o *openOrders) drop(order handlers.Order) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	delete(o.bySymbol[order.Symbol], strconv.FormatInt(order.OrderID, 10))
+	if len(o.bySymbol[order.Symbol]) == 0 {
+		delete(o.bySymbol, order.Symbol)
+	}
+	close(o.changed)
+	o.changed = make(chan struct{})
+}
+
+// disconnected marks the symbols with open orders for purging.
+func (o *openOrders) disconnected() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for symbol := range o.bySymbol {
+		o.purge = append(o.purge, symbol)
+	}
+	o.bySymbol = make(map[string]map[string]handlers.Order)
+}
+
+// restore replaces the open orders with a checkpoint.
+func (o *openOrders) restore(state openOrdersState) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.bySymbol = make(map[string]map[string]handlers.Order)
+	for _, s := range state.Orders {
+		if o.bySymbol[s.Symbol] == nil {
+			o.bySymbol[s.Symbol] = make(map[string]handlers.Order)
+		}
+		o.bySymbol[s.Symbol][strconv.FormatInt(s.OrderID, 10)] = handlers.Order{
+			Symbol:        s.Symbol,
+			OrderID:       s.OrderID,
+			ClientOrderID: s.ClientOrderID,
+			Status:        s.Status,
+			Side:          s.Side,
+			CumQty:        s.CumQty,
+		}
+	}
+	o.purge = state.Purge
+	close(o.changed)
+	o.changed = make(chan struct{})
+}
+
+func (o *openOrders) takePurge() []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	symbols := o.purge
+	o.purge = nil
+	return symbols
+}
+
+// purgeOpenOrders mass cancels the symbols that had open orders when the
+// previous session dropped.
+func (c *Client) purgeOpenOrders() {
+	for _, symbol := range c.openOrders.takePurge() {
+		id, err := c.resolveClOrdID("")
+		if err != nil {
+			c.logger().Errorw("Failed to generate ClOrdID for mass cancel", "symbol", symbol, "err", err)
+			continue
+		}
+
+		msg := quickfix.NewMessage()
+		msg.Header.Set(field.NewMsgType(enum.MsgType_ORDER_MASS_CANCEL_REQUEST))
+		msg.Body.Set(field.NewClOrdID(id))
+		msg.Body.Set(field.NewSymbol(symbol))
+		msg.Body.Set(field.NewMassCancelRequestType(enum.MassCancelRequestType_CANCEL_ORDERS_FOR_A_SECURITY))
+
+		if err := c.SendWithoutResponse(msg); err != nil {
+			c.logger().Errorw("Failed to cancel open orders after disconnect", "symbol", symbol, "err", err)
+		}
+	}
+}
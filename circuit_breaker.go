@@ -0,0 +1,107 @@
+package fix
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+var ErrCircuitOpen = errors.New("order submission blocked after consecutive rejects")
+
+// CircuitOpen is emitted when the circuit breaker starts blocking orders.
+type CircuitOpen struct {
+	Rejects    int       // consecutive rejects that tripped the breaker
+	LastReason string    // Text of the last reject
+	Until      time.Time // end of the cool-down
+}
+
+// WithCircuitBreaker blocks new orders with ErrCircuitOpen for cooldown after
+// threshold consecutive order rejects or BusinessMessageRejects. After the
+// cool-down a single order is let through: an accepted order closes the
+// breaker again, another reject reopens it right away.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) NewClientOption {
+	return func(o *Options) {
+		o.breakerThreshold = threshold
+		o.breakerCooldown = cooldown
+	}
+}
+
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	rejects   int
+	openUntil time.Time
+	halfOpen  bool
+}
+
+// allow reports whether a new order may be sent.
+func (b *circuitBreaker) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.openUntil.IsZero() {
+		return true
+	}
+	if now.Before(b.openUntil) {
+		return false
+	}
+	b.openUntil = time.Time{}
+	b.halfOpen = true
+	return true
+}
+
+// success records an accepted order.
+func (b *circuitBreaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.rejects = 0
+	b.halfOpen = false
+}
+
+// reject records a reject and returns the event to emit when it opened the
+// breaker.
+func (b *circuitBreaker) reject(now time.Time, reason string) *CircuitOpen {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.rejects++
+	if !b.openUntil.IsZero() || (!b.halfOpen && b.rejects < b.threshold) {
+		return nil
+	}
+	b.halfOpen = false
+	b.openUntil = now.Add(b.cooldown)
+	return &CircuitOpen{Rejects: b.rejects, LastReason: reason, Until: b.openUntil}
+}
+
+// observeRejects feeds order responses to the circuit breaker.
+func (c *Client) observeRejects(msgType string, msg *quickfix.Message) {
+	if c.breaker == nil {
+		return
+	}
+
+	switch enum.MsgType(msgType) {
+	case enum.MsgType_BUSINESS_MESSAGE_REJECT:
+	case enum.MsgType_EXECUTION_REPORT:
+		status, err := msg.Body.GetString(tag.OrdStatus)
+		if err != nil {
+			return
+		}
+		if enum.OrdStatus(status) != enum.OrdStatus_REJECTED {
+			c.breaker.success()
+			return
+		}
+	default:
+		return
+	}
+
+	reason, _ := msg.Body.GetString(tag.Text)
+	if e := c.breaker.reject(c.now(), reason); e != nil {
+		c.emit(CircuitOpenTopic, e)
+	}
+}
@@ -0,0 +1,115 @@
+package fix
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// driftSmoothing is the weight of a new sample in the drift estimate.
+const driftSmoothing = 0.1
+
+var sendingTimeField = []byte("\x0152=")
+
+// ClockDrift is emitted when the estimated offset between the server clock
+// and the local clock exceeds the tolerance set with WithClockDriftTolerance.
+type ClockDrift struct {
+	Drift     time.Duration // server time minus local time
+	Tolerance time.Duration
+}
+
+// WithClockDriftTolerance reports a ClockDrift event when the local clock is
+// off from the server clock by more than tolerance. With adjust, the
+// SendingTime of outgoing messages, which the logon signature covers, is
+// shifted by the estimated drift so a host with bad NTP can still log on.
+func WithClockDriftTolerance(tolerance time.Duration, adjust bool) NewClientOption {
+	return func(o *Options) {
+		o.driftTolerance = tolerance
+		o.driftAdjust = adjust
+	}
+}
+
+// clockDrift estimates the server clock offset from the SendingTime of
+// inbound messages. The estimate includes the one-way network latency.
+type clockDrift struct {
+	mu       sync.Mutex
+	estimate time.Duration
+	samples  int
+	exceeded bool
+}
+
+// observe adds a sample and reports whether the estimate just crossed
+// tolerance.
+func (d *clockDrift) observe(sendingTime, received time.Time, tolerance time.Duration) (time.Duration, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	offset := sendingTime.Sub(received)
+	if d.samples == 0 {
+		d.estimate = offset
+	} else {
+		d.estimate += time.Duration(driftSmoothing * float64(offset-d.estimate))
+	}
+	d.samples++
+
+	if tolerance <= 0 {
+		return d.estimate, false
+	}
+	over := d.estimate > tolerance || d.estimate < -tolerance
+	crossed := over && !d.exceeded
+	d.exceeded = over
+	return d.estimate, crossed
+}
+
+func (d *clockDrift) get() time.Duration {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.estimate
+}
+
+// ClockDrift returns the estimated offset of the server clock from the local
+// clock, positive when the server is ahead. It is zero until a message has
+// been received.
+func (c *Client) ClockDrift() time.Duration {
+	return c.drift.get()
+}
+
+// observeClock samples the SendingTime of a raw inbound message. Rejected
+// logons are sampled too, so a following logon attempt can be adjusted.
+func (c *Client) observeClock(data []byte, received time.Time) {
+	i := bytes.Index(data, sendingTimeField)
+	if i < 0 {
+		return
+	}
+	value := data[i+len(sendingTimeField):]
+	if end := bytes.IndexByte(value, '\x01'); end >= 0 {
+		value = value[:end]
+	}
+	sendingTime, err := time.Parse("20060102-15:04:05", string(value))
+	if err != nil {
+		return
+	}
+
+	drift, crossed := c.drift.observe(sendingTime, received, c.options.driftTolerance)
+	if crossed {
+		c.logger().Warnw("Local clock drifts from server clock", "drift", drift, "tolerance", c.options.driftTolerance)
+		c.emit(ClockDriftTopic, &ClockDrift{Drift: drift, Tolerance: c.options.driftTolerance})
+	}
+}
+
+// adjustSendingTime shifts the SendingTime quickfix stamped on msg by the
+// estimated drift, when enabled with WithClockDriftTolerance, and takes it
+// from the Clock set with WithClock.
+func (c *Client) adjustSendingTime(msg *quickfix.Message) {
+	var drift time.Duration
+	if c.options.driftAdjust {
+		drift = c.drift.get()
+	}
+	if _, ok := c.options.clock.(systemClock); ok && drift == 0 {
+		return
+	}
+	msg.Header.SetString(tag.SendingTime, c.now().Add(drift).UTC().Format(utcTimestampMillisFmt))
+}
@@ -0,0 +1,147 @@
+package fix
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxClOrdIDLength is the longest ClOrdID accepted by Binance.
+const MaxClOrdIDLength = 36
+
+var (
+	ErrClOrdIDTooLong = fmt.Errorf("ClOrdID exceeds %d characters", MaxClOrdIDLength)
+	// ErrClOrdIDInvalid is returned for a ClOrdID or prefix with characters
+	// outside Binance's ^[a-zA-Z0-9-_]{1,36}$.
+	ErrClOrdIDInvalid = errors.New("ClOrdID may only contain letters, digits, '-' and '_'")
+)
+
+// ClOrdIDGenerator generates ClOrdIDs for orders placed without an explicit one.
+// Implementations must be safe for concurrent use.
+type ClOrdIDGenerator interface {
+	NextClOrdID() (string, error)
+}
+
+// ClOrdIDGeneratorFunc adapts a function to the ClOrdIDGenerator interface.
+type ClOrdIDGeneratorFunc func() (string, error)
+
+func (f ClOrdIDGeneratorFunc) NextClOrdID() (string, error) {
+	return f()
+}
+
+// UUIDClOrdIDGenerator generates random UUIDv4 ClOrdIDs (36 characters).
+type UUIDClOrdIDGenerator struct{}
+
+func (UUIDClOrdIDGenerator) NextClOrdID() (string, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return "", err
+	}
+	return id.String(), nil
+}
+
+// MonotonicClOrdIDGenerator generates prefix + process epoch + counter IDs.
+// The epoch is taken when the generator is created, so IDs stay unique across
+// reconnects of the same process and across process restarts.
+type MonotonicClOrdIDGenerator struct {
+	prefix  string
+	epoch   string
+	counter atomic.Uint64
+}
+
+// NewMonotonicClOrdIDGenerator creates a monotonic generator with an optional prefix.
+func NewMonotonicClOrdIDGenerator(prefix string) (*MonotonicClOrdIDGenerator, error) {
+	if !isClOrdIDChars(prefix) {
+		return nil, ErrClOrdIDInvalid
+	}
+	g := &MonotonicClOrdIDGenerator{
+		prefix: prefix,
+		epoch:  strconv.FormatInt(time.Now().UnixNano(), 36),
+	}
+	// epoch (13) + separator + a generous 10 counter digits must fit.
+	if len(prefix)+len(g.epoch)+11 > MaxClOrdIDLength {
+		return nil, ErrClOrdIDTooLong
+	}
+	return g, nil
+}
+
+func (g *MonotonicClOrdIDGenerator) NextClOrdID() (string, error) {
+	n := g.counter.Add(1)
+	return g.prefix + g.epoch + "-" + strconv.FormatUint(n, 36), nil
+}
+
+// TimestampNonceClOrdIDGenerator generates prefix + timestamp + random nonce IDs,
+// which need no shared state between processes using the same prefix.
+type TimestampNonceClOrdIDGenerator struct {
+	prefix string
+}
+
+// NewTimestampNonceClOrdIDGenerator creates a timestamp+nonce generator.
+func NewTimestampNonceClOrdIDGenerator(prefix string) (*TimestampNonceClOrdIDGenerator, error) {
+	if !isClOrdIDChars(prefix) {
+		return nil, ErrClOrdIDInvalid
+	}
+	// timestamp (13) + separator + nonce (up to 13)
+	if len(prefix)+27 > MaxClOrdIDLength {
+		return nil, ErrClOrdIDTooLong
+	}
+	return &TimestampNonceClOrdIDGenerator{prefix: prefix}, nil
+}
+
+func (g *TimestampNonceClOrdIDGenerator) NextClOrdID() (string, error) {
+	var nonce [8]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return "", err
+	}
+	return g.prefix +
+		strconv.FormatInt(time.Now().UnixNano(), 36) + "-" +
+		strconv.FormatUint(binary.BigEndian.Uint64(nonce[:]), 36), nil
+}
+
+// ValidateClOrdID checks an ID against Binance's ClOrdID constraints,
+// ^[a-zA-Z0-9-_]{1,36}$.
+func ValidateClOrdID(id string) error {
+	if id == "" {
+		return errors.New("empty ClOrdID")
+	}
+	if len(id) > MaxClOrdIDLength {
+		return ErrClOrdIDTooLong
+	}
+	if !isClOrdIDChars(id) {
+		return ErrClOrdIDInvalid
+	}
+	return nil
+}
+
+// isClOrdIDChars reports whether s only has characters allowed in a ClOrdID.
+func isClOrdIDChars(s string) bool {
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', c == '-', c == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// resolveClOrdID validates a caller supplied ClOrdID, or generates one with
+// the client's generator when id is empty.
+func (c *Client) resolveClOrdID(id string) (string, error) {
+	if id == "" {
+		var err error
+		if id, err = c.options.clOrdIDGenerator.NextClOrdID(); err != nil {
+			return "", err
+		}
+	}
+	if err := ValidateClOrdID(id); err != nil {
+		return "", err
+	}
+	return id, nil
+}
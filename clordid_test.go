@@ -0,0 +1,62 @@
+package fix
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateClOrdID(t *testing.T) {
+	for _, tc := range []struct {
+		id   string
+		want error
+	}{
+		{id: "order-1_A"},
+		{id: strings.Repeat("a", MaxClOrdIDLength)},
+		{id: strings.Repeat("a", MaxClOrdIDLength+1), want: ErrClOrdIDTooLong},
+		{id: "order 1", want: ErrClOrdIDInvalid},
+		{id: "order.1", want: ErrClOrdIDInvalid},
+		{id: "ordér", want: ErrClOrdIDInvalid},
+	} {
+		if err := ValidateClOrdID(tc.id); !errors.Is(err, tc.want) {
+			t.Errorf("ValidateClOrdID(%q) = %v, want %v", tc.id, err, tc.want)
+		}
+	}
+	if err := ValidateClOrdID(""); err == nil {
+		t.Error("empty ClOrdID accepted")
+	}
+}
+
+func TestClOrdIDPrefixCharset(t *testing.T) {
+	for _, prefix := range []string{"bot 1", "bot/1", "bot|"} {
+		o := defaultOpts()
+		o.clOrdIDPrefix = prefix
+		if err := o.applyClOrdIDPrefix(); !errors.Is(err, ErrClOrdIDInvalid) {
+			t.Errorf("prefix %q: %v, want ErrClOrdIDInvalid", prefix, err)
+		}
+
+		o = defaultOpts()
+		o.clOrdIDGenerator = ClOrdIDGeneratorFunc(func() (string, error) { return "1", nil })
+		o.clOrdIDPrefix = prefix
+		if err := o.applyClOrdIDPrefix(); !errors.Is(err, ErrClOrdIDInvalid) {
+			t.Errorf("prefix %q on a custom generator: %v, want ErrClOrdIDInvalid", prefix, err)
+		}
+
+		if _, err := NewMonotonicClOrdIDGenerator(prefix); !errors.Is(err, ErrClOrdIDInvalid) {
+			t.Errorf("monotonic generator with prefix %q: %v, want ErrClOrdIDInvalid", prefix, err)
+		}
+	}
+
+	o := defaultOpts()
+	o.clOrdIDPrefix = "bot-1_"
+	if err := o.applyClOrdIDPrefix(); err != nil {
+		t.Fatal(err)
+	}
+	id, err := o.clOrdIDGenerator.NextClOrdID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(id, "bot-1_") || ValidateClOrdID(id) != nil {
+		t.Fatalf("generated %q", id)
+	}
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/quickfixgo/enum"
+
+	fix "github.com/ljm2ya/binance_fix_api"
+	"github.com/ljm2ya/binance_fix_api/handlers"
+)
+
+func orderEntry() fix.EndpointType { return fix.OrderEntryEndpoint }
+func marketData() fix.EndpointType { return fix.MarketDataEndpoint }
+
+var logonCmd = &command{
+	name:    "logon",
+	summary: "log on, print the session health and log out",
+	endpoint: func() fix.EndpointType {
+		if logonFlags.marketData {
+			return fix.MarketDataEndpoint
+		}
+		return fix.OrderEntryEndpoint
+	},
+	flags: func(fs *flag.FlagSet) {
+		fs.BoolVar(&logonFlags.marketData, "md", false, "log on to the market data endpoint instead of order entry")
+	},
+	run: func(_ context.Context, client *fix.Client) error {
+		return printJSON(client.Health())
+	},
+}
+
+var logonFlags struct {
+	marketData bool
+}
+
+var orderFlags struct {
+	symbol      string
+	side        string
+	orderType   string
+	timeInForce string
+	quantity    float64
+	price       float64
+	clOrdID     string
+}
+
+var sides = map[string]enum.Side{
+	"buy":  enum.Side_BUY,
+	"sell": enum.Side_SELL,
+}
+
+var orderTypes = map[string]enum.OrdType{
+	"market": enum.OrdType_MARKET,
+	"limit":  enum.OrdType_LIMIT,
+}
+
+var timesInForce = map[string]enum.TimeInForce{
+	"gtc": enum.TimeInForce_GOOD_TILL_CANCEL,
+	"ioc": enum.TimeInForce_IMMEDIATE_OR_CANCEL,
+	"fok": enum.TimeInForce_FILL_OR_KILL,
+}
+
+var orderCmd = &command{
+	name:     "order",
+	summary:  "place an order and print the execution report",
+	endpoint: orderEntry,
+	flags: func(fs *flag.FlagSet) {
+		fs.StringVar(&orderFlags.symbol, "symbol", "", "symbol, e.g. BTCUSDT")
+		fs.StringVar(&orderFlags.side, "side", "", "buy or sell")
+		fs.StringVar(&orderFlags.orderType, "type", "limit", "limit or market")
+		fs.StringVar(&orderFlags.timeInForce, "tif", "gtc", "time in force of limit orders: gtc, ioc or fok")
+		fs.Float64Var(&orderFlags.quantity, "qty", 0, "quantity")
+		fs.Float64Var(&orderFlags.price, "price", 0, "limit price")
+		fs.StringVar(&orderFlags.clOrdID, "clordid", "", "ClOrdID, generated when empty")
+	},
+	run: func(ctx context.Context, client *fix.Client) error {
+		side, ok := sides[strings.ToLower(orderFlags.side)]
+		if !ok {
+			return fmt.Errorf("invalid -side %q", orderFlags.side)
+		}
+		orderType, ok := orderTypes[strings.ToLower(orderFlags.orderType)]
+		if !ok {
+			return fmt.Errorf("invalid -type %q", orderFlags.orderType)
+		}
+
+		s := client.NewOrderSingleService().
+			Symbol(orderFlags.symbol).
+			Side(side).
+			Type(orderType).
+			Quantity(orderFlags.quantity)
+		if orderFlags.clOrdID != "" {
+			s.ClOrdID(orderFlags.clOrdID)
+		}
+		if orderType == enum.OrdType_LIMIT {
+			tif, ok := timesInForce[strings.ToLower(orderFlags.timeInForce)]
+			if !ok {
+				return fmt.Errorf("invalid -tif %q", orderFlags.timeInForce)
+			}
+			s.TimeInForce(tif).Price(orderFlags.price)
+		}
+
+		order, err := s.Do(ctx)
+		return printOrder(order, err)
+	},
+}
+
+var cancelFlags struct {
+	symbol      string
+	origClOrdID string
+	orderID     int64
+}
+
+var cancelCmd = &command{
+	name:     "cancel",
+	summary:  "cancel an order and print the execution report",
+	endpoint: orderEntry,
+	flags: func(fs *flag.FlagSet) {
+		fs.StringVar(&cancelFlags.symbol, "symbol", "", "symbol of the order")
+		fs.StringVar(&cancelFlags.origClOrdID, "clordid", "", "ClOrdID of the order")
+		fs.Int64Var(&cancelFlags.orderID, "orderid", 0, "OrderID of the order, instead of -clordid")
+	},
+	run: func(ctx context.Context, client *fix.Client) error {
+		if cancelFlags.origClOrdID == "" && cancelFlags.orderID == 0 {
+			return errors.New("-clordid or -orderid is required")
+		}
+		s := client.NewOrderCancelService().Symbol(cancelFlags.symbol)
+		if cancelFlags.origClOrdID != "" {
+			s.OrigClOrdID(cancelFlags.origClOrdID)
+		}
+		if cancelFlags.orderID != 0 {
+			s.OrderID(cancelFlags.orderID)
+		}
+
+		order, err := s.Do(ctx)
+		return printOrder(order, err)
+	},
+}
+
+// printOrder prints the order, or the order of a rejection along with the
+// error.
+func printOrder(order handlers.Order, err error) error {
+	var reject *handlers.OrderReject
+	if errors.As(err, &reject) {
+		order = reject.Order
+	}
+	if err != nil && reject == nil {
+		return err
+	}
+	if perr := printJSON(order); perr != nil {
+		return perr
+	}
+	return err
+}
+
+var tradesFlags struct {
+	symbols  string
+	duration time.Duration
+}
+
+var tradesCmd = &command{
+	name:     "trades",
+	summary:  "subscribe to the trades of symbols and print them",
+	endpoint: marketData,
+	flags: func(fs *flag.FlagSet) {
+		fs.StringVar(&tradesFlags.symbols, "symbols", "", "comma-separated symbols, e.g. BTCUSDT,ETHUSDT")
+		fs.DurationVar(&tradesFlags.duration, "for", 0, "stop after this long, at Ctrl-C otherwise")
+	},
+	run: func(ctx context.Context, client *fix.Client) error {
+		symbols := strings.Split(tradesFlags.symbols, ",")
+		if tradesFlags.symbols == "" {
+			return errors.New("-symbols is required")
+		}
+		if tradesFlags.duration > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, tradesFlags.duration)
+			defer cancel()
+		}
+
+		client.SubscribeToTradeStream(func(trade *handlers.Trade) {
+			_ = printJSON(trade)
+		})
+		if err := client.SubscribeToTrades(ctx, symbols); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-client.WaitForDisconnect():
+			return errors.New("disconnected")
+		}
+	},
+}
+
+var limitsCmd = &command{
+	name:     "limits",
+	summary:  "query the rate limits of the account",
+	endpoint: orderEntry,
+	run: func(ctx context.Context, client *fix.Client) error {
+		limits, err := client.NewGetLimitService().Do(ctx)
+		if err != nil {
+			return err
+		}
+		return printJSON(limits)
+	},
+}
@@ -0,0 +1,131 @@
+// Command binance-fix checks connectivity and credentials against the Binance
+// FIX API from a shell.
+//
+// Usage:
+//
+//	binance-fix <command> [flags]
+//
+// The commands are:
+//
+//	logon    log on, print the session health and log out
+//	order    place an order and print the execution report
+//	cancel   cancel an order and print the execution report
+//	trades   subscribe to the trades of symbols and print them
+//	limits   query the rate limits of the account
+//
+// The API key and the Ed25519 private key are read from -api-key and -key, or
+// from the BINANCE_API_KEY and BINANCE_PRIVATE_KEY_FILE environment variables.
+// Output is JSON, one object per line.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"go.uber.org/zap"
+
+	fix "github.com/ljm2ya/binance_fix_api"
+)
+
+type command struct {
+	name     string
+	summary  string
+	endpoint func() fix.EndpointType // read after the flags are parsed
+	flags    func(fs *flag.FlagSet)
+	run      func(ctx context.Context, client *fix.Client) error
+}
+
+var commands = []*command{logonCmd, orderCmd, cancelCmd, tradesCmd, limitsCmd}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: binance-fix <command> [flags]\n\nCommands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-8s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintf(os.Stderr, "\nRun binance-fix <command> -h for the flags of a command.\n")
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	var cmd *command
+	for _, c := range commands {
+		if c.name == os.Args[1] {
+			cmd = c
+		}
+	}
+	if cmd == nil {
+		if os.Args[1] != "-h" && os.Args[1] != "help" {
+			fmt.Fprintf(os.Stderr, "binance-fix: unknown command %q\n\n", os.Args[1])
+		}
+		usage()
+		os.Exit(2)
+	}
+
+	if err := runCommand(cmd, os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "binance-fix %s: %v\n", cmd.name, err)
+		os.Exit(1)
+	}
+}
+
+func runCommand(cmd *command, args []string) error {
+	fs := flag.NewFlagSet(cmd.name, flag.ExitOnError)
+	apiKey := fs.String("api-key", os.Getenv("BINANCE_API_KEY"), "API key")
+	keyFile := fs.String("key", os.Getenv("BINANCE_PRIVATE_KEY_FILE"), "Ed25519 private key PEM file")
+	settingsFile := fs.String("settings", "", "QuickFIX settings file, instead of the default Binance endpoint")
+	timeout := fs.Duration("timeout", 10*time.Second, "timeout of the logon and of each request")
+	verbose := fs.Bool("v", false, "log the session and the FIX messages to stderr")
+	if cmd.flags != nil {
+		cmd.flags(fs)
+	}
+	_ = fs.Parse(args)
+
+	if *apiKey == "" || *keyFile == "" {
+		return errors.New("-api-key and -key are required")
+	}
+	conf := fix.Config{
+		APIKey:             *apiKey,
+		PrivateKeyFilePath: *keyFile,
+		SettingsFilePath:   *settingsFile,
+		Endpoint:           cmd.endpoint(),
+	}
+
+	opts := []fix.NewClientOption{fix.WithLogonTimeout(*timeout), fix.WithDefaultCallTimeout(*timeout)}
+	if *verbose {
+		logger, err := zap.NewDevelopment()
+		if err != nil {
+			return err
+		}
+		defer logger.Sync()
+		opts = append(opts, fix.WithLogger(fix.NewZapLogger(logger)), fix.WithZapLogFactory(logger.Sugar()))
+	}
+
+	client, err := fix.NewClient(conf, opts...)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := client.Start(ctx); err != nil {
+		return fmt.Errorf("logon: %w", err)
+	}
+	defer client.Stop()
+
+	return cmd.run(ctx, client)
+}
+
+// printJSON writes v to stdout as a line of JSON.
+func printJSON(v any) error {
+	return json.NewEncoder(os.Stdout).Encode(v)
+}
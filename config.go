@@ -1,11 +1,10 @@
 package fix
 
 import (
-	"fmt"
 	"strings"
-	"time"
 
 	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/quickfix/config"
 )
 
 // EndpointType represents the type of FIX endpoint
@@ -14,8 +13,31 @@ type EndpointType string
 const (
 	OrderEntryEndpoint EndpointType = "OE"
 	MarketDataEndpoint EndpointType = "MD"
+	DropCopyEndpoint   EndpointType = "DC"
 )
 
+// senderCompIDPrefixes are the SenderCompID prefixes of generated settings,
+// by which the endpoint type of a session without Config.Endpoint is told.
+var senderCompIDPrefixes = map[EndpointType]string{
+	OrderEntryEndpoint: "BOE",
+	MarketDataEndpoint: "BMD",
+	DropCopyEndpoint:   "BDC",
+}
+
+// sessionEndpoint returns endpoint, or when it isn't set the endpoint type
+// of senderCompID's prefix. Other SenderCompIDs are order entry sessions.
+func sessionEndpoint(endpoint EndpointType, senderCompID string) EndpointType {
+	if endpoint != "" {
+		return endpoint
+	}
+	for e, prefix := range senderCompIDPrefixes {
+		if strings.HasPrefix(senderCompID, prefix) {
+			return e
+		}
+	}
+	return OrderEntryEndpoint
+}
+
 // EndpointConfig contains endpoint-specific configuration
 type EndpointConfig struct {
 	Host           string
@@ -24,6 +46,10 @@ type EndpointConfig struct {
 	TargetCompID   string
 	HeartbeatInt   int
 	ReconnectCount int
+
+	// FailoverAddresses are additional "host:port" gateways tried when Host is
+	// unreachable. Failing gateways are skipped for a growing cooldown.
+	FailoverAddresses []string
 }
 
 // DefaultEndpoints provides default Binance FIX endpoint configurations
@@ -44,73 +70,30 @@ var DefaultEndpoints = map[EndpointType]EndpointConfig{
 		HeartbeatInt:   30,
 		ReconnectCount: 10,
 	},
+	DropCopyEndpoint: {
+		Host:           "fix-dc.binance.com",
+		Port:           9000,
+		SenderCompID:   "BDCWATCH", // BDC + WATCH
+		TargetCompID:   "SPOT",
+		HeartbeatInt:   30,
+		ReconnectCount: 10,
+	},
 }
 
 // GenerateQuickFixSettings creates QuickFIX settings from endpoint config
 // Returns settings and the generated unique SenderCompID
 func GenerateQuickFixSettings(endpoint EndpointType, apiKey string, enableSSL bool) (*quickfix.Settings, string, error) {
-	config, exists := DefaultEndpoints[endpoint]
-	if !exists {
-		return nil, "", fmt.Errorf("unknown endpoint type: %s", endpoint)
-	}
-
-	// Generate unique SenderCompID to avoid duplicate session ID on reconnection
-	// Must match regex: ^[a-zA-Z0-9-_]{1,8}$
-	// Use last 4 digits of timestamp to keep it short
-	timestamp := time.Now().UnixNano() / 1e6 // milliseconds
-	shortTimestamp := timestamp % 10000 // Last 4 digits
-	
-	// For MD endpoint, use "BMD" prefix (3 chars) + 4 digits = 7 chars total
-	// For OE endpoint, use "BOE" prefix (3 chars) + 4 digits = 7 chars total
-	prefix := "BMD"
-	if endpoint == OrderEntryEndpoint {
-		prefix = "BOE"
-	}
-	
-	uniqueSenderCompID := fmt.Sprintf("%s%04d", prefix, shortTimestamp)
-
-	// Build settings string
-	var settingsBuilder strings.Builder
-
-	// Default section
-	settingsBuilder.WriteString("[DEFAULT]\n")
-	settingsBuilder.WriteString("BeginString=FIX.4.4\n")
-	settingsBuilder.WriteString(fmt.Sprintf("SocketConnectHost=%s\n", config.Host))
-	settingsBuilder.WriteString(fmt.Sprintf("SocketConnectPort=%d\n", config.Port))
-	settingsBuilder.WriteString(fmt.Sprintf("HeartBtInt=%d\n", config.HeartbeatInt))
-	settingsBuilder.WriteString(fmt.Sprintf("SenderCompID=%s\n", uniqueSenderCompID))
-	settingsBuilder.WriteString(fmt.Sprintf("TargetCompID=%s\n", config.TargetCompID))
-	settingsBuilder.WriteString("ConnectionType=initiator\n")
-	//settingsBuilder.WriteString("ReconnectInterval=5\n")
-	//settingsBuilder.WriteString("LogonTimeout=10\n")
-	//settingsBuilder.WriteString("StartTime=00:00:00\n")
-	//settingsBuilder.WriteString("EndTime=00:00:00\n")
-	//settingsBuilder.WriteString("UseDataDictionary=N\n")
-	//settingsBuilder.WriteString("ResetOnLogon=Y\n")
-	//settingsBuilder.WriteString("ResetOnLogout=Y\n")
-	//settingsBuilder.WriteString("ResetOnDisconnect=Y\n")
-	//if config.ReconnectCount > 0 {
-	//settingsBuilder.WriteString(fmt.Sprintf("MaxReconnectAttempts=%d\n", config.ReconnectCount))
-	//}
-	if enableSSL {
-		settingsBuilder.WriteString("SocketUseSSL=Y\n")
-		//settingsBuilder.WriteString("ValidateCertificates=Y\n")
+	settings, err := NewSettingsBuilder(endpoint).SSL(enableSSL).Build()
+	if err != nil {
+		return nil, "", err
 	}
-	settingsBuilder.WriteString("\n")
-
-	// Session section
-	settingsBuilder.WriteString("[SESSION]\n")
-	//settingsBuilder.WriteString("BeginString=FIX.4.4\n")
-	//settingsBuilder.WriteString(fmt.Sprintf("SenderCompID=%s\n", apiKey))
-	//settingsBuilder.WriteString(fmt.Sprintf("TargetCompID=%s\n", config.TargetCompID))
 
-	// Create settings from string
-	settings, err := quickfix.ParseSettings(strings.NewReader(settingsBuilder.String()))
+	senderCompID, err := settings.GlobalSettings().Setting(config.SenderCompID)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to parse settings: %w", err)
+		return nil, "", err
 	}
 
-	return settings, uniqueSenderCompID, nil
+	return settings, senderCompID, nil
 }
 
 // ConnectionConfig holds configuration for a FIX connection
@@ -0,0 +1,181 @@
+package fix
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/quickfix/config"
+)
+
+// ConfigError is returned by Config.Validate and NewClient with every problem
+// found in a Config, so they can all be fixed at once.
+type ConfigError struct {
+	Errors []error
+}
+
+func (e *ConfigError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return "invalid config: " + strings.Join(msgs, "; ")
+}
+
+// Unwrap lets errors.Is and errors.As see the problems, e.g.
+// ErrInvalidEd25519Key.
+func (e *ConfigError) Unwrap() []error {
+	return e.Errors
+}
+
+// Validate checks c before it is used by NewClient, which otherwise fails
+// late with quickfix errors: the API key is set and can be sent in a FIX
+// field, the private key parses as an Ed25519 key, Endpoint is a known
+// endpoint type and the Settings, if any, or those of SettingsFilePath are a
+// FIX.4.4 session with a SenderCompID, a TargetCompID, a gateway address and a
+// valid HeartBtInt. All problems are returned together as a *ConfigError.
+func (c Config) Validate() error {
+	return c.validate(Options{})
+}
+
+// validate is Validate for settings the options of NewClient complete.
+func (c Config) validate(o Options) error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	switch {
+	case c.APIKey == "":
+		fail("APIKey is required")
+	case strings.TrimSpace(c.APIKey) != c.APIKey:
+		fail("APIKey has leading or trailing spaces")
+	case strings.IndexFunc(c.APIKey, func(r rune) bool { return r < 0x21 || r > 0x7e }) >= 0:
+		fail("APIKey has characters other than printable ASCII")
+	}
+
+	if err := c.validateKey(o); err != nil {
+		fail("private key: %w", err)
+	}
+
+	switch c.Endpoint {
+	case "", OrderEntryEndpoint, MarketDataEndpoint, DropCopyEndpoint:
+	default:
+		fail("Endpoint %q is not one of %s, %s or %s", c.Endpoint,
+			OrderEntryEndpoint, MarketDataEndpoint, DropCopyEndpoint)
+	}
+
+	settings := c.Settings
+	switch {
+	case settings != nil && c.SettingsFilePath != "":
+		fail("only one of Settings or SettingsFilePath may be set")
+	case c.SettingsFilePath != "":
+		var err error
+		if settings, err = LoadSettings(c.SettingsFilePath); err != nil {
+			fail("SettingsFilePath: %w", err)
+		}
+	}
+	if settings != nil {
+		for _, err := range validateSettings(settings, o) {
+			fail("Settings: %w", err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return &ConfigError{Errors: errs}
+	}
+	return nil
+}
+
+// validateKey parses the private key NewClient would use, unless WithSigner
+// provides one.
+func (c Config) validateKey(o Options) error {
+	var err error
+	switch {
+	case o.signer != nil:
+	case c.PrivateKeySource != nil:
+		_, err = LoadPrivateKey(*c.PrivateKeySource)
+	case c.PrivateKeyPEM != nil:
+		_, err = ParseEd25519PrivateKey(c.PrivateKeyPEM)
+	case c.PrivateKeyFilePath != "":
+		_, err = GetEd25519PrivateKeyFromFile(c.PrivateKeyFilePath)
+	default:
+		err = errors.New("one of PrivateKeySource, PrivateKeyPEM or PrivateKeyFilePath is required")
+	}
+	return err
+}
+
+// validateSettings returns the problems of the settings each session uses:
+// the global settings overlaid with its [SESSION] section. Problems are
+// prefixed with the session when there are several.
+func validateSettings(settings *quickfix.Settings, o Options) []error {
+	sessions := settings.SessionSettings()
+	if len(sessions) == 0 {
+		return append(validateSessionSettings(settings.GlobalSettings(), o),
+			errors.New("no [SESSION] section"))
+	}
+
+	ids := sortedSessionIDs(sessions)
+	var errs []error
+	for _, id := range ids {
+		for _, err := range validateSessionSettings(sessions[id], o) {
+			if len(ids) > 1 {
+				err = fmt.Errorf("session %s: %w", id, err)
+			}
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// validateSessionSettings returns the problems of the settings of a session.
+func validateSessionSettings(session *quickfix.SessionSettings, o Options) []error {
+	var errs []error
+	setting := func(name string) string {
+		if !session.HasSetting(name) {
+			return ""
+		}
+		value, _ := session.Setting(name)
+		return value
+	}
+
+	switch beginString := setting(config.BeginString); beginString {
+	case quickfix.BeginStringFIX44:
+	case "":
+		errs = append(errs, fmt.Errorf("%s is required", config.BeginString))
+	default:
+		errs = append(errs, fmt.Errorf("%s is %s, Binance speaks %s",
+			config.BeginString, beginString, quickfix.BeginStringFIX44))
+	}
+
+	if o.senderCompID == "" && !o.randomSenderCompID {
+		if senderCompID := setting(config.SenderCompID); senderCompID == "" {
+			errs = append(errs, fmt.Errorf("%s is required", config.SenderCompID))
+		} else if !senderCompIDPattern.MatchString(senderCompID) {
+			errs = append(errs, fmt.Errorf("%s %q: %w", config.SenderCompID, senderCompID, ErrInvalidSenderCompID))
+		}
+	}
+	if setting(config.TargetCompID) == "" {
+		errs = append(errs, fmt.Errorf("%s is required", config.TargetCompID))
+	}
+
+	if setting(config.SocketConnectHost) == "" {
+		errs = append(errs, fmt.Errorf("%s is required", config.SocketConnectHost))
+	}
+	if port := setting(config.SocketConnectPort); port == "" {
+		errs = append(errs, fmt.Errorf("%s is required", config.SocketConnectPort))
+	} else if n, err := strconv.Atoi(port); err != nil || n <= 0 || n > 65535 {
+		errs = append(errs, fmt.Errorf("%s %q is not a port", config.SocketConnectPort, port))
+	}
+
+	if o.heartbeatInterval <= 0 {
+		if heartBtInt := setting(config.HeartBtInt); heartBtInt == "" {
+			errs = append(errs, fmt.Errorf("%s is required", config.HeartBtInt))
+		} else if n, err := strconv.Atoi(heartBtInt); err != nil || n <= 0 {
+			errs = append(errs, fmt.Errorf("%s %q is not a positive number of seconds", config.HeartBtInt, heartBtInt))
+		}
+	}
+	return errs
+}
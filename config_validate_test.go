@@ -0,0 +1,48 @@
+package fix
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ljm2ya/binance_fix_api/fixtest"
+	"github.com/quickfixgo/quickfix"
+)
+
+// sessionSectionSettings has the gateway address and HeartBtInt in the
+// [SESSION] section rather than in [DEFAULT].
+const sessionSectionSettings = `
+[DEFAULT]
+BeginString=FIX.4.4
+SenderCompID=BOETEST1
+TargetCompID=SPOT
+
+[SESSION]
+SocketConnectHost=127.0.0.1
+SocketConnectPort=9000
+HeartBtInt=30
+`
+
+func TestValidateSessionSection(t *testing.T) {
+	privateKey, _, err := fixtest.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parse := func(t *testing.T, text string) *quickfix.Settings {
+		t.Helper()
+		settings, err := quickfix.ParseSettings(strings.NewReader(text))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return settings
+	}
+
+	conf := Config{APIKey: "test", PrivateKeyPEM: privateKey, Settings: parse(t, sessionSectionSettings)}
+	if err := conf.Validate(); err != nil {
+		t.Fatalf("Validate = %v", err)
+	}
+
+	conf.Settings = parse(t, strings.Replace(sessionSectionSettings, "SocketConnectHost=127.0.0.1\n", "", 1))
+	if err := conf.Validate(); err == nil || !strings.Contains(err.Error(), "SocketConnectHost is required") {
+		t.Errorf("Validate without SocketConnectHost = %v", err)
+	}
+}
@@ -18,12 +18,33 @@ const (
 	tagLimitResetInterval           quickfix.Tag = 25007
 	tagLimitResetIntervalResolution quickfix.Tag = 25008
 
+	tagErrorCode         quickfix.Tag = 25016
 	tagCumQuoteQty       quickfix.Tag = 25017
 	tagOrderCreationTime quickfix.Tag = 25018
 	tagWorkingTime       quickfix.Tag = 25023
 
 	ExecutionReportTopic = "ExecutionReport<8>"
 	TradeStreamTopic     = "TradeStream"
+	AggTradeTopic        = "AggTrade"
+	ListStatusTopic      = "ListStatus<N>"
+	ConnectionStaleTopic = "ConnectionStale"
+	CancelRejectTopic    = "OrderCancelReject<9>"
+	FillTopic            = "Fill"
+	CircuitOpenTopic     = "CircuitOpen"
+	StateChangeTopic     = "StateChange"
+	ClockDriftTopic      = "ClockDrift"
+	ValidationErrorTopic = "ValidationError"
+	GapDetectedTopic     = "GapDetected"
+	DecodeErrorTopic     = "DecodeError"
+	LateResponseTopic    = "LateResponse"
+	UnknownMessageTopic  = "UnknownMessage"
+	BudgetWarningTopic   = "BudgetWarning"
+	OrderReconciledTopic = "OrderReconciled"
+
+	MarketDataSnapshotTopic = "MarketDataSnapshot"
+	DepthUpdateTopic        = "DepthUpdate"
+	SymbolStaleTopic        = "SymbolStale"
+	MaintenanceNoticeTopic  = "MaintenanceNotice"
 )
 
 const (
@@ -32,8 +53,15 @@ const (
 )
 
 var mappedMsgTypeTag = map[enum.MsgType]quickfix.Tag{
-	msgType_LIMIT_RESPONSE:        tagGetLimitReqID,
-	enum.MsgType_EXECUTION_REPORT: tag.ClOrdID,
+	msgType_LIMIT_RESPONSE:                tagGetLimitReqID,
+	enum.MsgType_EXECUTION_REPORT:         tag.ClOrdID,
+	enum.MsgType_ORDER_CANCEL_REJECT:      tag.ClOrdID,
+	enum.MsgType_ORDER_MASS_CANCEL_REPORT: tag.ClOrdID,
+	msgType_ORDER_AMEND_REJECT:            tag.ClOrdID,
+
+	enum.MsgType_MARKET_DATA_SNAPSHOT_FULL_REFRESH: tag.MDReqID,
+	enum.MsgType_MARKET_DATA_REQUEST_REJECT:        tag.MDReqID,
+	msgType_INSTRUMENT_LIST:                        tagInstrumentReqID,
 }
 
 func getReqIDTagFromMsgType(msgType enum.MsgType) (quickfix.Tag, error) {
@@ -44,20 +72,6 @@ func getReqIDTagFromMsgType(msgType enum.MsgType) (quickfix.Tag, error) {
 	return 0, ErrInvalidRequestIDTag
 }
 
-type MessageHandling int
-
-const (
-	MessageHandlingUnordered  MessageHandling = 1
-	MessageHandlingSequential MessageHandling = 2
-)
-
-type ResponseMode int
-
-const (
-	ResponseModeEverything ResponseMode = 1
-	ResponseModeOnlyAcks   ResponseMode = 2
-)
-
 type OrderStatus string
 
 const (
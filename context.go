@@ -0,0 +1,48 @@
+package fix
+
+import (
+	"context"
+)
+
+// Context returns the root context of the client. Start creates it, keeping
+// the values of the context passed to the first Start, and Stop cancels it;
+// it is done before Start. Callbacks can bound their work with it, and the
+// goroutines the client runs in the background, like the callback workers of
+// WithCallbackWorkers, exit once it is done.
+func (c *Client) Context() context.Context {
+	c.rootMu.Lock()
+	defer c.rootMu.Unlock()
+	return c.root
+}
+
+// startRoot creates the root context from parent unless the client already
+// runs one, e.g. when Start logs on again after a maintenance, and starts the
+// goroutines bound to it.
+func (c *Client) startRoot(parent context.Context) {
+	c.rootMu.Lock()
+	defer c.rootMu.Unlock()
+	if c.root.Err() == nil {
+		return
+	}
+
+	c.root, c.rootCancel = context.WithCancel(context.WithoutCancel(parent))
+	if c.callbacks != nil {
+		c.callbacks.start(c.root)
+	}
+}
+
+// stopRoot cancels the root context. The goroutines bound to it exit on their
+// own; it doesn't wait for them, so Stop can be called from a callback.
+func (c *Client) stopRoot() {
+	c.rootMu.Lock()
+	defer c.rootMu.Unlock()
+	c.rootCancel()
+}
+
+// doneContext returns a context that is already canceled, the root context
+// of a client that isn't started.
+func doneContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx, cancel
+}
@@ -0,0 +1,225 @@
+package fix
+
+import (
+	"errors"
+	"hash/fnv"
+	"sync"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// ErrDuplicateRequestID is returned for a call whose request ID is already
+// used by a call awaiting its response.
+var ErrDuplicateRequestID = errors.New("request ID already awaiting a response")
+
+// correlationShards is the number of lock stripes of the pending calls.
+const correlationShards = 32
+
+// fallbackTags are the request tags a response is matched on when it lacks
+// the request ID tag of its MsgType, in order of precedence: the order a
+// cancel or replace refers to.
+var fallbackTags = map[enum.MsgType][]quickfix.Tag{
+	enum.MsgType_EXECUTION_REPORT:    {tag.OrigClOrdID, tag.OrderID},
+	enum.MsgType_ORDER_CANCEL_REJECT: {tag.OrigClOrdID, tag.OrderID},
+}
+
+// correlationKey identifies a pending call by the value of a fallback tag of
+// its request.
+type correlationKey struct {
+	tag   quickfix.Tag
+	value string
+}
+
+// correlations are the calls awaiting their response. A call is registered
+// under its request ID, which must be unique among pending calls, in one of
+// correlationShards lock stripes, so concurrent calls rarely contend. Calls
+// whose request has an OrigClOrdID or OrderID are also indexed under those;
+// such a key may be shared, e.g. by two cancels of one order, and then
+// matches neither call.
+//
+// Removing a call from its stripe decides who completes it: of a response
+// matched by request ID and one matched by fallback tag, or of a response
+// and the caller giving up, only the first to remove it wins.
+//
+// Guaranteed orderings:
+//   - a response completes at most one call, and a call at most once;
+//   - responses are matched in the order the session receives them, on the
+//     goroutine processing the session;
+//   - subscribers are handed a response before its caller, unless
+//     WithBusyPoll is set, which hands it to the caller first;
+//   - concurrent calls are not ordered among each other, neither on the wire
+//     nor in their completion.
+type correlations struct {
+	shards   [correlationShards]correlationShard
+	fallback fallbackIndex
+}
+
+type correlationShard struct {
+	mu    sync.Mutex
+	calls map[string]*call
+}
+
+type fallbackEntry struct {
+	id string
+	cc *call
+}
+
+// fallbackIndex maps fallback keys to the calls indexed under them. A key
+// matches only while it has a single call.
+type fallbackIndex struct {
+	mu    sync.Mutex
+	byKey map[correlationKey][]fallbackEntry
+}
+
+func newCorrelations() *correlations {
+	r := &correlations{}
+	for i := range r.shards {
+		r.shards[i].calls = make(map[string]*call)
+	}
+	r.fallback.byKey = make(map[correlationKey][]fallbackEntry)
+	return r
+}
+
+func (r *correlations) shard(id string) *correlationShard {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(id))
+	return &r.shards[h.Sum32()%correlationShards]
+}
+
+// add registers cc under id and the fallback tags of its request.
+func (r *correlations) add(id string, cc *call) error {
+	for _, t := range []quickfix.Tag{tag.OrigClOrdID, tag.OrderID} {
+		if value, err := cc.request.Body.GetString(t); err == nil && value != "" {
+			cc.fallback = append(cc.fallback, correlationKey{tag: t, value: value})
+		}
+	}
+
+	s := r.shard(id)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.calls[id]; ok {
+		return ErrDuplicateRequestID
+	}
+	// The fallback keys go in under the lock of the stripe, so they are in
+	// place before a response can remove the call.
+	r.addFallback(id, cc)
+	s.calls[id] = cc
+	return nil
+}
+
+func (r *correlations) addFallback(id string, cc *call) {
+	if len(cc.fallback) == 0 {
+		return
+	}
+	r.fallback.mu.Lock()
+	defer r.fallback.mu.Unlock()
+	for _, key := range cc.fallback {
+		r.fallback.byKey[key] = append(r.fallback.byKey[key], fallbackEntry{id: id, cc: cc})
+	}
+}
+
+// remove unregisters cc if it is still the call of id, and reports whether
+// it was.
+func (r *correlations) remove(id string, cc *call) bool {
+	s := r.shard(id)
+	s.mu.Lock()
+	if s.calls[id] != cc {
+		s.mu.Unlock()
+		return false
+	}
+	delete(s.calls, id)
+	s.mu.Unlock()
+
+	r.removeFallback(cc)
+	return true
+}
+
+// removeFallback drops the fallback keys of cc. A key that was shared
+// matches its remaining call again once only one is left.
+func (r *correlations) removeFallback(cc *call) {
+	if len(cc.fallback) == 0 {
+		return
+	}
+	r.fallback.mu.Lock()
+	defer r.fallback.mu.Unlock()
+	for _, key := range cc.fallback {
+		entries := r.fallback.byKey[key]
+		for i, entry := range entries {
+			if entry.cc == cc {
+				entries = append(entries[:i:i], entries[i+1:]...)
+				break
+			}
+		}
+		if len(entries) == 0 {
+			delete(r.fallback.byKey, key)
+		} else {
+			r.fallback.byKey[key] = entries
+		}
+	}
+}
+
+// match unregisters and returns the call msg answers, matched on the request
+// ID tag of its MsgType or, when msg lacks it, on the fallback tags. id is
+// the request ID of msg, empty if it has none.
+func (r *correlations) match(msgType enum.MsgType, reqIDTag quickfix.Tag, msg *quickfix.Message) (id string, cc *call) {
+	if id, _ = msg.Body.GetString(reqIDTag); id != "" {
+		s := r.shard(id)
+		s.mu.Lock()
+		cc = s.calls[id]
+		delete(s.calls, id)
+		s.mu.Unlock()
+		if cc != nil {
+			r.removeFallback(cc)
+		}
+		return id, cc
+	}
+
+	for _, t := range fallbackTags[msgType] {
+		value, err := msg.Body.GetString(t)
+		if err != nil || value == "" {
+			continue
+		}
+		var entry fallbackEntry
+		r.fallback.mu.Lock()
+		if entries := r.fallback.byKey[correlationKey{tag: t, value: value}]; len(entries) == 1 {
+			entry = entries[0]
+		}
+		r.fallback.mu.Unlock()
+		if entry.cc != nil && r.remove(entry.id, entry.cc) {
+			return "", entry.cc
+		}
+	}
+	return "", nil
+}
+
+// drain unregisters and returns all pending calls.
+func (r *correlations) drain() []*call {
+	var calls []*call
+	for i := range r.shards {
+		s := &r.shards[i]
+		s.mu.Lock()
+		for id, cc := range s.calls {
+			calls = append(calls, cc)
+			delete(s.calls, id)
+		}
+		s.mu.Unlock()
+	}
+	for _, cc := range calls {
+		r.removeFallback(cc)
+	}
+	return calls
+}
+
+// len returns the number of pending calls.
+func (r *correlations) len() int {
+	n := 0
+	for i := range r.shards {
+		s := &r.shards[i]
+		s.mu.Lock()
+		n += len(s.calls)
+		s.mu.Unlock()
+	}
+	return n
+}
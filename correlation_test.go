@@ -0,0 +1,201 @@
+package fix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// cancelCall returns a pending cancel of the order origClOrdID.
+func cancelCall(origClOrdID string) *call {
+	msg := quickfix.NewMessage()
+	msg.Header.Set(field.NewMsgType(enum.MsgType_ORDER_CANCEL_REQUEST))
+	msg.Body.Set(field.NewOrigClOrdID(origClOrdID))
+	return &call{request: msg, done: make(chan error, 1)}
+}
+
+// reportFor returns an execution report matched only on its OrigClOrdID.
+func reportFor(origClOrdID string) *quickfix.Message {
+	msg := quickfix.NewMessage()
+	msg.Header.Set(field.NewMsgType(enum.MsgType_EXECUTION_REPORT))
+	msg.Body.Set(field.NewOrigClOrdID(origClOrdID))
+	return msg
+}
+
+func matchReport(r *correlations, origClOrdID string) *call {
+	_, cc := r.match(enum.MsgType_EXECUTION_REPORT, tag.ClOrdID, reportFor(origClOrdID))
+	return cc
+}
+
+func TestCorrelationDuplicateKeepsFallback(t *testing.T) {
+	r := newCorrelations()
+	first := cancelCall("order-1")
+	if err := r.add("cancel-1", first); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.add("cancel-1", cancelCall("order-1")); !errors.Is(err, ErrDuplicateRequestID) {
+		t.Fatalf("second add = %v, want ErrDuplicateRequestID", err)
+	}
+
+	if cc := matchReport(r, "order-1"); cc != first {
+		t.Fatal("the first call is no longer matched by its OrigClOrdID")
+	}
+	if n := r.len(); n != 0 {
+		t.Fatalf("%d calls pending, want 0", n)
+	}
+}
+
+func TestCorrelationSharedFallback(t *testing.T) {
+	r := newCorrelations()
+	first, second := cancelCall("order-1"), cancelCall("order-1")
+	if err := r.add("cancel-1", first); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.add("cancel-2", second); err != nil {
+		t.Fatal(err)
+	}
+
+	if cc := matchReport(r, "order-1"); cc != nil {
+		t.Fatal("a shared OrigClOrdID matched a call")
+	}
+
+	// Once the overlap ended the key belongs to the remaining call again.
+	if !r.remove("cancel-2", second) {
+		t.Fatal("second call not pending")
+	}
+	if cc := matchReport(r, "order-1"); cc != first {
+		t.Fatal("the remaining call is not matched by its OrigClOrdID")
+	}
+}
+
+// TestCorrelationStress registers, matches and abandons calls concurrently,
+// with shared fallback keys and duplicate request IDs, for the race detector.
+func TestCorrelationStress(t *testing.T) {
+	r := newCorrelations()
+	const workers, rounds = 16, 500
+
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range rounds {
+				// Four workers share every request ID and OrigClOrdID.
+				id := fmt.Sprintf("cancel-%d-%d", w%4, i)
+				cc := cancelCall(fmt.Sprintf("order-%d", i))
+				if err := r.add(id, cc); err != nil {
+					if !errors.Is(err, ErrDuplicateRequestID) {
+						t.Error(err)
+					}
+					continue
+				}
+				switch i % 3 {
+				case 0:
+					matchReport(r, fmt.Sprintf("order-%d", i))
+					r.remove(id, cc)
+				case 1:
+					msg := reportFor("")
+					msg.Body.Set(field.NewClOrdID(id))
+					if _, got := r.match(enum.MsgType_EXECUTION_REPORT, tag.ClOrdID, msg); got != nil && got != cc {
+						t.Error("matched the call of another request ID")
+					}
+				default:
+					r.remove(id, cc)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	r.drain()
+	r.fallback.mu.Lock()
+	defer r.fallback.mu.Unlock()
+	if n := len(r.fallback.byKey); n != 0 {
+		t.Fatalf("%d fallback keys left after drain", n)
+	}
+}
+
+// TestClientCorrelationStress runs concurrent orders, cancels and limit
+// queries against the fixtest gateway and checks each call gets its own
+// response.
+func TestClientCorrelationStress(t *testing.T) {
+	if testing.Short() {
+		t.Skip("stress test")
+	}
+	// The quickfix session loop spins while its send queue waits for the
+	// connection writer; on few processors that starves the connection
+	// goroutines of both ends and calls time out.
+	if runtime.GOMAXPROCS(0) < 4 {
+		defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+	}
+
+	client := startTestClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	const calls = 1000
+	var wg sync.WaitGroup
+	for i := range calls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := stressCall(ctx, client, i, "SYM"+strconv.Itoa(i%4)); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := client.Health().PendingCalls; n != 0 {
+		t.Fatalf("%d calls still pending", n)
+	}
+}
+
+// stressCall places and cancels an order, or queries the limits, and checks
+// the responses belong to the requests.
+func stressCall(ctx context.Context, client *Client, i int, symbol string) error {
+	if i%4 == 3 {
+		_, err := client.NewGetLimitService().Do(ctx)
+		return err
+	}
+
+	clOrdID := "stress-" + strconv.Itoa(i)
+	order, err := client.NewOrderSingleService().
+		ClOrdID(clOrdID).
+		Symbol(symbol).
+		Side(enum.Side_BUY).
+		Type(enum.OrdType_LIMIT).
+		TimeInForce(enum.TimeInForce_GOOD_TILL_CANCEL).
+		Quantity(1).
+		Price(float64(i + 1)).
+		Do(ctx)
+	if err != nil {
+		return err
+	}
+	if order.ClientOrderID != clOrdID || order.Symbol != symbol || order.Price != float64(i+1) {
+		return fmt.Errorf("order %s got the response of %s %s at %v", clOrdID, order.ClientOrderID, order.Symbol, order.Price)
+	}
+
+	canceled, err := client.NewOrderCancelService().
+		ClOrdID(clOrdID + "-c").
+		Symbol(symbol).
+		OrigClOrdID(clOrdID).
+		Do(ctx)
+	if err != nil {
+		return err
+	}
+	if canceled.ClientOrderID != clOrdID+"-c" || canceled.OrigClientOrderID != clOrdID {
+		return fmt.Errorf("cancel of %s got the response of %s", clOrdID, canceled.ClientOrderID)
+	}
+	return nil
+}
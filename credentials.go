@@ -0,0 +1,198 @@
+package fix
+
+import (
+	"context"
+	"crypto"
+	"sort"
+	"sync"
+
+	"github.com/quickfixgo/quickfix"
+)
+
+// symbolSet is the set of symbols with an active trade subscription.
+type symbolSet struct {
+	mu      sync.Mutex
+	symbols map[string]struct{}
+}
+
+func (s *symbolSet) add(symbols []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.symbols == nil {
+		s.symbols = make(map[string]struct{})
+	}
+	for _, symbol := range symbols {
+		s.symbols[symbol] = struct{}{}
+	}
+}
+
+func (s *symbolSet) remove(symbols []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, symbol := range symbols {
+		delete(s.symbols, symbol)
+	}
+}
+
+func (s *symbolSet) list() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	symbols := make([]string, 0, len(s.symbols))
+	for symbol := range s.symbols {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+// mdStream is a market data request made again under its MDReqID after a
+// new logon.
+type mdStream struct {
+	mdReqID string
+	symbol  string
+	trades  bool // of a TradeSubscription, the symbol is also in tradeSymbols
+	request func() *quickfix.Message
+}
+
+// mdStreams are the depth streams and the symbols of trade subscriptions, by
+// MDReqID.
+type mdStreams struct {
+	mu      sync.Mutex
+	streams map[string]mdStream
+}
+
+func (s *mdStreams) add(stream mdStream) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.streams == nil {
+		s.streams = make(map[string]mdStream)
+	}
+	s.streams[stream.mdReqID] = stream
+}
+
+func (s *mdStreams) remove(mdReqID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.streams, mdReqID)
+}
+
+// list returns the streams in MDReqID order.
+func (s *mdStreams) list() []mdStream {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	streams := make([]mdStream, 0, len(s.streams))
+	for _, stream := range s.streams {
+		streams = append(streams, stream)
+	}
+	sort.Slice(streams, func(i, j int) bool { return streams[i].mdReqID < streams[j].mdReqID })
+	return streams
+}
+
+// resubscribe requests the market data streams again after a new logon, the
+// server having dropped them with the old session: depth streams and the
+// symbols of trade subscriptions under their MDReqIDs, so they are still
+// unsubscribed with them, and the other trade streams, aggregated ones
+// included, anew.
+func (c *Client) resubscribe(ctx context.Context) error {
+	streamed := make(map[string]bool)
+	for _, stream := range c.mdStreams.list() {
+		if err := c.SendWithoutResponse(stream.request()); err != nil {
+			return err
+		}
+		if stream.trades {
+			streamed[stream.symbol] = true
+		}
+	}
+
+	var symbols []string
+	for _, symbol := range c.tradeSymbols.list() {
+		if !streamed[symbol] {
+			symbols = append(symbols, symbol)
+		}
+	}
+	if len(symbols) == 0 {
+		return nil
+	}
+	return c.SubscribeToTrades(ctx, symbols)
+}
+
+// credentials returns the API key, signer and SenderCompID the session logs
+// on with, replaced by RotateCredentials.
+func (c *Client) credentials() (apiKey string, signer crypto.Signer, senderCompID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.apiKey, c.signer, c.senderCompID
+}
+
+// RotateCredentials replaces the API key and private key of a running client.
+// The session is logged out cleanly and logged on again with the new
+// credentials, under a new SenderCompID when it was generated or set with
+// WithRandomSenderCompID. Event subscriptions are kept and market data
+// streams, depth streams and trade subscriptions included, are subscribed
+// again. Open orders are left alone, even with WithCancelOnDisconnect, and
+// pending calls fail with ErrClosed. When the new credentials are rejected
+// the LogonError is returned and the session keeps retrying with them until
+// rotated again. A client that was not started is only updated.
+func (c *Client) RotateCredentials(apiKey string, privateKeyPEM []byte) error {
+	privateKey, err := ParseEd25519PrivateKey(privateKeyPEM)
+	if err != nil {
+		return err
+	}
+
+	c.rotateMu.Lock()
+	defer c.rotateMu.Unlock()
+
+	// A generated SenderCompID is drawn again, keeping its endpoint prefix.
+	settings, senderCompID := c.config.Settings, c.senderCompID
+	o := c.options
+	if c.generatedSettings && o.senderCompID == "" {
+		o.senderCompID, o.randomSenderCompID = senderCompID[:3], true
+	}
+	if o.randomSenderCompID {
+		settings, senderCompID, err = resolveSenderCompID(settings, o)
+		if err != nil {
+			return err
+		}
+	}
+
+	running := c.State() != StateDisconnected
+	if running {
+		c.rotating.Store(true)
+		defer c.rotating.Store(false)
+		c.stopStaleWatchdog()
+		c.initiator.Stop()
+	} else if err := quickfix.UnregisterSession(c.sessionID); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.apiKey = apiKey
+	c.signer = privateKey
+	c.senderCompID = senderCompID
+	c.config.APIKey = apiKey
+	c.config.PrivateKeyPEM = privateKeyPEM
+	c.config.Settings = settings
+	c.mu.Unlock()
+
+	// The settings already point at the relay, if any, which is kept.
+	c.initiator, err = c.newInitiator()
+	if err != nil {
+		c.setState(StateDisconnected)
+		return err
+	}
+	if !running {
+		return nil
+	}
+
+	if err := c.Start(context.Background()); err != nil {
+		return err
+	}
+	return c.resubscribe(context.Background())
+}
@@ -8,10 +8,7 @@ import (
 	"errors"
 	"io"
 	"os"
-	"strings"
 	"time"
-
-	"github.com/quickfixgo/enum"
 )
 
 const (
@@ -65,10 +62,7 @@ func GetLogonRawData(
 	privateKey ed25519.PrivateKey,
 	senderCompID, targetCompID, sendingTime string,
 ) string {
-	method := string(enum.MsgType_LOGON)
-	msgSeqNum := "1" // Logon is the first request of fix protocol.
-	payload := strings.Join([]string{method, senderCompID, targetCompID, msgSeqNum, sendingTime}, "\x01")
-	data := ed25519.Sign(privateKey, []byte(payload))
+	data := ed25519.Sign(privateKey, logonPayload(senderCompID, targetCompID, sendingTime))
 
 	return base64.StdEncoding.EncodeToString(data)
 }
@@ -76,4 +70,10 @@ func GetLogonRawData(
 // SendingTimeNow returns current UTC timestamp in FIX format
 func SendingTimeNow() string {
 	return time.Now().UTC().Format(utcTimestampMillisFmt)
+}
+
+// SendingTime returns the current UTC timestamp of the client's Clock in FIX
+// format.
+func (c *Client) SendingTime() string {
+	return c.now().UTC().Format(utcTimestampMillisFmt)
 }
\ No newline at end of file
@@ -0,0 +1,53 @@
+package fix
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+
+	"github.com/ljm2ya/binance_fix_api/handlers"
+)
+
+// DecodeError is emitted for an inbound message the client failed to decode,
+// which therefore reaches no subscriber. A stream of them usually means
+// Binance changed the tags of a message.
+type DecodeError struct {
+	MsgType string
+	Err     error
+	Raw     string
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("decode %s: %v", e.MsgType, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// decodeFailed logs and emits a message that couldn't be decoded.
+func (c *Client) decodeFailed(msgType string, msg *quickfix.Message, err error) {
+	e := &DecodeError{MsgType: msgType, Err: err, Raw: msg.String()}
+	c.logger().Errorw("Failed to decode message, dropped", "msgType", msgType, "msg", e.Raw, "err", err)
+	c.emit(DecodeErrorTopic, e)
+}
+
+// carriesTrade tells a market data message that failed to decode as a trade
+// from a book snapshot or update, which has no trade to decode.
+func carriesTrade(msg *quickfix.Message, err error) bool {
+	if !errors.Is(err, handlers.ErrTradeIDNotFound) {
+		return true
+	}
+	entryType, _ := msg.Body.GetString(tag.MDEntryType)
+	return enum.MDEntryType(entryType) == enum.MDEntryType_TRADE
+}
+
+// carriesDepth tells a market data message starting with a bid or offer
+// entry, a depth update, from one carrying trades.
+func carriesDepth(msg *quickfix.Message) bool {
+	entryType, _ := msg.Body.GetString(tag.MDEntryType)
+	return enum.MDEntryType(entryType) == enum.MDEntryType_BID || enum.MDEntryType(entryType) == enum.MDEntryType_OFFER
+}
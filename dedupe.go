@@ -0,0 +1,78 @@
+package fix
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// DefaultDedupeWindow is how many execution reports are remembered to drop
+// resent duplicates, see WithExecutionReportDedupe.
+const DefaultDedupeWindow = 4096
+
+// WithExecutionReportDedupe sets how many of the latest execution reports are
+// remembered, DefaultDedupeWindow by default. A report resent with
+// PossDupFlag <43> that is among them is dropped before it reaches
+// subscribers or the client's order tracking, so fills aren't counted twice.
+// Reports are keyed on ExecID <17>, or on OrderID <37>, CumQty <14>,
+// ExecType <150> and OrdStatus <39> without one. A window of 0 keeps every
+// report.
+func WithExecutionReportDedupe(window int) NewClientOption {
+	return func(o *Options) {
+		o.dedupeWindow = window
+	}
+}
+
+// reportDedupe remembers the keys of the latest execution reports, evicting
+// the least recently seen beyond its window.
+type reportDedupe struct {
+	mu     sync.Mutex
+	window int
+	seen   map[string]*list.Element
+	order  *list.List // of keys, most recent first
+}
+
+func newReportDedupe(window int) *reportDedupe {
+	return &reportDedupe{window: window, seen: make(map[string]*list.Element), order: list.New()}
+}
+
+// duplicate records the report msg and reports whether it is a resent
+// duplicate of one seen before.
+func (d *reportDedupe) duplicate(msg *quickfix.Message) bool {
+	key := reportKey(msg)
+	if key == "" {
+		return false
+	}
+	possDup, _ := msg.Header.GetBool(tag.PossDupFlag)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if e, ok := d.seen[key]; ok {
+		d.order.MoveToFront(e)
+		return possDup
+	}
+	d.seen[key] = d.order.PushFront(key)
+	if d.order.Len() > d.window {
+		oldest := d.order.Back()
+		d.order.Remove(oldest)
+		delete(d.seen, oldest.Value.(string))
+	}
+	return false
+}
+
+func reportKey(msg *quickfix.Message) string {
+	if execID, _ := msg.Body.GetString(tag.ExecID); execID != "" {
+		return execID
+	}
+	orderID, _ := msg.Body.GetString(tag.OrderID)
+	if orderID == "" {
+		return ""
+	}
+	cumQty, _ := msg.Body.GetString(tag.CumQty)
+	execType, _ := msg.Body.GetString(tag.ExecType)
+	status, _ := msg.Body.GetString(tag.OrdStatus)
+	return orderID + "/" + cumQty + "/" + execType + "/" + status
+}
@@ -0,0 +1,509 @@
+package fix
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/quickfix/config"
+	"golang.org/x/net/proxy"
+)
+
+const (
+	relayHost        = "127.0.0.1"
+	relayDialTimeout = 10 * time.Second
+)
+
+var (
+	ErrUnsupportedProxy = errors.New("unsupported proxy scheme")
+	ErrInvalidLocalAddr = errors.New("invalid local address")
+)
+
+// upstreamDialer dials the real FIX gateway on behalf of the relay.
+type upstreamDialer func(ctx context.Context, address string) (net.Conn, error)
+
+// relay is a loopback TCP forwarder placed in front of the quickfix initiator
+// when the connection needs dialing quickfix can't do by itself (HTTP CONNECT
+// tunnels, source address binding, keepalive tuning, gateway failover).
+// quickfix connects to the relay and the relay dials the gateway.
+//
+// quickfix rotates through SocketConnectHost<n> by itself, but only round
+// robin, one address per reconnect: it can't skip gateways that just failed
+// or are about to go into maintenance, and it verifies the certificate of
+// every gateway against the host name of the first one it dialed. So with
+// several gateways the relay picks them from a gatewayPool and negotiates TLS
+// with each under its own host name, and quickfix speaks plain FIX to the
+// relay. Only sessions with a client certificate, which quickfix insists on
+// negotiating itself, keep TLS end to end in quickfix.
+type relay struct {
+	listener net.Listener
+	gateways *gatewayPool
+	dial     upstreamDialer
+	tls      bool // whether the relay negotiates TLS
+	closed   chan struct{}
+	once     sync.Once
+	wg       sync.WaitGroup
+
+	mu      sync.Mutex
+	tunnels map[*tunnel]struct{}
+}
+
+// tunnel is a forwarded connection to the gateway at address.
+type tunnel struct {
+	address string
+	local   net.Conn
+	remote  net.Conn
+}
+
+func newRelay(gateways *gatewayPool, dial upstreamDialer) (*relay, error) {
+	listener, err := net.Listen("tcp", net.JoinHostPort(relayHost, "0"))
+	if err != nil {
+		return nil, err
+	}
+
+	r := &relay{
+		listener: listener,
+		gateways: gateways,
+		dial:     dial,
+		closed:   make(chan struct{}),
+		tunnels:  make(map[*tunnel]struct{}),
+	}
+	r.wg.Add(1)
+	go r.serve()
+
+	return r, nil
+}
+
+// Port returns the loopback port quickfix should connect to.
+func (r *relay) Port() int {
+	return r.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (r *relay) serve() {
+	defer r.wg.Done()
+	for {
+		conn, err := r.listener.Accept()
+		if err != nil {
+			return
+		}
+		r.wg.Add(1)
+		go func() {
+			defer r.wg.Done()
+			r.forward(conn)
+		}()
+	}
+}
+
+func (r *relay) forward(local net.Conn) {
+	defer local.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), relayDialTimeout)
+	remote, address, err := r.gateways.dial(ctx, r.dial)
+	cancel()
+	if err != nil {
+		return
+	}
+	defer remote.Close()
+
+	t := &tunnel{address: address, local: local, remote: remote}
+	r.mu.Lock()
+	r.tunnels[t] = struct{}{}
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		delete(r.tunnels, t)
+		r.mu.Unlock()
+	}()
+
+	done := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(remote, local)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(local, remote)
+		done <- struct{}{}
+	}()
+
+	select {
+	case <-done:
+	case <-r.closed:
+	}
+}
+
+// failover drops the active tunnels and keeps their gateways behind the others
+// until the given time, so quickfix reconnects to another gateway.
+func (r *relay) failover(until time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for t := range r.tunnels {
+		r.gateways.deprioritize(t.address, until)
+		_ = t.remote.Close()
+		_ = t.local.Close()
+	}
+}
+
+// activeAddress returns the gateway of an active tunnel, or the one the next
+// connection tries first without any.
+func (r *relay) activeAddress() string {
+	r.mu.Lock()
+	for t := range r.tunnels {
+		r.mu.Unlock()
+		return t.address
+	}
+	r.mu.Unlock()
+
+	if candidates := r.gateways.candidates(time.Now()); len(candidates) > 0 {
+		return candidates[0]
+	}
+	return ""
+}
+
+// Close stops accepting connections and tears down active tunnels.
+func (r *relay) Close() error {
+	var err error
+	r.once.Do(func() {
+		close(r.closed)
+		err = r.listener.Close()
+		r.wg.Wait()
+	})
+	return err
+}
+
+// configureDialer rewrites the socket settings according to the connection
+// options. It returns a relay when the connection has to be tunnelled by the
+// client itself, nil when quickfix can dial directly.
+func configureDialer(settings *quickfix.Settings, o Options) (*relay, error) {
+	// A single gateway without proxy or source binding is dialed by quickfix,
+	// which reports a missing one itself.
+	if o.proxyURL == "" && o.localAddr == "" && o.tcpKeepAlive == 0 {
+		if addresses, err := gatewayAddresses(sessionSettings(settings)); err != nil || len(addresses) < 2 {
+			return nil, nil
+		}
+	}
+
+	base, err := baseDialer(o.localAddr, o.tcpKeepAlive)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.proxyURL == "" {
+		return relaySettings(settings, directDialer(base))
+	}
+
+	proxyURL, err := url.Parse(o.proxyURL)
+	if err != nil {
+		return nil, err
+	}
+
+	switch proxyURL.Scheme {
+	case "socks5", "socks5h":
+		// quickfix dials SOCKS5 itself unless the source address must be bound.
+		if o.localAddr == "" {
+			setSocksProxySettings(settings.GlobalSettings(), proxyURL)
+			return nil, nil
+		}
+		return relaySettings(settings, socksDialer(proxyURL, base))
+	case "http", "https":
+		return relaySettings(settings, httpConnectDialer(proxyURL, base))
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedProxy, proxyURL.Scheme)
+	}
+}
+
+// baseDialer returns the dialer used for outgoing TCP connections, bound to
+// localAddr when set. localAddr is either an IP address or an interface name.
+func baseDialer(localAddr string, keepAlive time.Duration) (*net.Dialer, error) {
+	dialer := &net.Dialer{KeepAlive: keepAlive}
+	if localAddr == "" {
+		return dialer, nil
+	}
+
+	ip, err := resolveLocalIP(localAddr)
+	if err != nil {
+		return nil, err
+	}
+	dialer.LocalAddr = &net.TCPAddr{IP: ip}
+
+	return dialer, nil
+}
+
+func resolveLocalIP(localAddr string) (net.IP, error) {
+	if ip := net.ParseIP(localAddr); ip != nil {
+		return ip, nil
+	}
+
+	iface, err := net.InterfaceByName(localAddr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q is neither an IP address nor an interface", ErrInvalidLocalAddr, localAddr)
+	}
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return nil, err
+	}
+
+	// Prefer IPv4 since that's what exchanges whitelist in practice.
+	var fallback net.IP
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok {
+			continue
+		}
+		if ip4 := ipNet.IP.To4(); ip4 != nil {
+			return ip4, nil
+		}
+		if fallback == nil {
+			fallback = ipNet.IP
+		}
+	}
+	if fallback == nil {
+		return nil, fmt.Errorf("%w: interface %q has no address", ErrInvalidLocalAddr, localAddr)
+	}
+
+	return fallback, nil
+}
+
+func setSocksProxySettings(global *quickfix.SessionSettings, proxyURL *url.URL) {
+	global.Set(config.ProxyType, "socks")
+	global.Set(config.ProxyHost, proxyURL.Hostname())
+	global.Set(config.ProxyPort, proxyPort(proxyURL))
+	if proxyURL.User != nil {
+		global.Set(config.ProxyUser, proxyURL.User.Username())
+		if password, ok := proxyURL.User.Password(); ok {
+			global.Set(config.ProxyPassword, password)
+		}
+	}
+}
+
+// directDialer dials the gateway with base.
+func directDialer(base *net.Dialer) upstreamDialer {
+	return func(ctx context.Context, address string) (net.Conn, error) {
+		return base.DialContext(ctx, "tcp", address)
+	}
+}
+
+// socksDialer dials the gateway through a SOCKS5 proxy using base for the
+// connection to the proxy.
+func socksDialer(proxyURL *url.URL, base *net.Dialer) upstreamDialer {
+	return func(ctx context.Context, address string) (net.Conn, error) {
+		var auth *proxy.Auth
+		if proxyURL.User != nil {
+			password, _ := proxyURL.User.Password()
+			auth = &proxy.Auth{User: proxyURL.User.Username(), Password: password}
+		}
+
+		dialer, err := proxy.SOCKS5("tcp", net.JoinHostPort(proxyURL.Hostname(), proxyPort(proxyURL)), auth, base)
+		if err != nil {
+			return nil, err
+		}
+		if contextDialer, ok := dialer.(proxy.ContextDialer); ok {
+			return contextDialer.DialContext(ctx, "tcp", address)
+		}
+		return dialer.Dial("tcp", address)
+	}
+}
+
+// relaySettings starts a relay to the gateways of the session and points the
+// socket settings at it, in the section they are read from: [DEFAULT] when it
+// holds the gateway and the [SESSION] sections.
+func relaySettings(settings *quickfix.Settings, dial upstreamDialer) (*relay, error) {
+	session := sessionSettings(settings)
+	addresses, err := gatewayAddresses(session)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := relayTLSConfig(session)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		dial = tlsDialer(dial, tlsConfig)
+	}
+
+	r, err := newRelay(newGatewayPool(addresses), dial)
+	if err != nil {
+		return nil, err
+	}
+
+	pointAtRelay := func(s *quickfix.SessionSettings) {
+		switch {
+		case tlsConfig != nil:
+			s.Set(config.SocketUseSSL, "N")
+		case !s.HasSetting(config.SocketServerName):
+			// quickfix negotiates TLS with the relay's address otherwise.
+			host, _, _ := net.SplitHostPort(addresses[0])
+			s.Set(config.SocketServerName, host)
+		}
+		setGatewayAddresses(s, len(addresses), relayHost, r.Port())
+	}
+	if global := settings.GlobalSettings(); global.HasSetting(config.SocketConnectHost) {
+		pointAtRelay(global)
+	}
+	if err := updateSessionSettings(settings, pointAtRelay); err != nil {
+		r.Close()
+		return nil, err
+	}
+	r.tls = tlsConfig != nil
+
+	return r, nil
+}
+
+// relayTLSConfig returns the TLS configuration of the relay from the socket
+// settings of the session, nil if the session doesn't use TLS or uses a
+// client certificate.
+func relayTLSConfig(session *quickfix.SessionSettings) (*tls.Config, error) {
+	if !session.HasSetting(config.SocketUseSSL) {
+		return nil, nil
+	}
+	useSSL, err := session.BoolSetting(config.SocketUseSSL)
+	if err != nil || !useSSL {
+		return nil, err
+	}
+	for _, setting := range []string{
+		config.SocketPrivateKeyFile, config.SocketCertificateFile,
+		config.SocketPrivateKeyBytes, config.SocketCertificateBytes,
+	} {
+		if session.HasSetting(setting) {
+			return nil, nil
+		}
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if session.HasSetting(config.SocketServerName) {
+		tlsConfig.ServerName, _ = session.Setting(config.SocketServerName)
+	}
+	if session.HasSetting(config.SocketInsecureSkipVerify) {
+		if tlsConfig.InsecureSkipVerify, err = session.BoolSetting(config.SocketInsecureSkipVerify); err != nil {
+			return nil, err
+		}
+	}
+	if session.HasSetting(config.SocketMinimumTLSVersion) {
+		minVersion, _ := session.Setting(config.SocketMinimumTLSVersion)
+		switch minVersion {
+		case "TLS10":
+			tlsConfig.MinVersion = tls.VersionTLS10
+		case "TLS11":
+			tlsConfig.MinVersion = tls.VersionTLS11
+		}
+	}
+
+	var ca []byte
+	switch {
+	case session.HasSetting(config.SocketCAFile):
+		caFile, _ := session.Setting(config.SocketCAFile)
+		if ca, err = os.ReadFile(caFile); err != nil {
+			return nil, fmt.Errorf("read CA bundle: %w", err)
+		}
+	case session.HasSetting(config.SocketCABytes):
+		ca, _ = session.RawSetting(config.SocketCABytes)
+	}
+	if ca != nil {
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, errors.New("parse CA bundle: no certificates")
+		}
+	}
+	return tlsConfig, nil
+}
+
+// tlsDialer negotiates TLS on the connections of dial. Each gateway's
+// certificate is verified against its own host name unless SocketServerName
+// pins one.
+func tlsDialer(dial upstreamDialer, tlsConfig *tls.Config) upstreamDialer {
+	return func(ctx context.Context, address string) (net.Conn, error) {
+		conn, err := dial(ctx, address)
+		if err != nil {
+			return nil, err
+		}
+
+		conf := tlsConfig
+		if conf.ServerName == "" {
+			conf = tlsConfig.Clone()
+			conf.ServerName, _, _ = net.SplitHostPort(address)
+		}
+		tlsConn := tls.Client(conn, conf)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("TLS handshake with %s: %w", address, err)
+		}
+		return tlsConn, nil
+	}
+}
+
+func proxyPort(proxyURL *url.URL) string {
+	if port := proxyURL.Port(); port != "" {
+		return port
+	}
+
+	switch proxyURL.Scheme {
+	case "http":
+		return "80"
+	case "https":
+		return "443"
+	default:
+		return "1080"
+	}
+}
+
+// httpConnectDialer opens a tunnel to the gateway with an HTTP CONNECT request.
+func httpConnectDialer(proxyURL *url.URL, base *net.Dialer) upstreamDialer {
+	return func(ctx context.Context, address string) (net.Conn, error) {
+		conn, err := base.DialContext(ctx, "tcp", net.JoinHostPort(proxyURL.Hostname(), proxyPort(proxyURL)))
+		if err != nil {
+			return nil, err
+		}
+		if proxyURL.Scheme == "https" {
+			conn = tls.Client(conn, &tls.Config{ServerName: proxyURL.Hostname(), MinVersion: tls.VersionTLS12})
+		}
+
+		if deadline, ok := ctx.Deadline(); ok {
+			_ = conn.SetDeadline(deadline)
+		}
+
+		req := &http.Request{
+			Method: http.MethodConnect,
+			URL:    &url.URL{Opaque: address},
+			Host:   address,
+			Header: make(http.Header),
+		}
+		if proxyURL.User != nil {
+			password, _ := proxyURL.User.Password()
+			credentials := base64.StdEncoding.EncodeToString([]byte(proxyURL.User.Username() + ":" + password))
+			req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+		}
+
+		if err := req.Write(conn); err != nil {
+			conn.Close()
+			return nil, err
+		}
+
+		// The gateway doesn't speak before the client, so nothing past the
+		// CONNECT response can be buffered here.
+		resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			conn.Close()
+			return nil, fmt.Errorf("proxy CONNECT to %s failed: %s", address, resp.Status)
+		}
+
+		_ = conn.SetDeadline(time.Time{})
+		return conn, nil
+	}
+}
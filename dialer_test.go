@@ -0,0 +1,207 @@
+package fix
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ljm2ya/binance_fix_api/fixtest"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/quickfix/config"
+)
+
+// testCA issues certificates for test gateways.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// gateway starts a TLS listener with a certificate valid for host only,
+// which greets every connection with name.
+func (ca *testCA) gateway(t *testing.T, host, name string) int {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: host},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		template.IPAddresses = []net.IP{ip}
+	} else {
+		template.DNSNames = []string{host}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = conn.Write([]byte(name + "\n"))
+				_, _ = conn.Read(make([]byte, 1))
+			}()
+		}
+	}()
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+func TestRelayVerifiesEachGatewayByItsHost(t *testing.T) {
+	ca := newTestCA(t)
+	primary := ca.gateway(t, "localhost", "primary")
+	backup := ca.gateway(t, "127.0.0.1", "backup")
+
+	settings := quickfix.NewSettings()
+	global := settings.GlobalSettings()
+	global.Set(config.SocketUseSSL, "Y")
+	global.SetRaw(config.SocketCABytes, ca.pem)
+	global.Set(config.SocketConnectHost, "localhost")
+	global.Set(config.SocketConnectPort, strconv.Itoa(primary))
+	global.Set(config.SocketConnectHost+"1", "127.0.0.1")
+	global.Set(config.SocketConnectPort+"1", strconv.Itoa(backup))
+
+	r, err := relaySettings(settings, directDialer(&net.Dialer{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if useSSL, _ := global.BoolSetting(config.SocketUseSSL); useSSL {
+		t.Fatal("quickfix still negotiates TLS with the relay")
+	}
+
+	greeting := func() string {
+		t.Helper()
+		conn, err := net.Dial("tcp", net.JoinHostPort(relayHost, strconv.Itoa(r.Port())))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { conn.Close() })
+		_ = conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+		line, err := bufio.NewReader(conn).ReadString('\n')
+		if err != nil {
+			t.Fatalf("no greeting through the relay: %v", err)
+		}
+		return line[:len(line)-1]
+	}
+
+	if got := greeting(); got != "primary" {
+		t.Fatalf("connected to %s, want primary", got)
+	}
+	r.failover(time.Now().Add(time.Minute))
+	if got := greeting(); got != "backup" {
+		t.Fatalf("connected to %s after failover, want backup", got)
+	}
+}
+
+func TestClientSettingsInSessionSection(t *testing.T) {
+	gw := startTestGateway(t)
+	settingsText := fmt.Sprintf(`
+[DEFAULT]
+BeginString=FIX.4.4
+SenderCompID=BOETEST1
+TargetCompID=%s
+
+[SESSION]
+SocketConnectHost=127.0.0.1
+SocketConnectPort=%d
+HeartBtInt=30
+`, fixtest.TargetCompID, gw.Port())
+
+	for _, tc := range []struct {
+		name      string
+		opts      []NewClientOption
+		relay     bool
+		heartBeat time.Duration
+	}{
+		{name: "quickfix", heartBeat: 30 * time.Second},
+		{name: "relay", opts: []NewClientOption{WithTCPKeepAlive(time.Minute)}, relay: true, heartBeat: 30 * time.Second},
+		{name: "heartbeat option", opts: []NewClientOption{WithHeartbeatInterval(10 * time.Second)}, heartBeat: 10 * time.Second},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			settings, err := quickfix.ParseSettings(strings.NewReader(settingsText))
+			if err != nil {
+				t.Fatal(err)
+			}
+			client, err := NewClient(Config{APIKey: "test", PrivateKeyPEM: gw.privateKey, Settings: settings}, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer client.Stop()
+			if err := client.Start(context.Background()); err != nil {
+				t.Fatalf("logon: %v", err)
+			}
+
+			if (client.relay != nil) != tc.relay {
+				t.Errorf("relay = %v, want one: %v", client.relay, tc.relay)
+			}
+			if info := client.SessionInfo(); info.ConnectPort != gw.Port() {
+				t.Errorf("SessionInfo().ConnectPort = %d, want %d", info.ConnectPort, gw.Port())
+			}
+			if client.heartbeatInterval != tc.heartBeat {
+				t.Errorf("heartbeat interval = %v, want %v", client.heartbeatInterval, tc.heartBeat)
+			}
+			if heartBtInt, _ := sessionSettings(settings).IntSetting(config.HeartBtInt); heartBtInt != int(tc.heartBeat/time.Second) {
+				t.Errorf("session HeartBtInt = %d, want %v", heartBtInt, tc.heartBeat)
+			}
+		})
+	}
+}
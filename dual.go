@@ -0,0 +1,209 @@
+package fix
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/chuckpreslar/emission"
+
+	"github.com/ljm2ya/binance_fix_api/handlers"
+)
+
+// maxPendingFills bounds how many unmatched executions and trades are kept
+// while waiting for their counterpart.
+const maxPendingFills = 4096
+
+// Fill is an execution of one of the account's orders matched with the public
+// trade it produced on the market data stream.
+type Fill struct {
+	Order *handlers.Order
+	Trade *handlers.Trade
+}
+
+type FillHandler func(f *Fill)
+
+// DualClient runs an order entry and a market data session as one object.
+// Both sessions share an event emitter, so subscriptions registered on the
+// DualClient or on either Client receive events from both sessions.
+type DualClient struct {
+	OrderEntry *Client
+	MarketData *Client
+
+	emitter *emission.Emitter
+	fills   *fillMatcher
+}
+
+// NewDualClient creates the order entry and market data clients. The options
+// apply to both sessions. Configs without Settings connect to the default
+// Binance endpoints.
+func NewDualClient(orderEntry, marketData Config, opts ...NewClientOption) (*DualClient, error) {
+	if orderEntry.Endpoint == "" {
+		orderEntry.Endpoint = OrderEntryEndpoint
+	}
+	if marketData.Endpoint == "" {
+		marketData.Endpoint = MarketDataEndpoint
+	}
+
+	oe, err := NewClient(orderEntry, opts...)
+	if err != nil {
+		return nil, err
+	}
+	md, err := NewClient(marketData, opts...)
+	if err != nil {
+		oe.closeRelay()
+		return nil, err
+	}
+	md.emitter = oe.emitter
+
+	d := &DualClient{
+		OrderEntry: oe,
+		MarketData: md,
+		emitter:    oe.emitter,
+		fills:      newFillMatcher(),
+	}
+	d.emitter.On(ExecutionReportTopic, d.onExecutionReport)
+	d.emitter.On(TradeStreamTopic, d.onTrade)
+
+	return d, nil
+}
+
+// Start logs on both sessions. If the market data logon fails, the order
+// entry session is stopped again.
+func (d *DualClient) Start(ctx context.Context) error {
+	if err := d.OrderEntry.Start(ctx); err != nil {
+		d.OrderEntry.Stop()
+		return err
+	}
+	if err := d.MarketData.Start(ctx); err != nil {
+		d.MarketData.Stop()
+		d.OrderEntry.Stop()
+		return err
+	}
+	return nil
+}
+
+// Stop closes both sessions.
+func (d *DualClient) Stop() {
+	d.MarketData.Stop()
+	d.OrderEntry.Stop()
+}
+
+// IsConnected reports whether both sessions are logged on.
+func (d *DualClient) IsConnected() bool {
+	return d.OrderEntry.IsConnected() && d.MarketData.IsConnected()
+}
+
+func (d *DualClient) SubscribeToExecutionReport(listener ExecutionReportHandler) {
+	d.emitter.On(ExecutionReportTopic, listener)
+}
+
+func (d *DualClient) SubscribeToTradeStream(listener TradeStreamHandler) {
+	d.emitter.On(TradeStreamTopic, listener)
+}
+
+// SubscribeToFill notifies when an execution of the account has been matched
+// with its trade on the market data stream. Fills of symbols without a trade
+// stream subscription are never reported.
+func (d *DualClient) SubscribeToFill(listener FillHandler) {
+	d.emitter.On(FillTopic, listener)
+}
+
+func (d *DualClient) onExecutionReport(o *handlers.Order) {
+	if o.TradeID == 0 {
+		return
+	}
+	if fill := d.fills.addOrder(o); fill != nil {
+		d.emitter.Emit(FillTopic, fill)
+	}
+}
+
+func (d *DualClient) onTrade(t *handlers.Trade) {
+	if fill := d.fills.addTrade(t); fill != nil {
+		d.emitter.Emit(FillTopic, fill)
+	}
+}
+
+// fillMatcher pairs executions and trades by symbol and trade ID, whichever
+// arrives first.
+type fillMatcher struct {
+	mu     sync.Mutex
+	orders pendingFills[*handlers.Order]
+	trades pendingFills[*handlers.Trade]
+}
+
+func newFillMatcher() *fillMatcher {
+	return &fillMatcher{
+		orders: newPendingFills[*handlers.Order](),
+		trades: newPendingFills[*handlers.Trade](),
+	}
+}
+
+func fillKey(symbol string, tradeID int64) string {
+	return symbol + ":" + strconv.FormatInt(tradeID, 10)
+}
+
+func (m *fillMatcher) addOrder(o *handlers.Order) *Fill {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := fillKey(o.Symbol, o.TradeID)
+	if t, ok := m.trades.take(key); ok {
+		return &Fill{Order: o, Trade: t}
+	}
+	m.orders.put(key, o)
+	return nil
+}
+
+func (m *fillMatcher) addTrade(t *handlers.Trade) *Fill {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := fillKey(t.Symbol, t.TradeID)
+	if o, ok := m.orders.take(key); ok {
+		return &Fill{Order: o, Trade: t}
+	}
+	m.trades.put(key, t)
+	return nil
+}
+
+// pendingFills is a map that forgets its oldest entries beyond
+// maxPendingFills.
+type pendingFills[T any] struct {
+	items map[string]T
+	order []string
+}
+
+func newPendingFills[T any]() pendingFills[T] {
+	return pendingFills[T]{items: make(map[string]T)}
+}
+
+func (p *pendingFills[T]) put(key string, v T) {
+	if _, ok := p.items[key]; !ok {
+		p.order = append(p.order, key)
+	}
+	p.items[key] = v
+
+	for len(p.items) > maxPendingFills && len(p.order) > 0 {
+		delete(p.items, p.order[0])
+		p.order = p.order[1:]
+	}
+	if len(p.order) > 2*maxPendingFills {
+		// drop keys already taken
+		live := p.order[:0]
+		for _, k := range p.order {
+			if _, ok := p.items[k]; ok {
+				live = append(live, k)
+			}
+		}
+		p.order = live
+	}
+}
+
+func (p *pendingFills[T]) take(key string) (T, bool) {
+	v, ok := p.items[key]
+	if ok {
+		delete(p.items, key)
+	}
+	return v, ok
+}
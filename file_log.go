@@ -0,0 +1,199 @@
+package fix
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/quickfix"
+)
+
+const fileLogTimeFormat = "20060102-15:04:05.000000"
+
+// RotatingFileLogConfig configures NewRotatingFileLogFactory.
+type RotatingFileLogConfig struct {
+	// Dir receives a messages and an event log per session.
+	Dir string
+	// MaxSize rotates a log once it would grow beyond this many bytes.
+	MaxSize int64
+	// MaxAge rotates a log once it is this old, e.g. 24h for daily files.
+	MaxAge time.Duration
+	// MaxBackups is the number of rotated files kept per log, all if zero.
+	MaxBackups int
+	// Redact masks the logon signature, API key and account, see Redact.
+	Redact bool
+}
+
+// NewRotatingFileLogFactory writes the raw FIX messages of each session, as
+// sent and received, to "<session>.messages.log" in config.Dir and session
+// events to "<session>.event.log", rotating them by size and age. Rotated
+// files get a timestamp suffix. Give each client its own factory to
+// configure sessions differently.
+func NewRotatingFileLogFactory(config RotatingFileLogConfig) (quickfix.LogFactory, error) {
+	if err := os.MkdirAll(config.Dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &rotatingFileLogFactory{config: config}, nil
+}
+
+type rotatingFileLogFactory struct {
+	config RotatingFileLogConfig
+}
+
+func (f *rotatingFileLogFactory) Create() (quickfix.Log, error) {
+	return f.create("GLOBAL")
+}
+
+func (f *rotatingFileLogFactory) CreateSessionLog(sessionID quickfix.SessionID) (quickfix.Log, error) {
+	name := strings.NewReplacer(":", "-", ">", "", "/", "_").Replace(sessionID.String())
+	return f.create(name)
+}
+
+func (f *rotatingFileLogFactory) create(name string) (quickfix.Log, error) {
+	messages, err := openRotatingFile(filepath.Join(f.config.Dir, name+".messages.log"), f.config)
+	if err != nil {
+		return nil, err
+	}
+	events, err := openRotatingFile(filepath.Join(f.config.Dir, name+".event.log"), f.config)
+	if err != nil {
+		messages.close()
+		return nil, err
+	}
+	return &rotatingFileLog{messages: messages, events: events, redact: f.config.Redact}, nil
+}
+
+type rotatingFileLog struct {
+	messages *rotatingFile
+	events   *rotatingFile
+	redact   bool
+}
+
+func (l *rotatingFileLog) OnIncoming(data []byte) {
+	l.message("IN ", data)
+}
+
+func (l *rotatingFileLog) OnOutgoing(data []byte) {
+	l.message("OUT", data)
+}
+
+func (l *rotatingFileLog) message(direction string, data []byte) {
+	if l.redact {
+		data = Redact(data)
+	}
+	if err := l.messages.writeLine(direction + " " + string(data)); err != nil {
+		l.OnEventf("message log write failed: %v", err)
+	}
+}
+
+func (l *rotatingFileLog) OnEvent(text string) {
+	// There's nowhere left to report a failing event log.
+	_ = l.events.writeLine(text)
+}
+
+func (l *rotatingFileLog) OnEventf(format string, params ...interface{}) {
+	l.OnEvent(fmt.Sprintf(format, params...))
+}
+
+// rotatingFile is a log file that is renamed aside and reopened once it is
+// too large or too old.
+type rotatingFile struct {
+	mu     sync.Mutex
+	path   string
+	config RotatingFileLogConfig
+	file   *os.File
+	size   int64
+	opened time.Time
+}
+
+func openRotatingFile(path string, config RotatingFileLogConfig) (*rotatingFile, error) {
+	f := &rotatingFile{path: path, config: config}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file, f.size, f.opened = file, info.Size(), time.Now()
+	return nil
+}
+
+func (f *rotatingFile) writeLine(text string) error {
+	now := time.Now()
+	line := now.UTC().Format(fileLogTimeFormat) + " " + text + "\n"
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return os.ErrClosed
+	}
+	tooLarge := f.config.MaxSize > 0 && f.size > 0 && f.size+int64(len(line)) > f.config.MaxSize
+	tooOld := f.config.MaxAge > 0 && now.Sub(f.opened) >= f.config.MaxAge
+	if tooLarge || tooOld {
+		if err := f.rotate(now); err != nil {
+			return err
+		}
+	}
+
+	n, err := f.file.WriteString(line)
+	f.size += int64(n)
+	return err
+}
+
+func (f *rotatingFile) rotate(now time.Time) error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	f.file = nil
+	if err := os.Rename(f.path, f.path+"."+now.UTC().Format("20060102-150405.000000")); err != nil {
+		return err
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+	return f.prune()
+}
+
+// prune removes the oldest rotated files beyond MaxBackups.
+func (f *rotatingFile) prune() error {
+	if f.config.MaxBackups <= 0 {
+		return nil
+	}
+	backups, err := filepath.Glob(f.path + ".*")
+	if err != nil {
+		return err
+	}
+	// The timestamp suffixes sort chronologically.
+	sort.Strings(backups)
+	for len(backups) > f.config.MaxBackups {
+		if err := os.Remove(backups[0]); err != nil {
+			return err
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
+
+func (f *rotatingFile) close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file != nil {
+		f.file.Close()
+		f.file = nil
+	}
+}
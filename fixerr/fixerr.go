@@ -0,0 +1,78 @@
+// Package fixerr tells which request an error returned by the client belongs
+// to. Errors of Call, CallAndDecode, order placement and cancels wrap the
+// request's correlation ID, MsgType and elapsed time in a *RequestError, so
+// logs and error reports show the failed request without annotating it by
+// hand. The cause stays reachable with errors.Is and errors.As.
+package fixerr
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// RequestError is an error of a request to the exchange.
+type RequestError struct {
+	// RequestID is the correlation ID of the request: the ClOrdID of orders
+	// and cancels, the MDReqID of market data requests and so on. It is empty
+	// when the request failed before an ID was assigned.
+	RequestID string
+	MsgType   string
+	// Elapsed is the time from the start of the request until it failed.
+	Elapsed time.Duration
+	Err     error
+}
+
+func (e *RequestError) Error() string {
+	if e.RequestID == "" {
+		return fmt.Sprintf("%s request failed after %s: %v", e.MsgType, e.Elapsed, e.Err)
+	}
+	return fmt.Sprintf("%s request %s failed after %s: %v", e.MsgType, e.RequestID, e.Elapsed, e.Err)
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// Wrap wraps err in a *RequestError. It returns err unchanged if it is nil or
+// already wraps a *RequestError, so the innermost request context is kept.
+func Wrap(err error, requestID, msgType string, elapsed time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	var r *RequestError
+	if errors.As(err, &r) {
+		return err
+	}
+	return &RequestError{RequestID: requestID, MsgType: msgType, Elapsed: elapsed, Err: err}
+}
+
+// RequestID returns the correlation ID of the request err belongs to, empty if
+// it carries none.
+func RequestID(err error) string {
+	var r *RequestError
+	if errors.As(err, &r) {
+		return r.RequestID
+	}
+	return ""
+}
+
+// MsgType returns the MsgType of the request err belongs to, empty if it
+// carries none.
+func MsgType(err error) string {
+	var r *RequestError
+	if errors.As(err, &r) {
+		return r.MsgType
+	}
+	return ""
+}
+
+// Elapsed returns how long the request err belongs to ran before failing,
+// zero if it carries no request context.
+func Elapsed(err error) time.Duration {
+	var r *RequestError
+	if errors.As(err, &r) {
+		return r.Elapsed
+	}
+	return 0
+}
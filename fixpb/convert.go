@@ -0,0 +1,219 @@
+// Package fixpb is the Protobuf schema of the client's events, events.proto,
+// with its generated Go types and the converters from and to the decoded
+// messages of the handlers package.
+//
+// Pass Encoder to bridge.WithEncoder to publish Protobuf instead of JSON.
+package fixpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative events.proto
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	fix "github.com/ljm2ya/binance_fix_api"
+	"github.com/ljm2ya/binance_fix_api/bridge"
+	"github.com/ljm2ya/binance_fix_api/handlers"
+)
+
+var orderStatuses = map[handlers.OrderStatus]OrderStatus{
+	handlers.OrderStatusNew:             OrderStatus_ORDER_STATUS_NEW,
+	handlers.OrderStatusPartiallyFilled: OrderStatus_ORDER_STATUS_PARTIALLY_FILLED,
+	handlers.OrderStatusFilled:          OrderStatus_ORDER_STATUS_FILLED,
+	handlers.OrderStatusCanceled:        OrderStatus_ORDER_STATUS_CANCELED,
+	handlers.OrderStatusPendingCancel:   OrderStatus_ORDER_STATUS_PENDING_CANCEL,
+	handlers.OrderStatusRejected:        OrderStatus_ORDER_STATUS_REJECTED,
+	handlers.OrderStatusPendingNew:      OrderStatus_ORDER_STATUS_PENDING_NEW,
+	handlers.OrderStatusExpired:         OrderStatus_ORDER_STATUS_EXPIRED,
+}
+
+var timesInForce = map[handlers.TimeInForce]TimeInForce{
+	handlers.TimeInForceGTC: TimeInForce_TIME_IN_FORCE_GTC,
+	handlers.TimeInForceIOC: TimeInForce_TIME_IN_FORCE_IOC,
+	handlers.TimeInForceFOK: TimeInForce_TIME_IN_FORCE_FOK,
+}
+
+var orderTypes = map[handlers.OrderType]OrderType{
+	handlers.OrderTypeMarket:    OrderType_ORDER_TYPE_MARKET,
+	handlers.OrderTypeLimit:     OrderType_ORDER_TYPE_LIMIT,
+	handlers.OrderTypeStop:      OrderType_ORDER_TYPE_STOP,
+	handlers.OrderTypeStopLimit: OrderType_ORDER_TYPE_STOP_LIMIT,
+}
+
+var sides = map[handlers.SideType]Side{
+	handlers.SideTypeBuy:  Side_SIDE_BUY,
+	handlers.SideTypeSell: Side_SIDE_SELL,
+}
+
+var commissionTypes = map[handlers.CommissionType]CommissionType{
+	handlers.CommissionTypePerUnit:  CommissionType_COMMISSION_TYPE_PER_UNIT,
+	handlers.CommissionTypePercent:  CommissionType_COMMISSION_TYPE_PERCENT,
+	handlers.CommissionTypeAbsolute: CommissionType_COMMISSION_TYPE_ABSOLUTE,
+}
+
+var feeTypes = map[handlers.FeeType]FeeType{
+	handlers.FeeTypeExchangeFees:    FeeType_FEE_TYPE_EXCHANGE_FEES,
+	handlers.FeeTypeLocalCommission: FeeType_FEE_TYPE_LOCAL_COMMISSION,
+	handlers.FeeTypeOther:           FeeType_FEE_TYPE_OTHER,
+}
+
+// reverse looks up the handlers value of a Protobuf enum, "" when unspecified.
+func reverse[K comparable, V comparable](m map[K]V, v V) K {
+	for k, mv := range m {
+		if mv == v {
+			return k
+		}
+	}
+	var zero K
+	return zero
+}
+
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func fromTimestamp(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+// FromOrder converts a decoded execution report.
+func FromOrder(o *handlers.Order) *Order {
+	fees := make([]*Fee, 0, len(o.Fees))
+	for _, fee := range o.Fees {
+		fees = append(fees, &Fee{Amount: fee.Amount, Asset: fee.Asset, Type: feeTypes[fee.Type]})
+	}
+	return &Order{
+		Symbol:            o.Symbol,
+		OrderId:           o.OrderID,
+		ClientOrderId:     o.ClientOrderID,
+		OrigClientOrderId: o.OrigClientOrderID,
+		Price:             o.Price,
+		OrderQty:          o.OrderQty,
+		CumQty:            o.CumQty,
+		CumQuoteQty:       o.CumQuoteQty,
+		Status:            orderStatuses[o.Status],
+		TimeInForce:       timesInForce[o.TimeInForce],
+		Type:              orderTypes[o.Type],
+		Side:              sides[o.Side],
+		IcebergQty:        o.IcebergQuantity,
+		TransactTime:      timestamp(o.TransactTime),
+		OrderCreationTime: timestamp(o.OrderCreationTime),
+		WorkingTime:       timestamp(o.WorkingTime),
+		LastPx:            o.LastPx,
+		LastQty:           o.LastQty,
+		TradeId:           o.TradeID,
+		Commission:        o.Commission,
+		CommissionType:    commissionTypes[o.CommissionType],
+		CommissionAsset:   o.CommissionAsset,
+		Fees:              fees,
+		RejectReason:      o.RejectReason,
+		OrdRejReason:      o.OrdRejReason,
+		ErrorCode:         o.ErrorCode,
+	}
+}
+
+// ToOrder converts back to a handlers.Order, without Raw, Timings and
+// Warnings.
+func ToOrder(o *Order) handlers.Order {
+	var fees []handlers.Fee
+	for _, fee := range o.GetFees() {
+		fees = append(fees, handlers.Fee{Amount: fee.GetAmount(), Asset: fee.GetAsset(), Type: reverse(feeTypes, fee.GetType())})
+	}
+	return handlers.Order{
+		Symbol:            o.GetSymbol(),
+		OrderID:           o.GetOrderId(),
+		ClientOrderID:     o.GetClientOrderId(),
+		OrigClientOrderID: o.GetOrigClientOrderId(),
+		Price:             o.GetPrice(),
+		OrderQty:          o.GetOrderQty(),
+		CumQty:            o.GetCumQty(),
+		CumQuoteQty:       o.GetCumQuoteQty(),
+		Status:            reverse(orderStatuses, o.GetStatus()),
+		TimeInForce:       reverse(timesInForce, o.GetTimeInForce()),
+		Type:              reverse(orderTypes, o.GetType()),
+		Side:              reverse(sides, o.GetSide()),
+		IcebergQuantity:   o.GetIcebergQty(),
+		TransactTime:      fromTimestamp(o.GetTransactTime()),
+		OrderCreationTime: fromTimestamp(o.GetOrderCreationTime()),
+		WorkingTime:       fromTimestamp(o.GetWorkingTime()),
+		LastPx:            o.GetLastPx(),
+		LastQty:           o.GetLastQty(),
+		TradeID:           o.GetTradeId(),
+		Commission:        o.GetCommission(),
+		CommissionType:    reverse(commissionTypes, o.GetCommissionType()),
+		CommissionAsset:   o.GetCommissionAsset(),
+		Fees:              fees,
+		RejectReason:      o.GetRejectReason(),
+		OrdRejReason:      o.GetOrdRejReason(),
+		ErrorCode:         o.GetErrorCode(),
+	}
+}
+
+// FromTrade converts a trade of the market data stream.
+func FromTrade(t *handlers.Trade) *Trade {
+	return &Trade{
+		Symbol:        t.Symbol,
+		TradeId:       t.TradeID,
+		Price:         t.Price,
+		Quantity:      t.Quantity,
+		TradeTime:     timestamp(t.TradeTime),
+		EventTime:     timestamp(t.EventTime),
+		BuyerOrderId:  t.BuyerOrderID,
+		SellerOrderId: t.SellerOrderID,
+		IsBuyerMaker:  t.IsBuyerMaker,
+	}
+}
+
+// ToTrade converts back to a handlers.Trade, without Raw.
+func ToTrade(t *Trade) handlers.Trade {
+	return handlers.Trade{
+		Symbol:        t.GetSymbol(),
+		TradeID:       t.GetTradeId(),
+		Price:         t.GetPrice(),
+		Quantity:      t.GetQuantity(),
+		TradeTime:     fromTimestamp(t.GetTradeTime()),
+		EventTime:     fromTimestamp(t.GetEventTime()),
+		BuyerOrderID:  t.GetBuyerOrderId(),
+		SellerOrderID: t.GetSellerOrderId(),
+		IsBuyerMaker:  t.GetIsBuyerMaker(),
+	}
+}
+
+// FromSnapshot converts the top of a book snapshot. A side without levels is
+// left at zero.
+func FromSnapshot(s *fix.MarketDataSnapshot) *BookTicker {
+	ticker := &BookTicker{Symbol: s.Symbol, UpdateId: s.LastBookUpdateID}
+	if len(s.Bids) > 0 {
+		ticker.BidPrice, ticker.BidQty = s.Bids[0].Price, s.Bids[0].Quantity
+	}
+	if len(s.Asks) > 0 {
+		ticker.AskPrice, ticker.AskQty = s.Asks[0].Price, s.Asks[0].Quantity
+	}
+	return ticker
+}
+
+// Encoder is a bridge.Encoder marshaling execution reports and trades to
+// their Protobuf messages.
+var Encoder bridge.Encoder = bridge.EncoderFunc(func(_ bridge.Kind, v any) ([]byte, error) {
+	switch v := v.(type) {
+	case *handlers.Order:
+		return proto.Marshal(FromOrder(v))
+	case *handlers.Trade:
+		return proto.Marshal(FromTrade(v))
+	case *fix.MarketDataSnapshot:
+		return proto.Marshal(FromSnapshot(v))
+	case proto.Message:
+		return proto.Marshal(v)
+	default:
+		return nil, fmt.Errorf("fixpb: cannot encode %T", v)
+	}
+})
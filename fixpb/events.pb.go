@@ -0,0 +1,1024 @@
+// Events of the Binance FIX client, for consumers of the bridge and recording
+// packages in other languages. Fields are only ever added, never renumbered.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: events.proto
+
+package fixpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type OrderStatus int32
+
+const (
+	OrderStatus_ORDER_STATUS_UNSPECIFIED      OrderStatus = 0
+	OrderStatus_ORDER_STATUS_NEW              OrderStatus = 1
+	OrderStatus_ORDER_STATUS_PARTIALLY_FILLED OrderStatus = 2
+	OrderStatus_ORDER_STATUS_FILLED           OrderStatus = 3
+	OrderStatus_ORDER_STATUS_CANCELED         OrderStatus = 4
+	OrderStatus_ORDER_STATUS_PENDING_CANCEL   OrderStatus = 5
+	OrderStatus_ORDER_STATUS_REJECTED         OrderStatus = 6
+	OrderStatus_ORDER_STATUS_PENDING_NEW      OrderStatus = 7
+	OrderStatus_ORDER_STATUS_EXPIRED          OrderStatus = 8
+)
+
+// Enum value maps for OrderStatus.
+var (
+	OrderStatus_name = map[int32]string{
+		0: "ORDER_STATUS_UNSPECIFIED",
+		1: "ORDER_STATUS_NEW",
+		2: "ORDER_STATUS_PARTIALLY_FILLED",
+		3: "ORDER_STATUS_FILLED",
+		4: "ORDER_STATUS_CANCELED",
+		5: "ORDER_STATUS_PENDING_CANCEL",
+		6: "ORDER_STATUS_REJECTED",
+		7: "ORDER_STATUS_PENDING_NEW",
+		8: "ORDER_STATUS_EXPIRED",
+	}
+	OrderStatus_value = map[string]int32{
+		"ORDER_STATUS_UNSPECIFIED":      0,
+		"ORDER_STATUS_NEW":              1,
+		"ORDER_STATUS_PARTIALLY_FILLED": 2,
+		"ORDER_STATUS_FILLED":           3,
+		"ORDER_STATUS_CANCELED":         4,
+		"ORDER_STATUS_PENDING_CANCEL":   5,
+		"ORDER_STATUS_REJECTED":         6,
+		"ORDER_STATUS_PENDING_NEW":      7,
+		"ORDER_STATUS_EXPIRED":          8,
+	}
+)
+
+func (x OrderStatus) Enum() *OrderStatus {
+	p := new(OrderStatus)
+	*p = x
+	return p
+}
+
+func (x OrderStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_events_proto_enumTypes[0].Descriptor()
+}
+
+func (OrderStatus) Type() protoreflect.EnumType {
+	return &file_events_proto_enumTypes[0]
+}
+
+func (x OrderStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderStatus.Descriptor instead.
+func (OrderStatus) EnumDescriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{0}
+}
+
+type TimeInForce int32
+
+const (
+	TimeInForce_TIME_IN_FORCE_UNSPECIFIED TimeInForce = 0
+	TimeInForce_TIME_IN_FORCE_GTC         TimeInForce = 1
+	TimeInForce_TIME_IN_FORCE_IOC         TimeInForce = 2
+	TimeInForce_TIME_IN_FORCE_FOK         TimeInForce = 3
+)
+
+// Enum value maps for TimeInForce.
+var (
+	TimeInForce_name = map[int32]string{
+		0: "TIME_IN_FORCE_UNSPECIFIED",
+		1: "TIME_IN_FORCE_GTC",
+		2: "TIME_IN_FORCE_IOC",
+		3: "TIME_IN_FORCE_FOK",
+	}
+	TimeInForce_value = map[string]int32{
+		"TIME_IN_FORCE_UNSPECIFIED": 0,
+		"TIME_IN_FORCE_GTC":         1,
+		"TIME_IN_FORCE_IOC":         2,
+		"TIME_IN_FORCE_FOK":         3,
+	}
+)
+
+func (x TimeInForce) Enum() *TimeInForce {
+	p := new(TimeInForce)
+	*p = x
+	return p
+}
+
+func (x TimeInForce) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TimeInForce) Descriptor() protoreflect.EnumDescriptor {
+	return file_events_proto_enumTypes[1].Descriptor()
+}
+
+func (TimeInForce) Type() protoreflect.EnumType {
+	return &file_events_proto_enumTypes[1]
+}
+
+func (x TimeInForce) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TimeInForce.Descriptor instead.
+func (TimeInForce) EnumDescriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{1}
+}
+
+type OrderType int32
+
+const (
+	OrderType_ORDER_TYPE_UNSPECIFIED OrderType = 0
+	OrderType_ORDER_TYPE_MARKET      OrderType = 1
+	OrderType_ORDER_TYPE_LIMIT       OrderType = 2
+	OrderType_ORDER_TYPE_STOP        OrderType = 3
+	OrderType_ORDER_TYPE_STOP_LIMIT  OrderType = 4
+)
+
+// Enum value maps for OrderType.
+var (
+	OrderType_name = map[int32]string{
+		0: "ORDER_TYPE_UNSPECIFIED",
+		1: "ORDER_TYPE_MARKET",
+		2: "ORDER_TYPE_LIMIT",
+		3: "ORDER_TYPE_STOP",
+		4: "ORDER_TYPE_STOP_LIMIT",
+	}
+	OrderType_value = map[string]int32{
+		"ORDER_TYPE_UNSPECIFIED": 0,
+		"ORDER_TYPE_MARKET":      1,
+		"ORDER_TYPE_LIMIT":       2,
+		"ORDER_TYPE_STOP":        3,
+		"ORDER_TYPE_STOP_LIMIT":  4,
+	}
+)
+
+func (x OrderType) Enum() *OrderType {
+	p := new(OrderType)
+	*p = x
+	return p
+}
+
+func (x OrderType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderType) Descriptor() protoreflect.EnumDescriptor {
+	return file_events_proto_enumTypes[2].Descriptor()
+}
+
+func (OrderType) Type() protoreflect.EnumType {
+	return &file_events_proto_enumTypes[2]
+}
+
+func (x OrderType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderType.Descriptor instead.
+func (OrderType) EnumDescriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{2}
+}
+
+type Side int32
+
+const (
+	Side_SIDE_UNSPECIFIED Side = 0
+	Side_SIDE_BUY         Side = 1
+	Side_SIDE_SELL        Side = 2
+)
+
+// Enum value maps for Side.
+var (
+	Side_name = map[int32]string{
+		0: "SIDE_UNSPECIFIED",
+		1: "SIDE_BUY",
+		2: "SIDE_SELL",
+	}
+	Side_value = map[string]int32{
+		"SIDE_UNSPECIFIED": 0,
+		"SIDE_BUY":         1,
+		"SIDE_SELL":        2,
+	}
+)
+
+func (x Side) Enum() *Side {
+	p := new(Side)
+	*p = x
+	return p
+}
+
+func (x Side) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Side) Descriptor() protoreflect.EnumDescriptor {
+	return file_events_proto_enumTypes[3].Descriptor()
+}
+
+func (Side) Type() protoreflect.EnumType {
+	return &file_events_proto_enumTypes[3]
+}
+
+func (x Side) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Side.Descriptor instead.
+func (Side) EnumDescriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{3}
+}
+
+type CommissionType int32
+
+const (
+	CommissionType_COMMISSION_TYPE_UNSPECIFIED CommissionType = 0
+	CommissionType_COMMISSION_TYPE_PER_UNIT    CommissionType = 1
+	CommissionType_COMMISSION_TYPE_PERCENT     CommissionType = 2
+	CommissionType_COMMISSION_TYPE_ABSOLUTE    CommissionType = 3
+)
+
+// Enum value maps for CommissionType.
+var (
+	CommissionType_name = map[int32]string{
+		0: "COMMISSION_TYPE_UNSPECIFIED",
+		1: "COMMISSION_TYPE_PER_UNIT",
+		2: "COMMISSION_TYPE_PERCENT",
+		3: "COMMISSION_TYPE_ABSOLUTE",
+	}
+	CommissionType_value = map[string]int32{
+		"COMMISSION_TYPE_UNSPECIFIED": 0,
+		"COMMISSION_TYPE_PER_UNIT":    1,
+		"COMMISSION_TYPE_PERCENT":     2,
+		"COMMISSION_TYPE_ABSOLUTE":    3,
+	}
+)
+
+func (x CommissionType) Enum() *CommissionType {
+	p := new(CommissionType)
+	*p = x
+	return p
+}
+
+func (x CommissionType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CommissionType) Descriptor() protoreflect.EnumDescriptor {
+	return file_events_proto_enumTypes[4].Descriptor()
+}
+
+func (CommissionType) Type() protoreflect.EnumType {
+	return &file_events_proto_enumTypes[4]
+}
+
+func (x CommissionType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CommissionType.Descriptor instead.
+func (CommissionType) EnumDescriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{4}
+}
+
+type FeeType int32
+
+const (
+	FeeType_FEE_TYPE_UNSPECIFIED      FeeType = 0
+	FeeType_FEE_TYPE_EXCHANGE_FEES    FeeType = 1
+	FeeType_FEE_TYPE_LOCAL_COMMISSION FeeType = 2
+	FeeType_FEE_TYPE_OTHER            FeeType = 3
+)
+
+// Enum value maps for FeeType.
+var (
+	FeeType_name = map[int32]string{
+		0: "FEE_TYPE_UNSPECIFIED",
+		1: "FEE_TYPE_EXCHANGE_FEES",
+		2: "FEE_TYPE_LOCAL_COMMISSION",
+		3: "FEE_TYPE_OTHER",
+	}
+	FeeType_value = map[string]int32{
+		"FEE_TYPE_UNSPECIFIED":      0,
+		"FEE_TYPE_EXCHANGE_FEES":    1,
+		"FEE_TYPE_LOCAL_COMMISSION": 2,
+		"FEE_TYPE_OTHER":            3,
+	}
+)
+
+func (x FeeType) Enum() *FeeType {
+	p := new(FeeType)
+	*p = x
+	return p
+}
+
+func (x FeeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FeeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_events_proto_enumTypes[5].Descriptor()
+}
+
+func (FeeType) Type() protoreflect.EnumType {
+	return &file_events_proto_enumTypes[5]
+}
+
+func (x FeeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FeeType.Descriptor instead.
+func (FeeType) EnumDescriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{5}
+}
+
+// Fee is a fee charged for a fill.
+type Fee struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Amount        float64                `protobuf:"fixed64,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Asset         string                 `protobuf:"bytes,2,opt,name=asset,proto3" json:"asset,omitempty"`
+	Type          FeeType                `protobuf:"varint,3,opt,name=type,proto3,enum=binance.fix.v1.FeeType" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Fee) Reset() {
+	*x = Fee{}
+	mi := &file_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Fee) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Fee) ProtoMessage() {}
+
+func (x *Fee) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Fee.ProtoReflect.Descriptor instead.
+func (*Fee) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Fee) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Fee) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+func (x *Fee) GetType() FeeType {
+	if x != nil {
+		return x.Type
+	}
+	return FeeType_FEE_TYPE_UNSPECIFIED
+}
+
+// Order is a decoded ExecutionReport <8>.
+type Order struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Symbol            string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	OrderId           int64                  `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ClientOrderId     string                 `protobuf:"bytes,3,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	OrigClientOrderId string                 `protobuf:"bytes,4,opt,name=orig_client_order_id,json=origClientOrderId,proto3" json:"orig_client_order_id,omitempty"`
+	Price             float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
+	OrderQty          float64                `protobuf:"fixed64,6,opt,name=order_qty,json=orderQty,proto3" json:"order_qty,omitempty"`
+	CumQty            float64                `protobuf:"fixed64,7,opt,name=cum_qty,json=cumQty,proto3" json:"cum_qty,omitempty"`
+	CumQuoteQty       float64                `protobuf:"fixed64,8,opt,name=cum_quote_qty,json=cumQuoteQty,proto3" json:"cum_quote_qty,omitempty"`
+	Status            OrderStatus            `protobuf:"varint,9,opt,name=status,proto3,enum=binance.fix.v1.OrderStatus" json:"status,omitempty"`
+	TimeInForce       TimeInForce            `protobuf:"varint,10,opt,name=time_in_force,json=timeInForce,proto3,enum=binance.fix.v1.TimeInForce" json:"time_in_force,omitempty"`
+	Type              OrderType              `protobuf:"varint,11,opt,name=type,proto3,enum=binance.fix.v1.OrderType" json:"type,omitempty"`
+	Side              Side                   `protobuf:"varint,12,opt,name=side,proto3,enum=binance.fix.v1.Side" json:"side,omitempty"`
+	IcebergQty        float64                `protobuf:"fixed64,13,opt,name=iceberg_qty,json=icebergQty,proto3" json:"iceberg_qty,omitempty"`
+	TransactTime      *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=transact_time,json=transactTime,proto3" json:"transact_time,omitempty"`
+	OrderCreationTime *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=order_creation_time,json=orderCreationTime,proto3" json:"order_creation_time,omitempty"`
+	WorkingTime       *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=working_time,json=workingTime,proto3" json:"working_time,omitempty"`
+	// The fill reported by this execution, if any.
+	LastPx          float64        `protobuf:"fixed64,17,opt,name=last_px,json=lastPx,proto3" json:"last_px,omitempty"`
+	LastQty         float64        `protobuf:"fixed64,18,opt,name=last_qty,json=lastQty,proto3" json:"last_qty,omitempty"`
+	TradeId         int64          `protobuf:"varint,19,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	Commission      float64        `protobuf:"fixed64,20,opt,name=commission,proto3" json:"commission,omitempty"`
+	CommissionType  CommissionType `protobuf:"varint,21,opt,name=commission_type,json=commissionType,proto3,enum=binance.fix.v1.CommissionType" json:"commission_type,omitempty"`
+	CommissionAsset string         `protobuf:"bytes,22,opt,name=commission_asset,json=commissionAsset,proto3" json:"commission_asset,omitempty"`
+	Fees            []*Fee         `protobuf:"bytes,23,rep,name=fees,proto3" json:"fees,omitempty"`
+	// Set when status is ORDER_STATUS_REJECTED.
+	RejectReason  string `protobuf:"bytes,24,opt,name=reject_reason,json=rejectReason,proto3" json:"reject_reason,omitempty"`
+	OrdRejReason  string `protobuf:"bytes,25,opt,name=ord_rej_reason,json=ordRejReason,proto3" json:"ord_rej_reason,omitempty"`
+	ErrorCode     string `protobuf:"bytes,26,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Order) Reset() {
+	*x = Order{}
+	mi := &file_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Order) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Order) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *Order) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+func (x *Order) GetOrigClientOrderId() string {
+	if x != nil {
+		return x.OrigClientOrderId
+	}
+	return ""
+}
+
+func (x *Order) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Order) GetOrderQty() float64 {
+	if x != nil {
+		return x.OrderQty
+	}
+	return 0
+}
+
+func (x *Order) GetCumQty() float64 {
+	if x != nil {
+		return x.CumQty
+	}
+	return 0
+}
+
+func (x *Order) GetCumQuoteQty() float64 {
+	if x != nil {
+		return x.CumQuoteQty
+	}
+	return 0
+}
+
+func (x *Order) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *Order) GetTimeInForce() TimeInForce {
+	if x != nil {
+		return x.TimeInForce
+	}
+	return TimeInForce_TIME_IN_FORCE_UNSPECIFIED
+}
+
+func (x *Order) GetType() OrderType {
+	if x != nil {
+		return x.Type
+	}
+	return OrderType_ORDER_TYPE_UNSPECIFIED
+}
+
+func (x *Order) GetSide() Side {
+	if x != nil {
+		return x.Side
+	}
+	return Side_SIDE_UNSPECIFIED
+}
+
+func (x *Order) GetIcebergQty() float64 {
+	if x != nil {
+		return x.IcebergQty
+	}
+	return 0
+}
+
+func (x *Order) GetTransactTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TransactTime
+	}
+	return nil
+}
+
+func (x *Order) GetOrderCreationTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OrderCreationTime
+	}
+	return nil
+}
+
+func (x *Order) GetWorkingTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.WorkingTime
+	}
+	return nil
+}
+
+func (x *Order) GetLastPx() float64 {
+	if x != nil {
+		return x.LastPx
+	}
+	return 0
+}
+
+func (x *Order) GetLastQty() float64 {
+	if x != nil {
+		return x.LastQty
+	}
+	return 0
+}
+
+func (x *Order) GetTradeId() int64 {
+	if x != nil {
+		return x.TradeId
+	}
+	return 0
+}
+
+func (x *Order) GetCommission() float64 {
+	if x != nil {
+		return x.Commission
+	}
+	return 0
+}
+
+func (x *Order) GetCommissionType() CommissionType {
+	if x != nil {
+		return x.CommissionType
+	}
+	return CommissionType_COMMISSION_TYPE_UNSPECIFIED
+}
+
+func (x *Order) GetCommissionAsset() string {
+	if x != nil {
+		return x.CommissionAsset
+	}
+	return ""
+}
+
+func (x *Order) GetFees() []*Fee {
+	if x != nil {
+		return x.Fees
+	}
+	return nil
+}
+
+func (x *Order) GetRejectReason() string {
+	if x != nil {
+		return x.RejectReason
+	}
+	return ""
+}
+
+func (x *Order) GetOrdRejReason() string {
+	if x != nil {
+		return x.OrdRejReason
+	}
+	return ""
+}
+
+func (x *Order) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// Trade is a trade of the market data stream.
+type Trade struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	TradeId       int64                  `protobuf:"varint,2,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity      float64                `protobuf:"fixed64,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	TradeTime     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=trade_time,json=tradeTime,proto3" json:"trade_time,omitempty"`
+	EventTime     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=event_time,json=eventTime,proto3" json:"event_time,omitempty"`
+	BuyerOrderId  int64                  `protobuf:"varint,7,opt,name=buyer_order_id,json=buyerOrderId,proto3" json:"buyer_order_id,omitempty"`
+	SellerOrderId int64                  `protobuf:"varint,8,opt,name=seller_order_id,json=sellerOrderId,proto3" json:"seller_order_id,omitempty"`
+	IsBuyerMaker  bool                   `protobuf:"varint,9,opt,name=is_buyer_maker,json=isBuyerMaker,proto3" json:"is_buyer_maker,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Trade) Reset() {
+	*x = Trade{}
+	mi := &file_events_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Trade) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Trade) ProtoMessage() {}
+
+func (x *Trade) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Trade.ProtoReflect.Descriptor instead.
+func (*Trade) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Trade) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Trade) GetTradeId() int64 {
+	if x != nil {
+		return x.TradeId
+	}
+	return 0
+}
+
+func (x *Trade) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Trade) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Trade) GetTradeTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TradeTime
+	}
+	return nil
+}
+
+func (x *Trade) GetEventTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EventTime
+	}
+	return nil
+}
+
+func (x *Trade) GetBuyerOrderId() int64 {
+	if x != nil {
+		return x.BuyerOrderId
+	}
+	return 0
+}
+
+func (x *Trade) GetSellerOrderId() int64 {
+	if x != nil {
+		return x.SellerOrderId
+	}
+	return 0
+}
+
+func (x *Trade) GetIsBuyerMaker() bool {
+	if x != nil {
+		return x.IsBuyerMaker
+	}
+	return false
+}
+
+// BookTicker is the best bid and ask of a symbol.
+type BookTicker struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	UpdateId      int64                  `protobuf:"varint,2,opt,name=update_id,json=updateId,proto3" json:"update_id,omitempty"`
+	BidPrice      float64                `protobuf:"fixed64,3,opt,name=bid_price,json=bidPrice,proto3" json:"bid_price,omitempty"`
+	BidQty        float64                `protobuf:"fixed64,4,opt,name=bid_qty,json=bidQty,proto3" json:"bid_qty,omitempty"`
+	AskPrice      float64                `protobuf:"fixed64,5,opt,name=ask_price,json=askPrice,proto3" json:"ask_price,omitempty"`
+	AskQty        float64                `protobuf:"fixed64,6,opt,name=ask_qty,json=askQty,proto3" json:"ask_qty,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BookTicker) Reset() {
+	*x = BookTicker{}
+	mi := &file_events_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookTicker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookTicker) ProtoMessage() {}
+
+func (x *BookTicker) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookTicker.ProtoReflect.Descriptor instead.
+func (*BookTicker) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BookTicker) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *BookTicker) GetUpdateId() int64 {
+	if x != nil {
+		return x.UpdateId
+	}
+	return 0
+}
+
+func (x *BookTicker) GetBidPrice() float64 {
+	if x != nil {
+		return x.BidPrice
+	}
+	return 0
+}
+
+func (x *BookTicker) GetBidQty() float64 {
+	if x != nil {
+		return x.BidQty
+	}
+	return 0
+}
+
+func (x *BookTicker) GetAskPrice() float64 {
+	if x != nil {
+		return x.AskPrice
+	}
+	return 0
+}
+
+func (x *BookTicker) GetAskQty() float64 {
+	if x != nil {
+		return x.AskQty
+	}
+	return 0
+}
+
+var File_events_proto protoreflect.FileDescriptor
+
+const file_events_proto_rawDesc = "" +
+	"\n" +
+	"\fevents.proto\x12\x0ebinance.fix.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"`\n" +
+	"\x03Fee\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\x01R\x06amount\x12\x14\n" +
+	"\x05asset\x18\x02 \x01(\tR\x05asset\x12+\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x17.binance.fix.v1.FeeTypeR\x04type\"\xb5\b\n" +
+	"\x05Order\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\x03R\aorderId\x12&\n" +
+	"\x0fclient_order_id\x18\x03 \x01(\tR\rclientOrderId\x12/\n" +
+	"\x14orig_client_order_id\x18\x04 \x01(\tR\x11origClientOrderId\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\x01R\x05price\x12\x1b\n" +
+	"\torder_qty\x18\x06 \x01(\x01R\borderQty\x12\x17\n" +
+	"\acum_qty\x18\a \x01(\x01R\x06cumQty\x12\"\n" +
+	"\rcum_quote_qty\x18\b \x01(\x01R\vcumQuoteQty\x123\n" +
+	"\x06status\x18\t \x01(\x0e2\x1b.binance.fix.v1.OrderStatusR\x06status\x12?\n" +
+	"\rtime_in_force\x18\n" +
+	" \x01(\x0e2\x1b.binance.fix.v1.TimeInForceR\vtimeInForce\x12-\n" +
+	"\x04type\x18\v \x01(\x0e2\x19.binance.fix.v1.OrderTypeR\x04type\x12(\n" +
+	"\x04side\x18\f \x01(\x0e2\x14.binance.fix.v1.SideR\x04side\x12\x1f\n" +
+	"\viceberg_qty\x18\r \x01(\x01R\n" +
+	"icebergQty\x12?\n" +
+	"\rtransact_time\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\ftransactTime\x12J\n" +
+	"\x13order_creation_time\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\x11orderCreationTime\x12=\n" +
+	"\fworking_time\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\vworkingTime\x12\x17\n" +
+	"\alast_px\x18\x11 \x01(\x01R\x06lastPx\x12\x19\n" +
+	"\blast_qty\x18\x12 \x01(\x01R\alastQty\x12\x19\n" +
+	"\btrade_id\x18\x13 \x01(\x03R\atradeId\x12\x1e\n" +
+	"\n" +
+	"commission\x18\x14 \x01(\x01R\n" +
+	"commission\x12G\n" +
+	"\x0fcommission_type\x18\x15 \x01(\x0e2\x1e.binance.fix.v1.CommissionTypeR\x0ecommissionType\x12)\n" +
+	"\x10commission_asset\x18\x16 \x01(\tR\x0fcommissionAsset\x12'\n" +
+	"\x04fees\x18\x17 \x03(\v2\x13.binance.fix.v1.FeeR\x04fees\x12#\n" +
+	"\rreject_reason\x18\x18 \x01(\tR\frejectReason\x12$\n" +
+	"\x0eord_rej_reason\x18\x19 \x01(\tR\fordRejReason\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x1a \x01(\tR\terrorCode\"\xd6\x02\n" +
+	"\x05Trade\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x19\n" +
+	"\btrade_id\x18\x02 \x01(\x03R\atradeId\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\x01R\x05price\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x01R\bquantity\x129\n" +
+	"\n" +
+	"trade_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\ttradeTime\x129\n" +
+	"\n" +
+	"event_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\teventTime\x12$\n" +
+	"\x0ebuyer_order_id\x18\a \x01(\x03R\fbuyerOrderId\x12&\n" +
+	"\x0fseller_order_id\x18\b \x01(\x03R\rsellerOrderId\x12$\n" +
+	"\x0eis_buyer_maker\x18\t \x01(\bR\fisBuyerMaker\"\xad\x01\n" +
+	"\n" +
+	"BookTicker\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x1b\n" +
+	"\tupdate_id\x18\x02 \x01(\x03R\bupdateId\x12\x1b\n" +
+	"\tbid_price\x18\x03 \x01(\x01R\bbidPrice\x12\x17\n" +
+	"\abid_qty\x18\x04 \x01(\x01R\x06bidQty\x12\x1b\n" +
+	"\task_price\x18\x05 \x01(\x01R\baskPrice\x12\x17\n" +
+	"\aask_qty\x18\x06 \x01(\x01R\x06askQty*\x8c\x02\n" +
+	"\vOrderStatus\x12\x1c\n" +
+	"\x18ORDER_STATUS_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10ORDER_STATUS_NEW\x10\x01\x12!\n" +
+	"\x1dORDER_STATUS_PARTIALLY_FILLED\x10\x02\x12\x17\n" +
+	"\x13ORDER_STATUS_FILLED\x10\x03\x12\x19\n" +
+	"\x15ORDER_STATUS_CANCELED\x10\x04\x12\x1f\n" +
+	"\x1bORDER_STATUS_PENDING_CANCEL\x10\x05\x12\x19\n" +
+	"\x15ORDER_STATUS_REJECTED\x10\x06\x12\x1c\n" +
+	"\x18ORDER_STATUS_PENDING_NEW\x10\a\x12\x18\n" +
+	"\x14ORDER_STATUS_EXPIRED\x10\b*q\n" +
+	"\vTimeInForce\x12\x1d\n" +
+	"\x19TIME_IN_FORCE_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11TIME_IN_FORCE_GTC\x10\x01\x12\x15\n" +
+	"\x11TIME_IN_FORCE_IOC\x10\x02\x12\x15\n" +
+	"\x11TIME_IN_FORCE_FOK\x10\x03*\x84\x01\n" +
+	"\tOrderType\x12\x1a\n" +
+	"\x16ORDER_TYPE_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11ORDER_TYPE_MARKET\x10\x01\x12\x14\n" +
+	"\x10ORDER_TYPE_LIMIT\x10\x02\x12\x13\n" +
+	"\x0fORDER_TYPE_STOP\x10\x03\x12\x19\n" +
+	"\x15ORDER_TYPE_STOP_LIMIT\x10\x04*9\n" +
+	"\x04Side\x12\x14\n" +
+	"\x10SIDE_UNSPECIFIED\x10\x00\x12\f\n" +
+	"\bSIDE_BUY\x10\x01\x12\r\n" +
+	"\tSIDE_SELL\x10\x02*\x8a\x01\n" +
+	"\x0eCommissionType\x12\x1f\n" +
+	"\x1bCOMMISSION_TYPE_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18COMMISSION_TYPE_PER_UNIT\x10\x01\x12\x1b\n" +
+	"\x17COMMISSION_TYPE_PERCENT\x10\x02\x12\x1c\n" +
+	"\x18COMMISSION_TYPE_ABSOLUTE\x10\x03*r\n" +
+	"\aFeeType\x12\x18\n" +
+	"\x14FEE_TYPE_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16FEE_TYPE_EXCHANGE_FEES\x10\x01\x12\x1d\n" +
+	"\x19FEE_TYPE_LOCAL_COMMISSION\x10\x02\x12\x12\n" +
+	"\x0eFEE_TYPE_OTHER\x10\x03B)Z'github.com/ljm2ya/binance_fix_api/fixpbb\x06proto3"
+
+var (
+	file_events_proto_rawDescOnce sync.Once
+	file_events_proto_rawDescData []byte
+)
+
+func file_events_proto_rawDescGZIP() []byte {
+	file_events_proto_rawDescOnce.Do(func() {
+		file_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_events_proto_rawDesc), len(file_events_proto_rawDesc)))
+	})
+	return file_events_proto_rawDescData
+}
+
+var file_events_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_events_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_events_proto_goTypes = []any{
+	(OrderStatus)(0),              // 0: binance.fix.v1.OrderStatus
+	(TimeInForce)(0),              // 1: binance.fix.v1.TimeInForce
+	(OrderType)(0),                // 2: binance.fix.v1.OrderType
+	(Side)(0),                     // 3: binance.fix.v1.Side
+	(CommissionType)(0),           // 4: binance.fix.v1.CommissionType
+	(FeeType)(0),                  // 5: binance.fix.v1.FeeType
+	(*Fee)(nil),                   // 6: binance.fix.v1.Fee
+	(*Order)(nil),                 // 7: binance.fix.v1.Order
+	(*Trade)(nil),                 // 8: binance.fix.v1.Trade
+	(*BookTicker)(nil),            // 9: binance.fix.v1.BookTicker
+	(*timestamppb.Timestamp)(nil), // 10: google.protobuf.Timestamp
+}
+var file_events_proto_depIdxs = []int32{
+	5,  // 0: binance.fix.v1.Fee.type:type_name -> binance.fix.v1.FeeType
+	0,  // 1: binance.fix.v1.Order.status:type_name -> binance.fix.v1.OrderStatus
+	1,  // 2: binance.fix.v1.Order.time_in_force:type_name -> binance.fix.v1.TimeInForce
+	2,  // 3: binance.fix.v1.Order.type:type_name -> binance.fix.v1.OrderType
+	3,  // 4: binance.fix.v1.Order.side:type_name -> binance.fix.v1.Side
+	10, // 5: binance.fix.v1.Order.transact_time:type_name -> google.protobuf.Timestamp
+	10, // 6: binance.fix.v1.Order.order_creation_time:type_name -> google.protobuf.Timestamp
+	10, // 7: binance.fix.v1.Order.working_time:type_name -> google.protobuf.Timestamp
+	4,  // 8: binance.fix.v1.Order.commission_type:type_name -> binance.fix.v1.CommissionType
+	6,  // 9: binance.fix.v1.Order.fees:type_name -> binance.fix.v1.Fee
+	10, // 10: binance.fix.v1.Trade.trade_time:type_name -> google.protobuf.Timestamp
+	10, // 11: binance.fix.v1.Trade.event_time:type_name -> google.protobuf.Timestamp
+	12, // [12:12] is the sub-list for method output_type
+	12, // [12:12] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_events_proto_init() }
+func file_events_proto_init() {
+	if File_events_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_events_proto_rawDesc), len(file_events_proto_rawDesc)),
+			NumEnums:      6,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_events_proto_goTypes,
+		DependencyIndexes: file_events_proto_depIdxs,
+		EnumInfos:         file_events_proto_enumTypes,
+		MessageInfos:      file_events_proto_msgTypes,
+	}.Build()
+	File_events_proto = out.File
+	file_events_proto_goTypes = nil
+	file_events_proto_depIdxs = nil
+}
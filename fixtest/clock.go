@@ -0,0 +1,65 @@
+package fixtest
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is a fake clock for fix.WithClock that only moves when told to.
+// Timestamps the client derives from it are deterministic: two subscriptions
+// at the same frozen time get the same timestamp in their MDReqID, told apart
+// by the client's sequence number.
+type Clock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []clockWaiter
+}
+
+type clockWaiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+// NewClock returns a Clock frozen at now.
+func NewClock(now time.Time) *Clock {
+	return &Clock{now: now}
+}
+
+// Now returns the time of the clock.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After sends the time on the returned channel once the clock was advanced
+// by d.
+func (c *Clock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, clockWaiter{at: c.now.Add(d), ch: ch})
+	return ch
+}
+
+// Advance moves the clock forward by d, firing the After channels that are
+// due.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+
+	pending := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.at.After(c.now) {
+			pending = append(pending, w)
+			continue
+		}
+		w.ch <- c.now
+	}
+	c.waiters = pending
+}
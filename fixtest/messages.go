@@ -0,0 +1,721 @@
+package fixtest
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+
+	"github.com/ljm2ya/binance_fix_api/handlers"
+)
+
+const (
+	utcTimestampMicrosFmt = "20060102-15:04:05.000000"
+
+	msgTypeLimitQuery    enum.MsgType = "XLQ"
+	msgTypeLimitResponse enum.MsgType = "XLR"
+
+	msgTypeOrderAmendKeepPriority enum.MsgType = "XAK"
+	msgTypeOrderAmendReject       enum.MsgType = "XAR"
+
+	tagReqID             quickfix.Tag = 6136
+	tagNoLimitIndicators quickfix.Tag = 25003
+	tagLimitType         quickfix.Tag = 25004
+	tagLimitCount        quickfix.Tag = 25005
+	tagLimitMax          quickfix.Tag = 25006
+	tagLimitInterval     quickfix.Tag = 25007
+	tagLimitResolution   quickfix.Tag = 25008
+	tagCumQuoteQty       quickfix.Tag = 25017
+	tagOrderCreationTime quickfix.Tag = 25018
+	tagWorkingTime       quickfix.Tag = 25023
+	tagErrorCode         quickfix.Tag = 25016
+	tagUUID              quickfix.Tag = 25037
+	tagFirstBookUpdateID quickfix.Tag = 25043
+	tagLastBookUpdateID  quickfix.Tag = 25044
+
+	tagInstrumentReqID quickfix.Tag = 320
+	tagMinQtyIncrement quickfix.Tag = 25039
+	tagStartPriceRange quickfix.Tag = 1202
+	tagEndPriceRange   quickfix.Tag = 1203
+)
+
+// order is an order accepted by the server.
+type order struct {
+	orderID     int64
+	clOrdID     string
+	symbol      string
+	side        enum.Side
+	ordType     enum.OrdType
+	timeInForce string
+	qty         string
+	price       string
+}
+
+// onNewOrderSingle acknowledges limit orders as NEW and fills market orders
+// in full at the last canned trade price of the symbol.
+func (s *Server) onNewOrderSingle(msg *quickfix.Message, sessionID quickfix.SessionID) quickfix.MessageRejectError {
+	var (
+		clOrdID field.ClOrdIDField
+		symbol  field.SymbolField
+		side    field.SideField
+		ordType field.OrdTypeField
+	)
+	if err := msg.Body.Get(&clOrdID); err != nil {
+		return err
+	}
+	if err := msg.Body.Get(&symbol); err != nil {
+		return err
+	}
+	if err := msg.Body.Get(&side); err != nil {
+		return err
+	}
+	if err := msg.Body.Get(&ordType); err != nil {
+		return err
+	}
+
+	o := order{
+		clOrdID: clOrdID.Value(),
+		symbol:  symbol.Value(),
+		side:    side.Value(),
+		ordType: ordType.Value(),
+	}
+	o.timeInForce, _ = msg.Body.GetString(tag.TimeInForce)
+	o.qty, _ = msg.Body.GetString(tag.OrderQty)
+	o.price, _ = msg.Body.GetString(tag.Price)
+
+	orderID, execID := s.nextIDs()
+	o.orderID = orderID
+	report := executionReport(o, o.clOrdID, execID)
+
+	switch {
+	case o.qty == "":
+		report.Body.Set(field.NewExecType(enum.ExecType_REJECTED))
+		report.Body.Set(field.NewOrdStatus(enum.OrdStatus_REJECTED))
+		report.Body.Set(field.NewText("Missing OrderQty."))
+		report.Body.Set(field.NewOrdRejReason(enum.OrdRejReason_OTHER))
+		report.Body.SetString(tagErrorCode, "-1102")
+		report.Body.SetString(tag.CumQty, "0")
+		report.Body.SetString(tag.LeavesQty, "0")
+	case o.ordType == enum.OrdType_MARKET:
+		fillPrice := s.lastTradePrice(o.symbol)
+		report.Body.Set(field.NewExecType(enum.ExecType_TRADE))
+		report.Body.Set(field.NewOrdStatus(enum.OrdStatus_FILLED))
+		report.Body.SetString(tag.CumQty, o.qty)
+		report.Body.SetString(tag.LeavesQty, "0")
+		report.Body.SetString(tag.LastQty, o.qty)
+		report.Body.SetString(tag.LastPx, fillPrice)
+		report.Body.SetString(tag.TradeID, strconv.FormatInt(execID, 10))
+		report.Body.SetString(tagCumQuoteQty, formatFloat(parseFloat(o.qty)*parseFloat(fillPrice)))
+	default:
+		report.Body.Set(field.NewExecType(enum.ExecType_NEW))
+		report.Body.Set(field.NewOrdStatus(enum.OrdStatus_NEW))
+		report.Body.SetString(tag.CumQty, "0")
+		report.Body.SetString(tag.LeavesQty, o.qty)
+		report.Body.SetString(tagCumQuoteQty, "0")
+
+		s.mu.Lock()
+		s.orders[o.clOrdID] = o
+		s.mu.Unlock()
+	}
+
+	if err := quickfix.SendToTarget(report, sessionID); err != nil {
+		return quickfix.NewBusinessMessageRejectError(err.Error(), 0, nil)
+	}
+	return nil
+}
+
+// onOrderCancelRequest cancels an open order by OrigClOrdID or OrderID, or
+// answers with an OrderCancelReject when the order is unknown.
+func (s *Server) onOrderCancelRequest(msg *quickfix.Message, sessionID quickfix.SessionID) quickfix.MessageRejectError {
+	clOrdID, err := msg.Body.GetString(tag.ClOrdID)
+	if err != nil {
+		return err
+	}
+	origClOrdID, _ := msg.Body.GetString(tag.OrigClOrdID)
+	orderID, _ := msg.Body.GetString(tag.OrderID)
+
+	s.mu.Lock()
+	o, ok := s.lookupOrder(origClOrdID, orderID)
+	if ok {
+		delete(s.orders, o.clOrdID)
+		s.execID++
+	}
+	execID := s.execID
+	s.mu.Unlock()
+
+	var resp *quickfix.Message
+	if ok {
+		resp = executionReport(o, clOrdID, execID)
+		resp.Body.Set(field.NewOrigClOrdID(o.clOrdID))
+		resp.Body.Set(field.NewExecType(enum.ExecType_CANCELED))
+		resp.Body.Set(field.NewOrdStatus(enum.OrdStatus_CANCELED))
+		resp.Body.SetString(tag.CumQty, "0")
+		resp.Body.SetString(tag.LeavesQty, "0")
+		resp.Body.SetString(tagCumQuoteQty, "0")
+	} else {
+		resp = quickfix.NewMessage()
+		resp.Header.Set(field.NewMsgType(enum.MsgType_ORDER_CANCEL_REJECT))
+		resp.Body.Set(field.NewClOrdID(clOrdID))
+		if origClOrdID != "" {
+			resp.Body.Set(field.NewOrigClOrdID(origClOrdID))
+		}
+		if orderID != "" {
+			resp.Body.SetString(tag.OrderID, orderID)
+		}
+		resp.Body.Set(field.NewCxlRejResponseTo(enum.CxlRejResponseTo_ORDER_CANCEL_REQUEST))
+		resp.Body.Set(field.NewCxlRejReason(enum.CxlRejReason_OTHER))
+		resp.Body.SetString(tagErrorCode, "-2011")
+		resp.Body.Set(field.NewText("Unknown order sent."))
+	}
+
+	if err := quickfix.SendToTarget(resp, sessionID); err != nil {
+		return quickfix.NewBusinessMessageRejectError(err.Error(), 0, nil)
+	}
+	return nil
+}
+
+// lookupOrder finds an open order by ClOrdID or, failing that, by OrderID.
+// The caller holds s.mu.
+func (s *Server) lookupOrder(clOrdID, orderID string) (order, bool) {
+	if o, ok := s.orders[clOrdID]; ok {
+		return o, true
+	}
+	if orderID == "" {
+		return order{}, false
+	}
+	for _, candidate := range s.orders {
+		if strconv.FormatInt(candidate.orderID, 10) == orderID {
+			return candidate, true
+		}
+	}
+	return order{}, false
+}
+
+// onOrderAmendKeepPriority lowers the quantity of an open order, which takes
+// the ClOrdID of the request, or answers with an OrderAmendReject when the
+// order is unknown or the quantity isn't lower.
+func (s *Server) onOrderAmendKeepPriority(msg *quickfix.Message, sessionID quickfix.SessionID) quickfix.MessageRejectError {
+	clOrdID, err := msg.Body.GetString(tag.ClOrdID)
+	if err != nil {
+		return err
+	}
+	qty, err := msg.Body.GetString(tag.OrderQty)
+	if err != nil {
+		return err
+	}
+	origClOrdID, _ := msg.Body.GetString(tag.OrigClOrdID)
+	orderID, _ := msg.Body.GetString(tag.OrderID)
+
+	s.mu.Lock()
+	o, ok := s.lookupOrder(origClOrdID, orderID)
+	var errorCode, text string
+	switch {
+	case !ok:
+		errorCode, text = "-2011", "Unknown order sent."
+	case parseFloat(qty) <= 0 || parseFloat(qty) >= parseFloat(o.qty):
+		errorCode, text = "-1013", "The requested quantity must be less than the current quantity."
+	default:
+		delete(s.orders, o.clOrdID)
+		prevClOrdID := o.clOrdID
+		o.clOrdID, o.qty = clOrdID, qty
+		s.orders[clOrdID] = o
+		s.execID++
+		origClOrdID = prevClOrdID
+	}
+	execID := s.execID
+	s.mu.Unlock()
+
+	var resp *quickfix.Message
+	if errorCode == "" {
+		resp = executionReport(o, clOrdID, execID)
+		resp.Body.Set(field.NewOrigClOrdID(origClOrdID))
+		resp.Body.Set(field.NewExecType(enum.ExecType_REPLACED))
+		resp.Body.Set(field.NewOrdStatus(enum.OrdStatus_NEW))
+		resp.Body.SetString(tag.CumQty, "0")
+		resp.Body.SetString(tag.LeavesQty, qty)
+		resp.Body.SetString(tagCumQuoteQty, "0")
+	} else {
+		resp = quickfix.NewMessage()
+		resp.Header.Set(field.NewMsgType(msgTypeOrderAmendReject))
+		resp.Body.Set(field.NewClOrdID(clOrdID))
+		if symbol, err := msg.Body.GetString(tag.Symbol); err == nil {
+			resp.Body.Set(field.NewSymbol(symbol))
+		}
+		if origClOrdID != "" {
+			resp.Body.Set(field.NewOrigClOrdID(origClOrdID))
+		}
+		if orderID != "" {
+			resp.Body.SetString(tag.OrderID, orderID)
+		}
+		resp.Body.SetString(tag.OrderQty, qty)
+		resp.Body.SetString(tagErrorCode, errorCode)
+		resp.Body.Set(field.NewText(text))
+	}
+
+	if err := quickfix.SendToTarget(resp, sessionID); err != nil {
+		return quickfix.NewBusinessMessageRejectError(err.Error(), 0, nil)
+	}
+	return nil
+}
+
+// onOrderStatusRequest reports an open order by ClOrdID with an
+// ExecutionReport of ExecType ORDER_STATUS, or rejects it as an unknown order.
+func (s *Server) onOrderStatusRequest(msg *quickfix.Message, sessionID quickfix.SessionID) quickfix.MessageRejectError {
+	clOrdID, err := msg.Body.GetString(tag.ClOrdID)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	o, ok := s.orders[clOrdID]
+	s.execID++
+	execID := s.execID
+	s.mu.Unlock()
+
+	var resp *quickfix.Message
+	if ok {
+		resp = executionReport(o, clOrdID, execID)
+		resp.Body.Set(field.NewExecType(enum.ExecType_ORDER_STATUS))
+		resp.Body.Set(field.NewOrdStatus(enum.OrdStatus_NEW))
+		resp.Body.SetString(tag.CumQty, "0")
+		resp.Body.SetString(tag.LeavesQty, o.qty)
+		resp.Body.SetString(tagCumQuoteQty, "0")
+	} else {
+		symbol, _ := msg.Body.GetString(tag.Symbol)
+		resp = executionReport(order{symbol: symbol, side: enum.Side_BUY, ordType: enum.OrdType_LIMIT}, clOrdID, execID)
+		resp.Body.Set(field.NewExecType(enum.ExecType_ORDER_STATUS))
+		resp.Body.Set(field.NewOrdStatus(enum.OrdStatus_REJECTED))
+		resp.Body.Set(field.NewOrdRejReason(enum.OrdRejReason_UNKNOWN_ORDER))
+		resp.Body.SetString(tagErrorCode, "-2013")
+		resp.Body.Set(field.NewText("Order does not exist."))
+		resp.Body.SetString(tag.CumQty, "0")
+		resp.Body.SetString(tag.LeavesQty, "0")
+		resp.Body.SetString(tagCumQuoteQty, "0")
+	}
+
+	if err := quickfix.SendToTarget(resp, sessionID); err != nil {
+		return quickfix.NewBusinessMessageRejectError(err.Error(), 0, nil)
+	}
+	return nil
+}
+
+// onOrderMassCancelRequest cancels all open orders of a symbol and answers
+// with an OrderMassCancelReport.
+func (s *Server) onOrderMassCancelRequest(msg *quickfix.Message, sessionID quickfix.SessionID) quickfix.MessageRejectError {
+	clOrdID, err := msg.Body.GetString(tag.ClOrdID)
+	if err != nil {
+		return err
+	}
+	symbol, err := msg.Body.GetString(tag.Symbol)
+	if err != nil {
+		return err
+	}
+
+	type canceled struct {
+		order  order
+		execID int64
+	}
+	var orders []canceled
+	s.mu.Lock()
+	for id, o := range s.orders {
+		if o.symbol == symbol {
+			delete(s.orders, id)
+			s.execID++
+			orders = append(orders, canceled{o, s.execID})
+		}
+	}
+	s.mu.Unlock()
+
+	for _, c := range orders {
+		resp := executionReport(c.order, c.order.clOrdID, c.execID)
+		resp.Body.Set(field.NewExecType(enum.ExecType_CANCELED))
+		resp.Body.Set(field.NewOrdStatus(enum.OrdStatus_CANCELED))
+		resp.Body.SetString(tag.CumQty, "0")
+		resp.Body.SetString(tag.LeavesQty, "0")
+		resp.Body.SetString(tagCumQuoteQty, "0")
+		if err := quickfix.SendToTarget(resp, sessionID); err != nil {
+			return quickfix.NewBusinessMessageRejectError(err.Error(), 0, nil)
+		}
+	}
+
+	report := quickfix.NewMessage()
+	report.Header.Set(field.NewMsgType(enum.MsgType_ORDER_MASS_CANCEL_REPORT))
+	report.Body.Set(field.NewClOrdID(clOrdID))
+	report.Body.Set(field.NewSymbol(symbol))
+	report.Body.Set(field.NewMassCancelRequestType(enum.MassCancelRequestType_CANCEL_ORDERS_FOR_A_SECURITY))
+	report.Body.Set(field.NewMassCancelResponse(enum.MassCancelResponse_CANCEL_ORDERS_FOR_A_SECURITY))
+	report.Body.SetInt(tag.TotalAffectedOrders, len(orders))
+	if err := quickfix.SendToTarget(report, sessionID); err != nil {
+		return quickfix.NewBusinessMessageRejectError(err.Error(), 0, nil)
+	}
+	return nil
+}
+
+// executionReport builds an ExecutionReport with the static fields of o.
+func executionReport(o order, clOrdID string, execID int64) *quickfix.Message {
+	now := time.Now().UTC()
+
+	report := quickfix.NewMessage()
+	report.Header.Set(field.NewMsgType(enum.MsgType_EXECUTION_REPORT))
+	report.Body.Set(field.NewExecID(strconv.FormatInt(execID, 10)))
+	report.Body.Set(field.NewClOrdID(clOrdID))
+	report.Body.SetString(tag.OrderID, strconv.FormatInt(o.orderID, 10))
+	report.Body.Set(field.NewSymbol(o.symbol))
+	report.Body.Set(field.NewSide(o.side))
+	report.Body.Set(field.NewOrdType(o.ordType))
+	if o.timeInForce != "" {
+		report.Body.SetString(tag.TimeInForce, o.timeInForce)
+	}
+	if o.qty != "" {
+		report.Body.SetString(tag.OrderQty, o.qty)
+	}
+	if o.price != "" {
+		report.Body.SetString(tag.Price, o.price)
+	}
+	report.Body.SetString(tagOrderCreationTime, now.Format(utcTimestampMicrosFmt))
+	report.Body.SetString(tagWorkingTime, now.Format(utcTimestampMicrosFmt))
+	report.Body.Set(field.NewTransactTime(now))
+	return report
+}
+
+// onLimitQuery answers with canned, unexhausted order and message limits.
+func (s *Server) onLimitQuery(msg *quickfix.Message, sessionID quickfix.SessionID) quickfix.MessageRejectError {
+	reqID, err := msg.Body.GetString(tagReqID)
+	if err != nil {
+		return err
+	}
+
+	limits := quickfix.NewRepeatingGroup(tagNoLimitIndicators, quickfix.GroupTemplate{
+		quickfix.GroupElement(tagLimitType),
+		quickfix.GroupElement(tagLimitCount),
+		quickfix.GroupElement(tagLimitMax),
+		quickfix.GroupElement(tagLimitInterval),
+		quickfix.GroupElement(tagLimitResolution),
+	})
+	for _, l := range []struct {
+		limitType, max, interval, resolution string
+	}{
+		{"1", "10000", "10", "s"},
+		{"2", "10000", "60", "s"},
+	} {
+		limit := limits.Add()
+		limit.SetString(tagLimitType, l.limitType)
+		limit.SetString(tagLimitCount, "0")
+		limit.SetString(tagLimitMax, l.max)
+		limit.SetString(tagLimitInterval, l.interval)
+		limit.SetString(tagLimitResolution, l.resolution)
+	}
+
+	resp := quickfix.NewMessage()
+	resp.Header.Set(field.NewMsgType(msgTypeLimitResponse))
+	resp.Body.SetString(tagReqID, reqID)
+	resp.Body.SetGroup(limits)
+
+	if err := quickfix.SendToTarget(resp, sessionID); err != nil {
+		return quickfix.NewBusinessMessageRejectError(err.Error(), 0, nil)
+	}
+	return nil
+}
+
+// onMarketDataRequest starts or stops canned trade or depth streams per
+// symbol.
+func (s *Server) onMarketDataRequest(msg *quickfix.Message, sessionID quickfix.SessionID) quickfix.MessageRejectError {
+	var (
+		mdReqID          field.MDReqIDField
+		subscriptionType field.SubscriptionRequestTypeField
+	)
+	if err := msg.Body.Get(&mdReqID); err != nil {
+		return err
+	}
+	if err := msg.Body.Get(&subscriptionType); err != nil {
+		return err
+	}
+
+	symbols := quickfix.NewRepeatingGroup(tag.NoRelatedSym, quickfix.GroupTemplate{
+		quickfix.GroupElement(tag.Symbol),
+	})
+	if err := msg.Body.GetGroup(symbols); err != nil {
+		return err
+	}
+
+	if subscriptionType.Value() == enum.SubscriptionRequestType_SNAPSHOT {
+		return s.onSnapshotRequest(msg, mdReqID.Value(), symbols, sessionID)
+	}
+
+	depth := requestsBook(msg)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	streams := s.streams[sessionID]
+	if streams == nil {
+		streams = make(map[string]chan struct{})
+		s.streams[sessionID] = streams
+	}
+
+	for i := range symbols.Len() {
+		symbol, err := symbols.Get(i).GetString(tag.Symbol)
+		if err != nil {
+			return err
+		}
+
+		key := streamKey(symbol, depth)
+		if stop, ok := streams[key]; ok {
+			close(stop)
+			delete(streams, key)
+		}
+		if subscriptionType.Value() != enum.SubscriptionRequestType_SNAPSHOT_PLUS_UPDATES {
+			continue
+		}
+		stop := make(chan struct{})
+		streams[key] = stop
+		if depth {
+			if b, ok := s.options.books[symbol]; ok {
+				s.bookID++
+				go s.streamDepth(sessionID, depthMessage(mdReqID.Value(), symbol, b, s.bookID), stop)
+			}
+		} else {
+			go s.streamTrades(sessionID, mdReqID.Value(), s.options.trades[symbol], stop)
+		}
+	}
+
+	return nil
+}
+
+// requestsBook reports whether a MarketDataRequest is for bids and offers
+// rather than trades.
+func requestsBook(msg *quickfix.Message) bool {
+	entryTypes := quickfix.NewRepeatingGroup(tag.NoMDEntryTypes, quickfix.GroupTemplate{
+		quickfix.GroupElement(tag.MDEntryType),
+	})
+	if err := msg.Body.GetGroup(entryTypes); err != nil {
+		return false
+	}
+	for i := range entryTypes.Len() {
+		entryType, _ := entryTypes.Get(i).GetString(tag.MDEntryType)
+		if enum.MDEntryType(entryType) == enum.MDEntryType_BID || enum.MDEntryType(entryType) == enum.MDEntryType_OFFER {
+			return true
+		}
+	}
+	return false
+}
+
+// streamKey is the key of the trade or depth stream of symbol in
+// Server.streams.
+func streamKey(symbol string, depth bool) string {
+	if depth {
+		return symbol + "@depth"
+	}
+	return symbol
+}
+
+// onSnapshotRequest answers with the canned book of the requested symbol, or
+// rejects the request if there is none.
+func (s *Server) onSnapshotRequest(
+	msg *quickfix.Message, mdReqID string, symbols *quickfix.RepeatingGroup, sessionID quickfix.SessionID,
+) quickfix.MessageRejectError {
+	if symbols.Len() != 1 {
+		return quickfix.ValueIsIncorrect(tag.NoRelatedSym)
+	}
+	symbol, err := symbols.Get(0).GetString(tag.Symbol)
+	if err != nil {
+		return err
+	}
+	depth := 0
+	if msg.Body.Has(tag.MarketDepth) {
+		if depth, err = msg.Body.GetInt(tag.MarketDepth); err != nil {
+			return err
+		}
+	}
+
+	b, ok := s.options.books[symbol]
+	if !ok {
+		reject := quickfix.NewMessage()
+		reject.Header.Set(field.NewMsgType(enum.MsgType_MARKET_DATA_REQUEST_REJECT))
+		reject.Body.Set(field.NewMDReqID(mdReqID))
+		reject.Body.Set(field.NewMDReqRejReason(enum.MDReqRejReason_UNKNOWN_SYMBOL))
+		reject.Body.Set(field.NewText("Unknown symbol."))
+		_ = quickfix.SendToTarget(reject, sessionID)
+		return nil
+	}
+
+	entries := quickfix.NewRepeatingGroup(tag.NoMDEntries, quickfix.GroupTemplate{
+		quickfix.GroupElement(tag.MDEntryType),
+		quickfix.GroupElement(tag.MDEntryPx),
+		quickfix.GroupElement(tag.MDEntrySize),
+	})
+	add := func(entryType enum.MDEntryType, levels []Level) {
+		if depth > 0 && len(levels) > depth {
+			levels = levels[:depth]
+		}
+		for _, level := range levels {
+			entry := entries.Add()
+			entry.Set(field.NewMDEntryType(entryType))
+			entry.SetString(tag.MDEntryPx, formatFloat(level.Price))
+			entry.SetString(tag.MDEntrySize, formatFloat(level.Quantity))
+		}
+	}
+	add(enum.MDEntryType_BID, b.bids)
+	add(enum.MDEntryType_OFFER, b.asks)
+
+	snapshot := quickfix.NewMessage()
+	snapshot.Header.Set(field.NewMsgType(enum.MsgType_MARKET_DATA_SNAPSHOT_FULL_REFRESH))
+	snapshot.Body.Set(field.NewMDReqID(mdReqID))
+	snapshot.Body.Set(field.NewSymbol(symbol))
+	snapshot.Body.SetGroup(entries)
+	_ = quickfix.SendToTarget(snapshot, sessionID)
+	return nil
+}
+
+// onInstrumentListRequest answers with the instruments of WithInstruments.
+func (s *Server) onInstrumentListRequest(msg *quickfix.Message, sessionID quickfix.SessionID) quickfix.MessageRejectError {
+	reqID, err := msg.Body.GetString(tagInstrumentReqID)
+	if err != nil {
+		return err
+	}
+
+	symbols := quickfix.NewRepeatingGroup(tag.NoRelatedSym, quickfix.GroupTemplate{
+		quickfix.GroupElement(tag.Symbol),
+		quickfix.GroupElement(tag.Currency),
+		quickfix.GroupElement(tag.MinTradeVol),
+		quickfix.GroupElement(tag.MaxTradeVol),
+		quickfix.GroupElement(tagMinQtyIncrement),
+		quickfix.GroupElement(tag.MinPriceIncrement),
+		quickfix.GroupElement(tagStartPriceRange),
+		quickfix.GroupElement(tagEndPriceRange),
+	})
+	for _, instrument := range s.options.instruments {
+		entry := symbols.Add()
+		entry.SetString(tag.Symbol, instrument.Symbol)
+		entry.SetString(tag.Currency, instrument.QuoteAsset)
+		entry.SetString(tag.MinTradeVol, formatFloat(instrument.MinQty))
+		entry.SetString(tag.MaxTradeVol, formatFloat(instrument.MaxQty))
+		entry.SetString(tagMinQtyIncrement, formatFloat(instrument.StepSize))
+		entry.SetString(tag.MinPriceIncrement, formatFloat(instrument.TickSize))
+		entry.SetString(tagStartPriceRange, formatFloat(instrument.MinPrice))
+		entry.SetString(tagEndPriceRange, formatFloat(instrument.MaxPrice))
+	}
+
+	resp := quickfix.NewMessage()
+	resp.Header.Set(field.NewMsgType(enum.MsgType_SECURITY_LIST))
+	resp.Body.SetString(tagInstrumentReqID, reqID)
+	resp.Body.SetGroup(symbols)
+
+	if err := quickfix.SendToTarget(resp, sessionID); err != nil {
+		return quickfix.NewBusinessMessageRejectError(err.Error(), 0, nil)
+	}
+	return nil
+}
+
+func (s *Server) stopStreamsLocked(sessionID quickfix.SessionID) {
+	for _, stop := range s.streams[sessionID] {
+		close(stop)
+	}
+	delete(s.streams, sessionID)
+}
+
+func (s *Server) streamTrades(sessionID quickfix.SessionID, mdReqID string, trades []handlers.Trade, stop <-chan struct{}) {
+	ticker := time.NewTicker(s.options.tradeInterval)
+	defer ticker.Stop()
+
+	for _, trade := range trades {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		if err := quickfix.SendToTarget(tradeMessage(mdReqID, trade), sessionID); err != nil {
+			return
+		}
+	}
+}
+
+// streamDepth sends the depth update of a book after the trade interval,
+// unless the stream is stopped first.
+func (s *Server) streamDepth(sessionID quickfix.SessionID, update *quickfix.Message, stop <-chan struct{}) {
+	select {
+	case <-stop:
+	case <-time.After(s.options.tradeInterval):
+		_ = quickfix.SendToTarget(update, sessionID)
+	}
+}
+
+// depthMessage builds a MarketDataIncrementalRefresh adding every level of a
+// book as the book update updateID.
+func depthMessage(mdReqID, symbol string, b book, updateID int64) *quickfix.Message {
+	entries := quickfix.NewRepeatingGroup(tag.NoMDEntries, quickfix.GroupTemplate{
+		quickfix.GroupElement(tag.MDUpdateAction),
+		quickfix.GroupElement(tag.MDEntryType),
+		quickfix.GroupElement(tag.Symbol),
+		quickfix.GroupElement(tag.MDEntryPx),
+		quickfix.GroupElement(tag.MDEntrySize),
+	})
+	add := func(entryType enum.MDEntryType, levels []Level) {
+		for _, level := range levels {
+			entry := entries.Add()
+			entry.Set(field.NewMDUpdateAction(enum.MDUpdateAction_NEW))
+			entry.Set(field.NewMDEntryType(entryType))
+			if entries.Len() == 1 {
+				entry.SetString(tag.Symbol, symbol)
+			}
+			entry.SetString(tag.MDEntryPx, formatFloat(level.Price))
+			entry.SetString(tag.MDEntrySize, formatFloat(level.Quantity))
+		}
+	}
+	add(enum.MDEntryType_BID, b.bids)
+	add(enum.MDEntryType_OFFER, b.asks)
+
+	msg := quickfix.NewMessage()
+	msg.Header.Set(field.NewMsgType(enum.MsgType_MARKET_DATA_INCREMENTAL_REFRESH))
+	msg.Body.Set(field.NewMDReqID(mdReqID))
+	msg.Body.SetInt(tagFirstBookUpdateID, int(updateID))
+	msg.Body.SetInt(tagLastBookUpdateID, int(updateID))
+	msg.Body.SetGroup(entries)
+	return msg
+}
+
+// tradeMessage builds a MarketDataIncrementalRefresh carrying a single trade.
+func tradeMessage(mdReqID string, trade handlers.Trade) *quickfix.Message {
+	entries := quickfix.NewRepeatingGroup(tag.NoMDEntries, quickfix.GroupTemplate{
+		quickfix.GroupElement(tag.MDUpdateAction),
+		quickfix.GroupElement(tag.MDEntryType),
+		quickfix.GroupElement(tag.Symbol),
+		quickfix.GroupElement(tag.MDEntryPx),
+		quickfix.GroupElement(tag.MDEntrySize),
+		quickfix.GroupElement(tag.TransactTime),
+		quickfix.GroupElement(tag.TradeID),
+	})
+
+	transactTime := trade.TradeTime
+	if transactTime.IsZero() {
+		transactTime = time.Now()
+	}
+
+	entry := entries.Add()
+	entry.Set(field.NewMDUpdateAction(enum.MDUpdateAction_NEW))
+	entry.Set(field.NewMDEntryType(enum.MDEntryType_TRADE))
+	entry.SetString(tag.Symbol, trade.Symbol)
+	entry.SetString(tag.MDEntryPx, formatFloat(trade.Price))
+	entry.SetString(tag.MDEntrySize, formatFloat(trade.Quantity))
+	entry.SetString(tag.TransactTime, transactTime.UTC().Format(utcTimestampMicrosFmt))
+	entry.SetString(tag.TradeID, strconv.FormatInt(trade.TradeID, 10))
+
+	msg := quickfix.NewMessage()
+	msg.Header.Set(field.NewMsgType(enum.MsgType_MARKET_DATA_INCREMENTAL_REFRESH))
+	msg.Body.Set(field.NewMDReqID(mdReqID))
+	msg.Body.SetGroup(entries)
+	return msg
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func parseFloat(s string) float64 {
+	f, _ := strconv.ParseFloat(s, 64)
+	return f
+}
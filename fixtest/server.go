@@ -0,0 +1,367 @@
+// Package fixtest runs an in-process Binance FIX gateway for integration tests.
+//
+// The server verifies Binance's Ed25519 logon signature, answers
+// NewOrderSingle, OrderCancelRequest, OrderStatusRequest and
+// OrderAmendKeepPriorityRequest messages with ExecutionReports,
+// OrderCancelRejects or OrderAmendRejects, answers LimitQuery requests and streams canned trades
+// to MarketDataRequest subscribers or answers them with a canned book, sent
+// to depth subscribers as a depth update, answers InstrumentList requests, all over a loopback socket so tests need
+// no network access or exchange credentials.
+package fixtest
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+
+	"github.com/ljm2ya/binance_fix_api/handlers"
+	"github.com/ljm2ya/binance_fix_api/instruments"
+)
+
+const (
+	// TargetCompID is the CompID the server uses, as Binance does.
+	TargetCompID = "SPOT"
+
+	beginString      = "FIX.4.4"
+	templateCompID   = "FIXTEST"
+	defaultInterval  = 10 * time.Millisecond
+	defaultHeartBeat = 30
+)
+
+var (
+	ErrUnknownAPIKey    = errors.New("unknown API key")
+	ErrInvalidSignature = errors.New("invalid logon signature")
+)
+
+type Option func(o *options)
+
+type options struct {
+	credentials   map[string]ed25519.PublicKey
+	trades        map[string][]handlers.Trade
+	books         map[string]book
+	instruments   []instruments.Instrument
+	tradeInterval time.Duration
+	logFactory    quickfix.LogFactory
+}
+
+// WithCredentials registers an API key and the public key its logons must be
+// signed with. Logons using any other API key are rejected.
+func WithCredentials(apiKey string, publicKey ed25519.PublicKey) Option {
+	return func(o *options) {
+		o.credentials[apiKey] = publicKey
+	}
+}
+
+// WithTrades adds canned trades streamed to subscribers of their symbol, in
+// the order given.
+func WithTrades(trades ...handlers.Trade) Option {
+	return func(o *options) {
+		for _, trade := range trades {
+			o.trades[trade.Symbol] = append(o.trades[trade.Symbol], trade)
+		}
+	}
+}
+
+// Level is a price level of a canned order book.
+type Level struct {
+	Price    float64
+	Quantity float64
+}
+
+type book struct {
+	bids, asks []Level
+}
+
+// WithBook sets the order book of symbol returned to snapshot requests, best
+// levels first, and sent once as a depth update to each depth subscription.
+// Snapshot requests for symbols without a book are rejected.
+func WithBook(symbol string, bids, asks []Level) Option {
+	return func(o *options) {
+		o.books[symbol] = book{bids: bids, asks: asks}
+	}
+}
+
+// WithInstruments sets the instruments returned to InstrumentList requests.
+func WithInstruments(list ...instruments.Instrument) Option {
+	return func(o *options) {
+		o.instruments = append(o.instruments, list...)
+	}
+}
+
+// WithTradeInterval sets the delay between two streamed trades.
+func WithTradeInterval(d time.Duration) Option {
+	return func(o *options) {
+		o.tradeInterval = d
+	}
+}
+
+// WithLogFactory sets the quickfix log factory of the server sessions.
+func WithLogFactory(factory quickfix.LogFactory) Option {
+	return func(o *options) {
+		o.logFactory = factory
+	}
+}
+
+// Server is an in-process FIX acceptor emulating a Binance gateway.
+type Server struct {
+	acceptor *quickfix.Acceptor
+	port     int
+	options  options
+
+	mu      sync.Mutex
+	orderID int64
+	execID  int64
+	bookID  int64                                           // last book update ID
+	orders  map[string]order                                // open orders by ClOrdID
+	streams map[quickfix.SessionID]map[string]chan struct{} // by symbol, see streamKey
+}
+
+// NewServer starts a server listening on a free loopback port.
+func NewServer(opts ...Option) (*Server, error) {
+	o := options{
+		credentials:   make(map[string]ed25519.PublicKey),
+		trades:        make(map[string][]handlers.Trade),
+		books:         make(map[string]book),
+		tradeInterval: defaultInterval,
+		logFactory:    quickfix.NewNullLogFactory(),
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	port, err := freePort()
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	b.WriteString("[DEFAULT]\n")
+	b.WriteString(fmt.Sprintf("BeginString=%s\n", beginString))
+	b.WriteString(fmt.Sprintf("SenderCompID=%s\n", TargetCompID))
+	b.WriteString("ConnectionType=acceptor\n")
+	b.WriteString("SocketAcceptHost=127.0.0.1\n")
+	b.WriteString(fmt.Sprintf("SocketAcceptPort=%d\n", port))
+	b.WriteString("DynamicSessions=Y\n")
+	b.WriteString("\n")
+	// quickfix only listens for configured sessions, client sessions are
+	// created dynamically as their logons arrive. Session IDs are global, so
+	// the placeholder is made unique per server.
+	b.WriteString("[SESSION]\n")
+	b.WriteString(fmt.Sprintf("TargetCompID=%s%d\n", templateCompID, port))
+
+	settings, err := quickfix.ParseSettings(strings.NewReader(b.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		port:    port,
+		options: o,
+		orders:  make(map[string]order),
+		streams: make(map[quickfix.SessionID]map[string]chan struct{}),
+	}
+	s.acceptor, err = quickfix.NewAcceptor(s, quickfix.NewMemoryStoreFactory(), settings, o.logFactory)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.acceptor.Start(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+// Port returns the port the server listens on.
+func (s *Server) Port() int {
+	return s.port
+}
+
+// Addr returns the "host:port" address the server listens on.
+func (s *Server) Addr() string {
+	return net.JoinHostPort("127.0.0.1", strconv.Itoa(s.port))
+}
+
+// Settings returns initiator settings connecting senderCompID to the server,
+// for use as fix.Config.Settings. Use a "BMD" prefix for market data sessions
+// and "BOE" for order entry, as the client derives the endpoint type from it.
+func (s *Server) Settings(senderCompID string) (*quickfix.Settings, error) {
+	var b strings.Builder
+	b.WriteString("[DEFAULT]\n")
+	b.WriteString(fmt.Sprintf("BeginString=%s\n", beginString))
+	b.WriteString("SocketConnectHost=127.0.0.1\n")
+	b.WriteString(fmt.Sprintf("SocketConnectPort=%d\n", s.port))
+	b.WriteString(fmt.Sprintf("HeartBtInt=%d\n", defaultHeartBeat))
+	b.WriteString(fmt.Sprintf("SenderCompID=%s\n", senderCompID))
+	b.WriteString(fmt.Sprintf("TargetCompID=%s\n", TargetCompID))
+	b.WriteString("ConnectionType=initiator\n")
+	b.WriteString("\n")
+	b.WriteString("[SESSION]\n")
+
+	return quickfix.ParseSettings(strings.NewReader(b.String()))
+}
+
+// Close logs out all sessions and stops listening.
+func (s *Server) Close() {
+	s.acceptor.Stop()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for sessionID := range s.streams {
+		s.stopStreamsLocked(sessionID)
+	}
+}
+
+// GenerateKey creates an Ed25519 key pair, returning the private key as the
+// PKCS#8 PEM block expected by fix.Config.PrivateKeyPEM.
+func GenerateKey() (privateKeyPEM []byte, publicKey ed25519.PublicKey, err error) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), publicKey, nil
+}
+
+/* IMPLEMENT quickfix.Application INTERFACE */
+
+func (s *Server) OnCreate(quickfix.SessionID) {}
+
+func (s *Server) OnLogon(quickfix.SessionID) {}
+
+func (s *Server) OnLogout(sessionID quickfix.SessionID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopStreamsLocked(sessionID)
+}
+
+// ToAdmin shapes the logon response like Binance's, which carries a session
+// UUID and does not echo ResetSeqNumFlag. Echoing it would make the client
+// reset its sequence numbers a second time.
+func (s *Server) ToAdmin(msg *quickfix.Message, _ quickfix.SessionID) {
+	if msg.IsMsgTypeOf(string(enum.MsgType_LOGON)) {
+		msg.Body.Remove(tag.ResetSeqNumFlag)
+		msg.Body.SetString(tagUUID, uuid.NewString())
+	}
+}
+
+func (s *Server) ToApp(*quickfix.Message, quickfix.SessionID) error {
+	return nil
+}
+
+func (s *Server) FromAdmin(msg *quickfix.Message, _ quickfix.SessionID) quickfix.MessageRejectError {
+	if msg.IsMsgTypeOf(string(enum.MsgType_LOGON)) {
+		if err := s.verifyLogon(msg); err != nil {
+			return quickfix.RejectLogon{Text: err.Error()}
+		}
+	}
+	return nil
+}
+
+func (s *Server) FromApp(msg *quickfix.Message, sessionID quickfix.SessionID) quickfix.MessageRejectError {
+	msgType, err := msg.MsgType()
+	if err != nil {
+		return err
+	}
+
+	switch enum.MsgType(msgType) {
+	case enum.MsgType_ORDER_SINGLE:
+		return s.onNewOrderSingle(msg, sessionID)
+	case enum.MsgType_ORDER_CANCEL_REQUEST:
+		return s.onOrderCancelRequest(msg, sessionID)
+	case enum.MsgType_ORDER_MASS_CANCEL_REQUEST:
+		return s.onOrderMassCancelRequest(msg, sessionID)
+	case msgTypeOrderAmendKeepPriority:
+		return s.onOrderAmendKeepPriority(msg, sessionID)
+	case enum.MsgType_ORDER_STATUS_REQUEST:
+		return s.onOrderStatusRequest(msg, sessionID)
+	case enum.MsgType_MARKET_DATA_REQUEST:
+		return s.onMarketDataRequest(msg, sessionID)
+	case enum.MsgType_SECURITY_LIST_REQUEST:
+		return s.onInstrumentListRequest(msg, sessionID)
+	case msgTypeLimitQuery:
+		return s.onLimitQuery(msg, sessionID)
+	default:
+		return quickfix.UnsupportedMessageType()
+	}
+}
+
+// verifyLogon checks the RawData signature over the logon header fields, as
+// described in Binance's FIX API documentation.
+func (s *Server) verifyLogon(msg *quickfix.Message) error {
+	apiKey, err := msg.Body.GetString(tag.Username)
+	if err != nil {
+		return ErrUnknownAPIKey
+	}
+	publicKey, ok := s.options.credentials[apiKey]
+	if !ok {
+		return ErrUnknownAPIKey
+	}
+
+	rawData, err := msg.Body.GetString(tag.RawData)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	signature, decodeErr := base64.StdEncoding.DecodeString(rawData)
+	if decodeErr != nil {
+		return ErrInvalidSignature
+	}
+
+	fields := make([]string, 0, 5)
+	fields = append(fields, string(enum.MsgType_LOGON))
+	for _, t := range []quickfix.Tag{tag.SenderCompID, tag.TargetCompID, tag.MsgSeqNum, tag.SendingTime} {
+		v, err := msg.Header.GetString(t)
+		if err != nil {
+			return ErrInvalidSignature
+		}
+		fields = append(fields, v)
+	}
+
+	if !ed25519.Verify(publicKey, []byte(strings.Join(fields, "\x01")), signature) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (s *Server) nextIDs() (orderID, execID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.orderID++
+	s.execID++
+	return s.orderID, s.execID
+}
+
+func (s *Server) lastTradePrice(symbol string) string {
+	trades := s.options.trades[symbol]
+	if len(trades) == 0 {
+		return "0"
+	}
+	return formatFloat(trades[len(trades)-1].Price)
+}
@@ -0,0 +1,265 @@
+package fix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+
+	"github.com/ljm2ya/binance_fix_api/account"
+	"github.com/ljm2ya/binance_fix_api/fixtest"
+	"github.com/ljm2ya/binance_fix_api/handlers"
+)
+
+func TestFixtestLogonSignature(t *testing.T) {
+	gw := startTestGateway(t)
+	// Sessions are registered process wide, so only one client at a time.
+	gw.startClient(t).Stop()
+
+	otherKey, _, err := fixtest.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := gw.newClient(t, otherKey, WithLogonTimeout(10*time.Second))
+	err = client.Start(context.Background())
+	var logonErr *LogonError
+	if !errors.As(err, &logonErr) {
+		t.Fatalf("logon signed with another key: %v, want a *LogonError", err)
+	}
+	if !strings.Contains(logonErr.Reason, fixtest.ErrInvalidSignature.Error()) {
+		t.Fatalf("logon rejected for %q, want %q", logonErr.Reason, fixtest.ErrInvalidSignature)
+	}
+}
+
+// stripCancelClOrdID removes the ClOrdID from cancel responses, so the client
+// has to match them on the OrigClOrdID of the pending cancel.
+func stripCancelClOrdID(msg *quickfix.Message) error {
+	msgType, _ := msg.MsgType()
+	execType, _ := msg.Body.GetString(tag.ExecType)
+	if enum.MsgType(msgType) == enum.MsgType_ORDER_CANCEL_REJECT || enum.ExecType(execType) == enum.ExecType_CANCELED {
+		msg.Body.Remove(tag.ClOrdID)
+	}
+	return nil
+}
+
+func TestFixtestCancelMatchedByOrigClOrdID(t *testing.T) {
+	client := startTestClient(t, WithReceiveInterceptor(stripCancelClOrdID))
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if _, err := client.NewOrderSingleService().
+		ClOrdID("order-1").
+		Symbol("BTCUSDT").
+		Side(enum.Side_BUY).
+		Type(enum.OrdType_LIMIT).
+		TimeInForce(enum.TimeInForce_GOOD_TILL_CANCEL).
+		Quantity(1).
+		Price(100).
+		Do(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	canceled, err := client.NewOrderCancelService().ClOrdID("cancel-1").Symbol("BTCUSDT").OrigClOrdID("order-1").Do(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if canceled.OrigClientOrderID != "order-1" || canceled.Status != handlers.OrderStatusCanceled {
+		t.Fatalf("cancel answered with %+v", canceled)
+	}
+
+	_, err = client.NewOrderCancelService().ClOrdID("cancel-2").Symbol("BTCUSDT").OrigClOrdID("order-1").Do(ctx)
+	var reject *handlers.CancelReject
+	if !errors.As(err, &reject) || reject.OrigClOrdID != "order-1" {
+		t.Fatalf("second cancel: %v, want a *handlers.CancelReject", err)
+	}
+
+	if n := client.Health().PendingCalls; n != 0 {
+		t.Fatalf("%d calls still pending", n)
+	}
+}
+
+func TestFixtestAccountProjection(t *testing.T) {
+	gw := startTestGateway(t, fixtest.WithTrades(handlers.Trade{Symbol: "BTCUSDT", TradeID: 1, Price: 100, Quantity: 1}))
+	client := gw.startClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	book := account.New(account.FetcherFunc(func(context.Context) ([]account.Balance, error) {
+		return []account.Balance{{Asset: "USDT", Free: 1000}}, nil
+	}))
+	if err := book.Refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+	client.SubscribeToExecutionReport(book.HandleExecutionReport)
+
+	// A limit order rests unfilled and is canceled, a market order fills at
+	// the last trade price.
+	if _, err := client.NewOrderSingleService().
+		ClOrdID("limit-1").
+		Symbol("BTCUSDT").
+		Side(enum.Side_SELL).
+		Type(enum.OrdType_LIMIT).
+		TimeInForce(enum.TimeInForce_GOOD_TILL_CANCEL).
+		Quantity(1).
+		Price(150).
+		Do(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.NewOrderCancelService().ClOrdID("cancel-1").Symbol("BTCUSDT").OrigClOrdID("limit-1").Do(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.NewOrderSingleService().
+		ClOrdID("market-1").
+		Symbol("BTCUSDT").
+		Side(enum.Side_BUY).
+		Type(enum.OrdType_MARKET).
+		Quantity(2).
+		Do(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	for asset, want := range map[string]float64{"BTC": 2, "USDT": 800} {
+		if got := book.Balance(asset).Projected.Free; got != want {
+			t.Errorf("projected %s %v, want %v", asset, got, want)
+		}
+	}
+	if got := book.Balance("USDT").Confirmed.Free; got != 1000 {
+		t.Errorf("confirmed USDT %v, want 1000", got)
+	}
+}
+
+func TestFixtestExecutionReportsInOrder(t *testing.T) {
+	client := startTestClient(t, WithCallbackWorkers(4))
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		statuses = make(map[int64][]handlers.OrderStatus)
+		done     = make(chan struct{}, 64)
+	)
+	client.SubscribeToExecutionReport(func(o *handlers.Order) {
+		if o.Status == handlers.OrderStatusNew {
+			// Give the cancel a chance to overtake the NEW on another worker.
+			time.Sleep(time.Millisecond)
+		}
+		mu.Lock()
+		statuses[o.OrderID] = append(statuses[o.OrderID], o.Status)
+		mu.Unlock()
+		if o.Status == handlers.OrderStatusCanceled {
+			done <- struct{}{}
+		}
+	})
+
+	const orders = 32
+	var wg sync.WaitGroup
+	for i := range orders {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			clOrdID := fmt.Sprintf("order-%d", i)
+			if _, err := client.NewOrderSingleService().
+				ClOrdID(clOrdID).
+				Symbol("BTCUSDT").
+				Side(enum.Side_BUY).
+				Type(enum.OrdType_LIMIT).
+				TimeInForce(enum.TimeInForce_GOOD_TILL_CANCEL).
+				Quantity(1).
+				Price(100).
+				Do(ctx); err != nil {
+				t.Error(err)
+				return
+			}
+			if _, err := client.NewOrderCancelService().ClOrdID(clOrdID + "-c").Symbol("BTCUSDT").OrigClOrdID(clOrdID).Do(ctx); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for range orders {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			t.Fatal("not every cancel was delivered")
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(statuses) != orders {
+		t.Fatalf("reports of %d orders delivered, want %d", len(statuses), orders)
+	}
+	for orderID, got := range statuses {
+		if len(got) != 2 || got[0] != handlers.OrderStatusNew || got[1] != handlers.OrderStatusCanceled {
+			t.Errorf("order %d delivered as %v, want NEW then CANCELED", orderID, got)
+		}
+	}
+}
+
+func TestFixtestStreamsResumeAfterRotation(t *testing.T) {
+	gw := startTestGateway(t,
+		fixtest.WithBook("BTCUSDT", []fixtest.Level{{Price: 99, Quantity: 1}}, []fixtest.Level{{Price: 101, Quantity: 2}}),
+		fixtest.WithTrades(
+			handlers.Trade{Symbol: "ETHUSDT", TradeID: 1, Price: 10, Quantity: 1},
+			handlers.Trade{Symbol: "BNBUSDT", TradeID: 2, Price: 5, Quantity: 1},
+		),
+	)
+	client := gw.startClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	depth := make(chan string, 16)
+	trades := make(chan string, 16)
+	aggTrades := make(chan string, 16)
+	client.SubscribeToDepthUpdate(func(u *DepthUpdate) { depth <- u.MDReqID })
+	client.SubscribeToTradeStream(func(trade *handlers.Trade) {
+		if trade.Symbol == "ETHUSDT" {
+			mdReqID, _ := trade.Raw.Body.GetString(tag.MDReqID)
+			trades <- mdReqID
+		}
+	})
+	client.SubscribeToAggTradeStream(func(a *handlers.AggTrade) { aggTrades <- a.Symbol })
+
+	depthReqID, err := client.SubscribeToDepth(ctx, "BTCUSDT", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sub, err := client.NewTradeSubscription(ctx, []string{"ETHUSDT"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.SubscribeToAggTrades(ctx, []string{"BNBUSDT"}); err != nil {
+		t.Fatal(err)
+	}
+
+	expect := func(stream string, ch <-chan string, want string) {
+		t.Helper()
+		select {
+		case got := <-ch:
+			if got != want {
+				t.Errorf("%s of %s, want %s", stream, got, want)
+			}
+		case <-ctx.Done():
+			t.Fatalf("no %s", stream)
+		}
+	}
+	tradeReqID := sub.mdReqID["ETHUSDT"]
+	expect("depth update", depth, depthReqID)
+	expect("trade", trades, tradeReqID)
+	expect("aggregated trade", aggTrades, "BNBUSDT")
+
+	if err := client.RotateCredentials("test", gw.privateKey); err != nil {
+		t.Fatalf("RotateCredentials: %v", err)
+	}
+	expect("depth update after rotation", depth, depthReqID)
+	expect("trade after rotation", trades, tradeReqID)
+	expect("aggregated trade after rotation", aggTrades, "BNBUSDT")
+}
@@ -0,0 +1,61 @@
+package fix
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ljm2ya/binance_fix_api/fixtest"
+)
+
+// testGateway is a fixtest gateway with the key of its "test" API key.
+type testGateway struct {
+	*fixtest.Server
+	privateKey []byte
+}
+
+// startTestGateway starts a fixtest gateway, closed when the test ends.
+func startTestGateway(t testing.TB, opts ...fixtest.Option) *testGateway {
+	t.Helper()
+	privateKey, publicKey, err := fixtest.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv, err := fixtest.NewServer(append([]fixtest.Option{fixtest.WithCredentials("test", publicKey)}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(srv.Close)
+	return &testGateway{Server: srv, privateKey: privateKey}
+}
+
+// newClient returns a client of the gateway signing its logons with
+// privateKey, stopped when the test ends.
+func (g *testGateway) newClient(t testing.TB, privateKey []byte, opts ...NewClientOption) *Client {
+	t.Helper()
+	settings, err := g.Settings("BOETEST1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := NewClient(Config{APIKey: "test", PrivateKeyPEM: privateKey, Settings: settings}, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Stop)
+	return client
+}
+
+// startClient logs a client on to the gateway.
+func (g *testGateway) startClient(t testing.TB, opts ...NewClientOption) *Client {
+	t.Helper()
+	client := g.newClient(t, g.privateKey, opts...)
+	if err := client.Start(context.Background()); err != nil {
+		t.Fatalf("logon: %v", err)
+	}
+	return client
+}
+
+// startTestClient logs a client on to a new fixtest gateway.
+func startTestClient(t testing.TB, opts ...NewClientOption) *Client {
+	t.Helper()
+	return startTestGateway(t).startClient(t, opts...)
+}
@@ -0,0 +1,197 @@
+package fix
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+const (
+	tagFirstBookUpdateID quickfix.Tag = 25043
+	tagLastBookUpdateID  quickfix.Tag = 25044
+
+	gapRecoveryTimeout = 10 * time.Second
+)
+
+var (
+	msgSeqNumField   = []byte("\x0134=")
+	possDupFlagField = []byte("\x0143=Y\x01")
+	newSeqNoField    = []byte("\x0136=")
+	logonMsgType     = []byte("\x0135=A\x01")
+	seqResetMsgType  = []byte("\x0135=4\x01")
+)
+
+type GapKind string
+
+const (
+	// GapMsgSeqNum is a gap in the MsgSeqNum of the session. quickfix asks
+	// for a resend of the missed messages itself.
+	GapMsgSeqNum GapKind = "MsgSeqNum"
+	// GapBookUpdateID is a gap in the book update IDs of a symbol, the local
+	// order book of the symbol is stale.
+	GapBookUpdateID GapKind = "BookUpdateID"
+)
+
+// GapDetected reports missed messages, From and To being the first and last
+// missing sequence number or book update ID.
+type GapDetected struct {
+	Kind   GapKind
+	Symbol string // of GapBookUpdateID
+	From   int64
+	To     int64
+}
+
+// WithGapRecovery requests a snapshot of depth levels of the order book of a
+// symbol whose book update IDs have a gap, and emits it to
+// SubscribeToMarketDataSnapshot listeners.
+func WithGapRecovery(depth int) NewClientOption {
+	return func(o *Options) {
+		o.gapRecoveryDepth = depth
+	}
+}
+
+// gapTracker follows the MsgSeqNum of the session and the book update IDs of
+// each symbol.
+type gapTracker struct {
+	mu         sync.Mutex
+	seqNum     int64
+	books      map[string]int64 // last book update ID by symbol
+	recovering map[string]bool
+}
+
+// observeSeqNum checks the MsgSeqNum of a raw inbound message. Resent
+// messages are skipped, a Logon or SequenceReset starts over.
+func (c *Client) observeSeqNum(data []byte) {
+	if bytes.Contains(data, possDupFlagField) {
+		return
+	}
+	seqNum, ok := rawIntField(data, msgSeqNumField)
+	if !ok {
+		return
+	}
+
+	t := &c.gaps
+	t.mu.Lock()
+	var gap *GapDetected
+	switch {
+	case bytes.Contains(data, logonMsgType):
+		t.seqNum = seqNum
+	case bytes.Contains(data, seqResetMsgType):
+		if newSeqNo, ok := rawIntField(data, newSeqNoField); ok {
+			t.seqNum = newSeqNo - 1
+		}
+	default:
+		if t.seqNum > 0 && seqNum > t.seqNum+1 {
+			gap = &GapDetected{Kind: GapMsgSeqNum, From: t.seqNum + 1, To: seqNum - 1}
+		}
+		t.seqNum = max(t.seqNum, seqNum)
+	}
+	t.mu.Unlock()
+
+	if gap != nil {
+		c.emitGap(gap)
+	}
+}
+
+// observeBookUpdate checks the book update IDs of market data messages.
+func (c *Client) observeBookUpdate(msgType enum.MsgType, msg *quickfix.Message) {
+	if msgType != enum.MsgType_MARKET_DATA_INCREMENTAL_REFRESH &&
+		msgType != enum.MsgType_MARKET_DATA_SNAPSHOT_FULL_REFRESH {
+		return
+	}
+	if !msg.Body.Has(tagLastBookUpdateID) || !msg.Body.Has(tag.Symbol) {
+		return
+	}
+	symbol, err := msg.Body.GetString(tag.Symbol)
+	if err != nil {
+		return
+	}
+	last, err := msg.Body.GetInt(tagLastBookUpdateID)
+	if err != nil {
+		return
+	}
+
+	t := &c.gaps
+	t.mu.Lock()
+	if t.books == nil {
+		t.books = make(map[string]int64)
+	}
+	prev, seen := t.books[symbol]
+	var gap *GapDetected
+	if msgType == enum.MsgType_MARKET_DATA_INCREMENTAL_REFRESH && seen && msg.Body.Has(tagFirstBookUpdateID) {
+		if first, err := msg.Body.GetInt(tagFirstBookUpdateID); err == nil && int64(first) > prev+1 {
+			gap = &GapDetected{Kind: GapBookUpdateID, Symbol: symbol, From: prev + 1, To: int64(first) - 1}
+		}
+	}
+	// A snapshot older than the updates seen so far doesn't rewind them.
+	t.books[symbol] = max(prev, int64(last))
+	t.mu.Unlock()
+
+	if gap != nil {
+		c.emitGap(gap)
+		if c.options.gapRecoveryDepth > 0 {
+			c.recoverBook(symbol)
+		}
+	}
+}
+
+func (c *Client) emitGap(gap *GapDetected) {
+	c.logger().Warnw("Missed messages", "kind", gap.Kind, "symbol", gap.Symbol, "from", gap.From, "to", gap.To)
+	c.emit(GapDetectedTopic, gap)
+}
+
+// recoverBook requests a snapshot of the book of symbol, unless one is
+// already on its way.
+func (c *Client) recoverBook(symbol string) {
+	t := &c.gaps
+	t.mu.Lock()
+	if t.recovering[symbol] {
+		t.mu.Unlock()
+		return
+	}
+	if t.recovering == nil {
+		t.recovering = make(map[string]bool)
+	}
+	t.recovering[symbol] = true
+	t.mu.Unlock()
+
+	// The snapshot is delivered by the goroutine running this callback, so
+	// it is waited for on another one.
+	go func() {
+		defer func() {
+			t.mu.Lock()
+			delete(t.recovering, symbol)
+			t.mu.Unlock()
+		}()
+
+		ctx, cancel := context.WithTimeout(c.Context(), gapRecoveryTimeout)
+		defer cancel()
+		snapshot, err := c.GetMarketDataSnapshot(ctx, symbol, c.options.gapRecoveryDepth)
+		if err != nil {
+			c.logger().Errorw("Failed to recover order book", "symbol", symbol, "err", err)
+			return
+		}
+		c.emit(MarketDataSnapshotTopic, &snapshot)
+	}()
+}
+
+// rawIntField reads the integer value of field, e.g. "\x0134=", from a raw
+// message.
+func rawIntField(data, field []byte) (int64, bool) {
+	i := bytes.Index(data, field)
+	if i < 0 {
+		return 0, false
+	}
+	value := data[i+len(field):]
+	if end := bytes.IndexByte(value, '\x01'); end >= 0 {
+		value = value[:end]
+	}
+	n, err := strconv.ParseInt(string(value), 10, 64)
+	return n, err == nil
+}
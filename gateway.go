@@ -0,0 +1,217 @@
+package fix
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/quickfix/config"
+)
+
+const (
+	gatewayBaseCooldown = time.Second
+	gatewayMaxCooldown  = time.Minute
+)
+
+var ErrNoGateway = errors.New("no gateway configured")
+
+// GatewayStatus describes the health of a single FIX gateway address.
+type GatewayStatus struct {
+	Address             string
+	Healthy             bool
+	ConsecutiveFailures int
+	LastError           error
+	LastFailure         time.Time
+	LastConnected       time.Time
+}
+
+// gatewayPool tracks dial health for a list of gateway addresses and orders
+// them so a failing gateway is skipped for an exponentially growing cooldown.
+type gatewayPool struct {
+	mu       sync.Mutex
+	gateways []*GatewayStatus
+	avoid    map[string]time.Time // deprioritized until, e.g. for maintenance
+}
+
+func newGatewayPool(addresses []string) *gatewayPool {
+	p := &gatewayPool{}
+	for _, address := range addresses {
+		p.gateways = append(p.gateways, &GatewayStatus{Address: address, Healthy: true})
+	}
+	return p
+}
+
+// dial tries gateways in order of health until one accepts the connection.
+// It returns the address of the gateway that was connected.
+func (p *gatewayPool) dial(ctx context.Context, dial upstreamDialer) (net.Conn, string, error) {
+	var lastErr error = ErrNoGateway
+	for _, address := range p.candidates(time.Now()) {
+		conn, err := dial(ctx, address)
+		if err == nil {
+			p.markConnected(address)
+			return conn, address, nil
+		}
+		p.markFailed(address, err)
+		lastErr = err
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, "", lastErr
+}
+
+// candidates returns addresses with healthy gateways first, in configured
+// order, followed by gateways in cooldown ordered by when they become eligible.
+func (p *gatewayPool) candidates(now time.Time) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	type candidate struct {
+		address  string
+		eligible time.Time
+		index    int
+	}
+	list := make([]candidate, 0, len(p.gateways))
+	for i, g := range p.gateways {
+		eligible := time.Time{}
+		if !g.Healthy {
+			eligible = g.LastFailure.Add(gatewayCooldown(g.ConsecutiveFailures))
+		}
+		if until := p.avoid[g.Address]; until.After(eligible) {
+			eligible = until
+		}
+		if eligible.Before(now) {
+			eligible = time.Time{}
+		}
+		list = append(list, candidate{g.Address, eligible, i})
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].eligible.Before(list[j].eligible)
+	})
+
+	addresses := make([]string, 0, len(list))
+	for _, c := range list {
+		addresses = append(addresses, c.address)
+	}
+	return addresses
+}
+
+func gatewayCooldown(failures int) time.Duration {
+	cooldown := gatewayBaseCooldown
+	for i := 1; i < failures && cooldown < gatewayMaxCooldown; i++ {
+		cooldown *= 2
+	}
+	return min(cooldown, gatewayMaxCooldown)
+}
+
+func (p *gatewayPool) markConnected(address string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, g := range p.gateways {
+		if g.Address == address {
+			g.Healthy = true
+			g.ConsecutiveFailures = 0
+			g.LastError = nil
+			g.LastConnected = time.Now()
+		}
+	}
+}
+
+func (p *gatewayPool) markFailed(address string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, g := range p.gateways {
+		if g.Address == address {
+			g.Healthy = false
+			g.ConsecutiveFailures++
+			g.LastError = err
+			g.LastFailure = time.Now()
+		}
+	}
+}
+
+// deprioritize moves address behind the other gateways until the given time
+// without counting it as a failure.
+func (p *gatewayPool) deprioritize(address string, until time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.avoid == nil {
+		p.avoid = make(map[string]time.Time)
+	}
+	p.avoid[address] = until
+}
+
+// Status returns a snapshot of all gateways.
+func (p *gatewayPool) Status() []GatewayStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	statuses := make([]GatewayStatus, 0, len(p.gateways))
+	for _, g := range p.gateways {
+		statuses = append(statuses, *g)
+	}
+	return statuses
+}
+
+// gatewayAddresses reads SocketConnectHost/Port and the numbered
+// SocketConnectHostN/PortN failover entries from settings.
+func gatewayAddresses(settings *quickfix.SessionSettings) ([]string, error) {
+	var addresses []string
+	for i := 0; ; i++ {
+		hostSetting, portSetting := config.SocketConnectHost, config.SocketConnectPort
+		if i > 0 {
+			hostSetting += strconv.Itoa(i)
+			portSetting += strconv.Itoa(i)
+		}
+		if !settings.HasSetting(hostSetting) {
+			break
+		}
+
+		host, err := settings.Setting(hostSetting)
+		if err != nil {
+			return nil, err
+		}
+		port, err := settings.Setting(portSetting)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, net.JoinHostPort(host, port))
+	}
+
+	if len(addresses) == 0 {
+		return nil, ErrNoGateway
+	}
+	return addresses, nil
+}
+
+// setGatewayAddresses points every configured gateway slot at address so
+// quickfix's own rotation always lands on it.
+func setGatewayAddresses(settings *quickfix.SessionSettings, count int, host string, port int) {
+	for i := 0; i < count; i++ {
+		hostSetting, portSetting := config.SocketConnectHost, config.SocketConnectPort
+		if i > 0 {
+			hostSetting += strconv.Itoa(i)
+			portSetting += strconv.Itoa(i)
+		}
+		settings.Set(hostSetting, host)
+		settings.Set(portSetting, strconv.Itoa(port))
+	}
+}
+
+// GatewayHealth returns the health of each gateway the client can fail over
+// to. It is empty when the connection is dialed by quickfix directly.
+func (c *Client) GatewayHealth() []GatewayStatus {
+	if c.relay == nil {
+		return nil
+	}
+	return c.relay.gateways.Status()
+}
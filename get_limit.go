@@ -3,7 +3,6 @@ package fix
 import (
 	"context"
 
-	"github.com/google/uuid"
 	"github.com/quickfixgo/field"
 	"github.com/quickfixgo/quickfix"
 )
@@ -57,22 +56,22 @@ func (c *Client) NewGetLimitService() *LimitService {
 }
 
 func (s *LimitService) Do(ctx context.Context) (LimitResponse, error) {
-	id, err := uuid.NewRandom()
-	if err != nil {
-		return LimitResponse{}, err
-	}
+	resp, err := QueryAndDecode(ctx, s.c, func(id string) *quickfix.Message {
+		msg := quickfix.NewMessage()
+		msg.Header.Set(field.NewMsgType(msgType_LIMIT_REQUEST))
 
-	msg := quickfix.NewMessage()
-	msg.Header.Set(field.NewMsgType(msgType_LIMIT_REQUEST))
-
-	msg.Body.SetString(tagGetLimitReqID, id.String())
-
-	resp, err := s.c.Call(ctx, id.String(), msg)
+		msg.Body.SetString(tagGetLimitReqID, id)
+		return msg
+	}, DecodeLimitResponse)
 	if err != nil {
 		return LimitResponse{}, err
 	}
+	s.c.health.limits.Store(&limitsSeen{at: s.c.now(), limits: resp.Limits})
+	return resp, nil
+}
 
-	// parse response
+// DecodeLimitResponse decodes a LimitResponse <XLR> message.
+func DecodeLimitResponse(resp *quickfix.Message) (LimitResponse, error) {
 	reqID, err := resp.Body.GetString(tagGetLimitReqID)
 	if err != nil {
 		return LimitResponse{}, err
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+/*
+Tag     Name                Type            Required
+11      ClOrdID             STRING          Y
+41      OrigClOrdID         STRING          N
+37      OrderID             INT             N
+25015   OrigClListID        STRING          N
+25014   ClListID            STRING          N
+55      Symbol              STRING          N
+434     CxlRejResponseTo    CHAR            Y   1: ORDER_CANCEL_REQUEST, 2: ORDER_CANCEL_REPLACE_REQUEST
+102     CxlRejReason        INT             Y   99: OTHER
+25016   ErrorCode           INT             N
+58      Text                STRING          N
+*/
+
+// CancelReject is a rejected cancel (or cancel/replace) request. It
+// implements error so it can be returned as is from cancel calls.
+type CancelReject struct {
+	ClOrdID     string `json:"clientOrderId"`
+	OrigClOrdID string `json:"origClientOrderId"`
+	OrderID     int64  `json:"orderId"`
+	Symbol      string `json:"symbol"`
+	Reason      string `json:"reason"`
+	ErrorCode   string `json:"errorCode"`
+	Text        string `json:"text"`
+
+	// RetryAfter is how long the exchange asks to wait before sending again,
+	// see ParseRetryAfter.
+	RetryAfter time.Duration `json:"-"`
+
+	// Raw is the message the reject was decoded from, for tags not mapped above.
+	Raw *quickfix.Message `json:"-"`
+}
+
+func (r *CancelReject) Error() string {
+	if r.Text != "" {
+		return fmt.Sprintf("cancel rejected: %s", r.Text)
+	}
+	return fmt.Sprintf("cancel rejected: reason %s", r.Reason)
+}
+
+// DecodeOrderCancelReject parses a FIX OrderCancelReject message into a CancelReject struct.
+// Its RetryAfter is relative to the system clock.
+func DecodeOrderCancelReject(msg *quickfix.Message) (CancelReject, error) {
+	clOrdID, err := getClientOrderID(msg)
+	if err != nil {
+		return CancelReject{}, err
+	}
+	if clOrdID == "" {
+		return CancelReject{}, ErrClOrdIDNotFound
+	}
+
+	text, err := getText(msg)
+	if err != nil {
+		return CancelReject{}, err
+	}
+
+	errorCode := getOptionalString(msg, tagErrorCode)
+
+	var orderID int64
+	if s := getOptionalString(msg, tag.OrderID); s != "" {
+		if orderID, err = strconv.ParseInt(s, 10, 64); err != nil {
+			return CancelReject{}, err
+		}
+	}
+
+	return CancelReject{
+		ClOrdID:     clOrdID,
+		OrigClOrdID: getOptionalString(msg, tag.OrigClOrdID),
+		OrderID:     orderID,
+		Symbol:      getOptionalString(msg, tag.Symbol),
+		Reason:      getOptionalString(msg, tag.CxlRejReason),
+		ErrorCode:   errorCode,
+		Text:        text,
+		RetryAfter:  ParseRetryAfter(errorCode, text, time.Now()),
+		Raw:         msg,
+	}, nil
+}
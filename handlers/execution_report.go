@@ -2,130 +2,250 @@ package handlers
 
 import (
 	"errors"
+	"fmt"
 	"strconv"
 	"time"
 
+	"github.com/quickfixgo/enum"
 	"github.com/quickfixgo/field"
 	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
 )
 
 const (
 	utcTimestampMicrosFmt = "20060102-15:04:05.000000"
-	tagCumQuoteQty        = 381
+	tagCumQuoteQty        = 25017
+	tagGrossTradeAmt      = 381 // read when CumQuoteQty is missing
 	tagOrderCreationTime  = 6635
 	tagWorkingTime        = 636
 )
 
+// Fee is a fee charged for a fill.
+type Fee struct {
+	Amount float64 `json:"amount"`
+	Asset  string  `json:"asset"`
+	Type   FeeType `json:"type"`
+}
+
+// Timings are the monotonic timestamps of a request, for telling client-side
+// latency from the round trip to the exchange.
+type Timings struct {
+	Built    time.Time // building the request started
+	Enqueued time.Time // handed to the session
+	Sent     time.Time // stamped for the wire (ToApp)
+	Received time.Time // response arrived (FromApp)
+}
+
+// ClientLatency is the time from building the request to sending it.
+func (t Timings) ClientLatency() time.Duration {
+	if t.Built.IsZero() || t.Sent.IsZero() {
+		return 0
+	}
+	return t.Sent.Sub(t.Built)
+}
+
+// ExchangeLatency is the time from sending the request to receiving the
+// response, the network round trip included.
+func (t Timings) ExchangeLatency() time.Duration {
+	if t.Sent.IsZero() || t.Received.IsZero() {
+		return 0
+	}
+	return t.Received.Sub(t.Sent)
+}
+
 // Order represents a trading order with all relevant fields
 type Order struct {
-	Symbol            string
-	OrderID           int64
-	ClientOrderID     string
-	Price             float64
-	OrderQty          float64
-	CumQty            float64
-	CumQuoteQty       float64
-	Status            OrderStatus
-	TimeInForce       TimeInForce
-	Type              OrderType
-	Side              SideType
-	IcebergQuantity   float64
-	TransactTime      time.Time
-	OrderCreationTime time.Time
-	WorkingTime       time.Time
-}
-
-// DecodeExecutionReport parses a FIX ExecutionReport message into an Order struct
-func DecodeExecutionReport(msg *quickfix.Message) (Order, error) {
-	status, err := getOrderStatus(msg)
-	if err != nil {
-		return Order{}, err
-	}
+	Symbol            string      `json:"symbol"`
+	OrderID           int64       `json:"orderId"`
+	ClientOrderID     string      `json:"clientOrderId"`
+	OrigClientOrderID string      `json:"origClientOrderId"` // OrigClOrdID <41> of the order canceled, replaced or amended
+	ExecID            string      `json:"-"`                 // ExecID <17> of this report
+	Price             float64     `json:"price"`
+	OrderQty          float64     `json:"origQty"`
+	CumQty            float64     `json:"executedQty"`
+	CumQuoteQty       float64     `json:"cummulativeQuoteQty"`
+	Status            OrderStatus `json:"status"`
+	TimeInForce       TimeInForce `json:"timeInForce"`
+	Type              OrderType   `json:"type"`
+	Side              SideType    `json:"side"`
+	IcebergQuantity   float64     `json:"icebergQty"`
+	TransactTime      time.Time   `json:"transactTime"`
+	OrderCreationTime time.Time   `json:"orderCreationTime"`
+	WorkingTime       time.Time   `json:"workingTime"`
+
+	// LastPx, LastQty and TradeID describe the fill reported by this
+	// execution, if any.
+	LastPx  float64 `json:"lastPx"`
+	LastQty float64 `json:"lastQty"`
+	TradeID int64   `json:"tradeId"`
+
+	// Commission is the commission charged for the fill, in CommissionAsset.
+	// Binance reports it in Fees, which it is summed from when the report
+	// has no Commission field.
+	Commission      float64        `json:"commission"`
+	CommissionType  CommissionType `json:"commissionType"`
+	CommissionAsset string         `json:"commissionAsset"`
+	Fees            []Fee          `json:"fees"`
+
+	// RejectReason is the exchange's explanation (Text <58>) when Status is
+	// OrderStatusRejected, with the OrdRejReason <103> and Binance's
+	// ErrorCode <25016>.
+	RejectReason string `json:"rejectReason"`
+	OrdRejReason string `json:"ordRejReason"`
+	ErrorCode    string `json:"errorCode"`
+
+	// PossDup is set on a report the server resent with PossDupFlag <43>,
+	// which may have been received before.
+	PossDup bool `json:"-"`
+
+	// Timings of the request this order is the response to, zero for
+	// execution reports that aren't a response.
+	Timings Timings `json:"-"`
+
+	// Warnings lists the fields that could not be decoded.
+	Warnings DecodeWarnings `json:"warnings,omitempty"`
+
+	// Raw is the message the order was decoded from, for tags not mapped above.
+	Raw *quickfix.Message `json:"-"`
+}
 
-	if status == OrderStatusRejected {
-		reason, err := getText(msg)
-		if err != nil {
-			return Order{}, err
-		}
-		if reason != "" {
-			return Order{}, errors.New(reason)
-		}
+// OrderReject is a rejected order. It implements error so order calls can
+// return it while keeping the fully decoded Order.
+type OrderReject struct {
+	Order Order
+	// RetryAfter is how long the exchange asks to wait before sending again,
+	// zero unless the order was rejected for a rate limit or ban, see
+	// ParseRetryAfter.
+	RetryAfter time.Duration
+}
+
+func (r *OrderReject) Error() string {
+	if r.Order.RejectReason != "" {
+		return fmt.Sprintf("order rejected: %s", r.Order.RejectReason)
 	}
+	return fmt.Sprintf("order rejected: reason %s", r.Order.OrdRejReason)
+}
 
-	symbol, err := getSymbol(msg)
+var ErrClOrdIDNotFound = errors.New("ClOrdID not found")
+
+// DecodeWarning describes a field that could not be decoded and was left at
+// its zero value.
+type DecodeWarning struct {
+	Tag quickfix.Tag `json:"tag"`
+	Err error        `json:"error"`
+}
+
+func (w DecodeWarning) Error() string {
+	return fmt.Sprintf("tag %d: %v", w.Tag, w.Err)
+}
+
+// DecodeWarnings collects the fields skipped by a best-effort decode.
+type DecodeWarnings []DecodeWarning
+
+func (w *DecodeWarnings) add(t quickfix.Tag, err error) {
 	if err != nil {
-		return Order{}, err
+		*w = append(*w, DecodeWarning{Tag: t, Err: err})
 	}
+}
 
-	orderID, err := getOrderID(msg)
-	if err != nil {
-		return Order{}, err
+// DecodeExecutionReport parses a FIX ExecutionReport message into an Order struct.
+// Only a missing ClOrdID or Symbol fails the decode. Other fields that are
+// missing or malformed are left at their zero value and reported in
+// Order.Warnings. A rejected order is decoded like any other, with Status
+// OrderStatusRejected and the reason in RejectReason.
+func DecodeExecutionReport(msg *quickfix.Message) (Order, error) {
+	var warnings DecodeWarnings
+
+	status, err := getOrderStatus(msg)
+	warnings.add(tag.OrdStatus, err)
+
+	var rejectReason string
+	if status == OrderStatusRejected {
+		rejectReason, err = getText(msg)
+		warnings.add(tag.Text, err)
 	}
 
 	clientOrderID, err := getClientOrderID(msg)
 	if err != nil {
 		return Order{}, err
 	}
+	if clientOrderID == "" {
+		return Order{}, ErrClOrdIDNotFound
+	}
 
-	price, err := getPrice(msg)
+	symbol, err := getSymbol(msg)
 	if err != nil {
 		return Order{}, err
 	}
 
+	orderID, err := getOrderID(msg)
+	warnings.add(tag.OrderID, err)
+
+	price, err := getPrice(msg)
+	warnings.add(tag.Price, err)
+
 	orderQty, err := getOrderQty(msg)
-	if err != nil {
-		return Order{}, err
-	}
+	warnings.add(tag.OrderQty, err)
 
 	cumQty, err := getCumQty(msg)
-	if err != nil {
-		return Order{}, err
-	}
+	warnings.add(tag.CumQty, err)
 
 	cumQuoteQty, err := getCumQuoteQty(msg)
-	if err != nil {
-		return Order{}, err
-	}
+	warnings.add(tagCumQuoteQty, err)
 
 	timeInForce, err := getTimeInForce(msg)
-	if err != nil {
-		return Order{}, err
-	}
+	warnings.add(tag.TimeInForce, err)
 
 	orderType, err := getOrdType(msg)
-	if err != nil {
-		return Order{}, err
-	}
+	warnings.add(tag.OrdType, err)
 
 	side, err := getSide(msg)
-	if err != nil {
-		return Order{}, err
-	}
+	warnings.add(tag.Side, err)
 
 	maxFloor, err := getMaxFloor(msg)
-	if err != nil {
-		return Order{}, err
-	}
+	warnings.add(tag.MaxFloor, err)
 
 	transactTime, err := getTransactTime(msg)
-	if err != nil {
-		return Order{}, err
-	}
+	warnings.add(tag.TransactTime, err)
 
 	orderCreationTime, err := getOrderCreationTime(msg)
-	if err != nil {
-		return Order{}, err
-	}
+	warnings.add(tagOrderCreationTime, err)
 
 	workingTime, err := getWorkingTime(msg)
-	if err != nil {
-		return Order{}, err
+	warnings.add(tagWorkingTime, err)
+
+	lastPx, err := getLastPx(msg)
+	warnings.add(tag.LastPx, err)
+
+	lastQty, err := getLastQty(msg)
+	warnings.add(tag.LastQty, err)
+
+	tradeID, err := getExecTradeID(msg)
+	warnings.add(tag.TradeID, err)
+
+	commission, err := getCommission(msg)
+	warnings.add(tag.Commission, err)
+
+	commissionType, err := getCommType(msg)
+	warnings.add(tag.CommType, err)
+
+	commissionAsset, err := getCommCurrency(msg)
+	warnings.add(tag.CommCurrency, err)
+
+	fees, err := getMiscFees(msg)
+	warnings.add(tag.NoMiscFees, err)
+
+	if !msg.Body.Has(tag.Commission) && len(fees) > 0 {
+		commission, commissionAsset = sumFees(fees)
 	}
 
 	return Order{
 		Symbol:            symbol,
 		OrderID:           orderID,
 		ClientOrderID:     clientOrderID,
+		OrigClientOrderID: getOptionalString(msg, tag.OrigClOrdID),
+		ExecID:            getOptionalString(msg, tag.ExecID),
+		PossDup:           getPossDup(msg),
 		Price:             price,
 		OrderQty:          orderQty,
 		CumQty:            cumQty,
@@ -138,11 +258,28 @@ func DecodeExecutionReport(msg *quickfix.Message) (Order, error) {
 		TransactTime:      transactTime,
 		OrderCreationTime: orderCreationTime,
 		WorkingTime:       workingTime,
+		LastPx:            lastPx,
+		LastQty:           lastQty,
+		TradeID:           tradeID,
+		Commission:        commission,
+		CommissionType:    commissionType,
+		CommissionAsset:   commissionAsset,
+		Fees:              fees,
+		RejectReason:      rejectReason,
+		OrdRejReason:      getOptionalString(msg, tag.OrdRejReason),
+		ErrorCode:         getOptionalString(msg, tagErrorCode),
+		Warnings:          warnings,
+		Raw:               msg,
 	}, nil
 }
 
 // Field extraction functions
 
+func getPossDup(msg *quickfix.Message) bool {
+	possDup, _ := msg.Header.GetBool(tag.PossDupFlag)
+	return possDup
+}
+
 func getText(msg *quickfix.Message) (v string, err error) {
 	var f field.TextField
 	if msg.Body.Has(f.Tag()) {
@@ -250,12 +387,14 @@ func getCumQty(msg *quickfix.Message) (float64, error) {
 }
 
 func getCumQuoteQty(msg *quickfix.Message) (float64, error) {
-	if msg.Body.Has(tagCumQuoteQty) {
-		str, err := msg.Body.GetString(tagCumQuoteQty)
-		if err != nil {
-			return 0, err
+	for _, t := range [...]quickfix.Tag{tagCumQuoteQty, tagGrossTradeAmt} {
+		if msg.Body.Has(t) {
+			str, err := msg.Body.GetString(t)
+			if err != nil {
+				return 0, err
+			}
+			return strconv.ParseFloat(str, 64)
 		}
-		return strconv.ParseFloat(str, 64)
 	}
 	return 0, nil
 }
@@ -301,4 +440,128 @@ func getWorkingTime(msg *quickfix.Message) (time.Time, error) {
 		return time.Parse(utcTimestampMicrosFmt, str)
 	}
 	return time.Time{}, nil
-}
\ No newline at end of file
+}
+
+func getLastPx(msg *quickfix.Message) (float64, error) {
+	var f field.LastPxField
+	if msg.Body.Has(f.Tag()) {
+		if err := msg.Body.Get(&f); err != nil {
+			return 0, err
+		}
+		return f.InexactFloat64(), nil
+	}
+	return 0, nil
+}
+
+func getLastQty(msg *quickfix.Message) (float64, error) {
+	var f field.LastQtyField
+	if msg.Body.Has(f.Tag()) {
+		if err := msg.Body.Get(&f); err != nil {
+			return 0, err
+		}
+		return f.InexactFloat64(), nil
+	}
+	return 0, nil
+}
+
+func getExecTradeID(msg *quickfix.Message) (int64, error) {
+	if msg.Body.Has(tag.TradeID) {
+		str, err := msg.Body.GetString(tag.TradeID)
+		if err != nil {
+			return 0, err
+		}
+		return strconv.ParseInt(str, 10, 64)
+	}
+	return 0, nil
+}
+
+func getCommission(msg *quickfix.Message) (float64, error) {
+	var f field.CommissionField
+	if msg.Body.Has(f.Tag()) {
+		if err := msg.Body.Get(&f); err != nil {
+			return 0, err
+		}
+		return f.InexactFloat64(), nil
+	}
+	return 0, nil
+}
+
+func getCommType(msg *quickfix.Message) (v CommissionType, err error) {
+	var f field.CommTypeField
+	if msg.Body.Has(f.Tag()) {
+		if err = msg.Body.Get(&f); err == nil {
+			v = mappedCommissionType[f.Value()]
+		}
+	}
+	return
+}
+
+func getCommCurrency(msg *quickfix.Message) (v string, err error) {
+	var f field.CommCurrencyField
+	if msg.Body.Has(f.Tag()) {
+		if err = msg.Body.Get(&f); err == nil {
+			v = f.Value()
+		}
+	}
+	return
+}
+
+func getMiscFees(msg *quickfix.Message) ([]Fee, error) {
+	if !msg.Body.Has(tag.NoMiscFees) {
+		return nil, nil
+	}
+
+	group := quickfix.NewRepeatingGroup(tag.NoMiscFees, quickfix.GroupTemplate{
+		quickfix.GroupElement(tag.MiscFeeAmt),
+		quickfix.GroupElement(tag.MiscFeeCurr),
+		quickfix.GroupElement(tag.MiscFeeType),
+	})
+	if err := msg.Body.GetGroup(group); err != nil {
+		return nil, err
+	}
+
+	fees := make([]Fee, 0, group.Len())
+	for i := range group.Len() {
+		fee, err := decodeFee(group.Get(i))
+		if err != nil {
+			return nil, err
+		}
+		fees = append(fees, fee)
+	}
+
+	return fees, nil
+}
+
+func decodeFee(entry *quickfix.Group) (fee Fee, err error) {
+	amount, err := entry.GetString(tag.MiscFeeAmt)
+	if err != nil {
+		return fee, err
+	}
+	if fee.Amount, err = strconv.ParseFloat(amount, 64); err != nil {
+		return fee, err
+	}
+	if entry.Has(tag.MiscFeeCurr) {
+		if fee.Asset, err = entry.GetString(tag.MiscFeeCurr); err != nil {
+			return fee, err
+		}
+	}
+	if entry.Has(tag.MiscFeeType) {
+		var feeType string
+		if feeType, err = entry.GetString(tag.MiscFeeType); err != nil {
+			return fee, err
+		}
+		fee.Type = mappedFeeType[enum.MiscFeeType(feeType)]
+	}
+	return fee, nil
+}
+
+// sumFees adds up the fees charged in the asset of the first one.
+func sumFees(fees []Fee) (amount float64, asset string) {
+	asset = fees[0].Asset
+	for _, fee := range fees {
+		if fee.Asset == asset {
+			amount += fee.Amount
+		}
+	}
+	return amount, asset
+}
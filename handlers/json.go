@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/quickfixgo/quickfix"
+)
+
+// The decoded messages marshal to JSON the way the Binance APIs do: field
+// names follow the REST API, cummulativeQuoteQty typo included, times are
+// milliseconds since the Unix epoch (0 when unset) and enums are their
+// upper-case names. The raw FIX message is left out. Structs added to this
+// package are expected to follow the same conventions.
+
+// epochMillis returns t in milliseconds since the Unix epoch, 0 for the zero
+// time.
+func epochMillis(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+// fromEpochMillis is the inverse of epochMillis.
+func fromEpochMillis(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms).UTC()
+}
+
+func (o Order) MarshalJSON() ([]byte, error) {
+	type order Order
+	return json.Marshal(struct {
+		order
+		TransactTime      int64 `json:"transactTime"`
+		OrderCreationTime int64 `json:"orderCreationTime"`
+		WorkingTime       int64 `json:"workingTime"`
+	}{
+		order:             order(o),
+		TransactTime:      epochMillis(o.TransactTime),
+		OrderCreationTime: epochMillis(o.OrderCreationTime),
+		WorkingTime:       epochMillis(o.WorkingTime),
+	})
+}
+
+func (o *Order) UnmarshalJSON(data []byte) error {
+	type order Order
+	aux := struct {
+		*order
+		TransactTime      int64 `json:"transactTime"`
+		OrderCreationTime int64 `json:"orderCreationTime"`
+		WorkingTime       int64 `json:"workingTime"`
+	}{order: (*order)(o)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	o.TransactTime = fromEpochMillis(aux.TransactTime)
+	o.OrderCreationTime = fromEpochMillis(aux.OrderCreationTime)
+	o.WorkingTime = fromEpochMillis(aux.WorkingTime)
+	return nil
+}
+
+func (t Trade) MarshalJSON() ([]byte, error) {
+	type trade Trade
+	return json.Marshal(struct {
+		trade
+		TradeTime int64 `json:"time"`
+		EventTime int64 `json:"eventTime"`
+	}{
+		trade:     trade(t),
+		TradeTime: epochMillis(t.TradeTime),
+		EventTime: epochMillis(t.EventTime),
+	})
+}
+
+func (t *Trade) UnmarshalJSON(data []byte) error {
+	type trade Trade
+	aux := struct {
+		*trade
+		TradeTime int64 `json:"time"`
+		EventTime int64 `json:"eventTime"`
+	}{trade: (*trade)(t)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	t.TradeTime = fromEpochMillis(aux.TradeTime)
+	t.EventTime = fromEpochMillis(aux.EventTime)
+	return nil
+}
+
+func (s ListStatus) MarshalJSON() ([]byte, error) {
+	type listStatus ListStatus
+	return json.Marshal(struct {
+		listStatus
+		TransactTime int64 `json:"transactionTime"`
+	}{
+		listStatus:   listStatus(s),
+		TransactTime: epochMillis(s.TransactTime),
+	})
+}
+
+func (s *ListStatus) UnmarshalJSON(data []byte) error {
+	type listStatus ListStatus
+	aux := struct {
+		*listStatus
+		TransactTime int64 `json:"transactionTime"`
+	}{listStatus: (*listStatus)(s)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	s.TransactTime = fromEpochMillis(aux.TransactTime)
+	return nil
+}
+
+// MarshalJSON encodes the error as its message.
+func (w DecodeWarning) MarshalJSON() ([]byte, error) {
+	var msg string
+	if w.Err != nil {
+		msg = w.Err.Error()
+	}
+	return json.Marshal(struct {
+		Tag int    `json:"tag"`
+		Err string `json:"error"`
+	}{int(w.Tag), msg})
+}
+
+func (w *DecodeWarning) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Tag int    `json:"tag"`
+		Err string `json:"error"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	w.Tag = quickfix.Tag(aux.Tag)
+	w.Err = nil
+	if aux.Err != "" {
+		w.Err = errors.New(aux.Err)
+	}
+	return nil
+}
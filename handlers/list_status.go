@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+/*
+Tag     Name                Type            Required
+55      Symbol              STRING          Y
+66      ListID              STRING          N
+25014   ClListID            STRING          N
+25015   OrigClListID        STRING          N
+1385    ContingencyType     CHAR            N   1: ONE_CANCELS_THE_OTHER, 2: ONE_TRIGGERS_THE_OTHER
+429     ListStatusType      INT             Y   2: RESPONSE, 4: EXEC_STARTED, 5: ALL_DONE, 100: UPDATED
+431     ListOrderStatus     INT             Y   3: EXECUTING, 6: ALL_DONE, 7: REJECT
+1386    ListRejectReason    INT             N   99: OTHER
+103     OrdRejReason        INT             N
+60      TransactTime        UTCTIMESTAMP    N
+25016   ErrorCode           INT             N
+58      Text                STRING          N
+73      NoOrders            NUMINGROUP      N
+» 55    Symbol              STRING          Y
+» 37    OrderID             INT             Y
+» 11    ClOrdID             STRING          Y
+*/
+
+const (
+	tagClListID     = 25014
+	tagOrigClListID = 25015
+	tagErrorCode    = 25016
+)
+
+// ListStatus represents the state of an order list (OCO, OTO, ...)
+type ListStatus struct {
+	Symbol           string            `json:"symbol"`
+	ListID           string            `json:"orderListId"`
+	ClListID         string            `json:"listClientOrderId"`
+	OrigClListID     string            `json:"origListClientOrderId"`
+	ContingencyType  ContingencyType   `json:"contingencyType"`
+	ListStatusType   ListStatusType    `json:"listStatusType"`
+	ListOrderStatus  ListOrderStatus   `json:"listOrderStatus"`
+	ListRejectReason string            `json:"listRejectReason"`
+	OrdRejReason     string            `json:"ordRejReason"`
+	ErrorCode        string            `json:"errorCode"`
+	Text             string            `json:"text"`
+	TransactTime     time.Time         `json:"transactionTime"`
+	Orders           []ListStatusOrder `json:"orders"`
+
+	// Raw is the message the list status was decoded from, for tags not mapped above.
+	Raw *quickfix.Message `json:"-"`
+}
+
+// ListStatusOrder is a single leg of an order list
+type ListStatusOrder struct {
+	Symbol        string `json:"symbol"`
+	OrderID       int64  `json:"orderId"`
+	ClientOrderID string `json:"clientOrderId"`
+}
+
+// DecodeListStatus parses a FIX ListStatus message into a ListStatus struct
+func DecodeListStatus(msg *quickfix.Message) (ListStatus, error) {
+	symbol, err := getSymbol(msg)
+	if err != nil {
+		return ListStatus{}, err
+	}
+
+	listStatusType, err := getListStatusType(msg)
+	if err != nil {
+		return ListStatus{}, err
+	}
+
+	listOrderStatus, err := getListOrderStatus(msg)
+	if err != nil {
+		return ListStatus{}, err
+	}
+
+	contingencyType, err := getContingencyType(msg)
+	if err != nil {
+		return ListStatus{}, err
+	}
+
+	text, err := getText(msg)
+	if err != nil {
+		return ListStatus{}, err
+	}
+
+	transactTime, err := getTransactTime(msg)
+	if err != nil {
+		return ListStatus{}, err
+	}
+
+	orders, err := getListStatusOrders(msg)
+	if err != nil {
+		return ListStatus{}, err
+	}
+
+	return ListStatus{
+		Symbol:           symbol,
+		ListID:           getOptionalString(msg, tag.ListID),
+		ClListID:         getOptionalString(msg, tagClListID),
+		OrigClListID:     getOptionalString(msg, tagOrigClListID),
+		ContingencyType:  contingencyType,
+		ListStatusType:   listStatusType,
+		ListOrderStatus:  listOrderStatus,
+		ListRejectReason: getOptionalString(msg, tag.ListRejectReason),
+		OrdRejReason:     getOptionalString(msg, tag.OrdRejReason),
+		ErrorCode:        getOptionalString(msg, tagErrorCode),
+		Text:             text,
+		TransactTime:     transactTime,
+		Orders:           orders,
+		Raw:              msg,
+	}, nil
+}
+
+func getOptionalString(msg *quickfix.Message, t quickfix.Tag) string {
+	if !msg.Body.Has(t) {
+		return ""
+	}
+	v, _ := msg.Body.GetString(t)
+	return v
+}
+
+func getListStatusType(msg *quickfix.Message) (v ListStatusType, err error) {
+	var s string
+	if s, err = msg.Body.GetString(tag.ListStatusType); err == nil {
+		v = mappedListStatusType[s]
+	}
+	return
+}
+
+func getListOrderStatus(msg *quickfix.Message) (v ListOrderStatus, err error) {
+	var f field.ListOrderStatusField
+	if err = msg.Body.Get(&f); err == nil {
+		v = mappedListOrderStatus[f.Value()]
+	}
+	return
+}
+
+func getContingencyType(msg *quickfix.Message) (v ContingencyType, err error) {
+	var f field.ContingencyTypeField
+	if msg.Body.Has(f.Tag()) {
+		if err = msg.Body.Get(&f); err == nil {
+			v = mappedContingencyType[f.Value()]
+		}
+	}
+	return
+}
+
+func getListStatusOrders(msg *quickfix.Message) ([]ListStatusOrder, error) {
+	if !msg.Body.Has(tag.NoOrders) {
+		return nil, nil
+	}
+
+	group := quickfix.NewRepeatingGroup(tag.NoOrders, quickfix.GroupTemplate{
+		quickfix.GroupElement(tag.Symbol),
+		quickfix.GroupElement(tag.OrderID),
+		quickfix.GroupElement(tag.ClOrdID),
+	})
+	if err := msg.Body.GetGroup(group); err != nil {
+		return nil, err
+	}
+
+	orders := make([]ListStatusOrder, 0, group.Len())
+	for i := range group.Len() {
+		leg := group.Get(i)
+
+		symbol, err := leg.GetString(tag.Symbol)
+		if err != nil {
+			return nil, err
+		}
+		orderID, err := leg.GetInt(tag.OrderID)
+		if err != nil {
+			return nil, err
+		}
+		clOrdID, err := leg.GetString(tag.ClOrdID)
+		if err != nil {
+			return nil, err
+		}
+
+		orders = append(orders, ListStatusOrder{
+			Symbol:        symbol,
+			OrderID:       int64(orderID),
+			ClientOrderID: clOrdID,
+		})
+	}
+
+	return orders, nil
+}
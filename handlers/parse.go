@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+const (
+	utcTimestampSecondsFmt = "20060102-15:04:05"
+	utcTimestampMillisFmt  = "20060102-15:04:05.000"
+	utcTimestampNanosFmt   = "20060102-15:04:05.000000000"
+)
+
+var ErrInvalidNumber = errors.New("invalid numeric field value")
+
+// bytesToString returns a string sharing memory with b. The result must not
+// outlive b, which for quickfix field values means the lifetime of the message.
+func bytesToString(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	return unsafe.String(unsafe.SliceData(b), len(b))
+}
+
+// parseInt64 parses a base 10 integer directly from field bytes without
+// allocating. Values out of the int64 range are invalid.
+func parseInt64(b []byte) (int64, error) {
+	if len(b) == 0 {
+		return 0, ErrInvalidNumber
+	}
+
+	neg := false
+	if b[0] == '-' {
+		neg = true
+		b = b[1:]
+		if len(b) == 0 {
+			return 0, ErrInvalidNumber
+		}
+	}
+
+	limit := uint64(math.MaxInt64)
+	if neg {
+		limit++
+	}
+	var n uint64
+	for _, c := range b {
+		if c < '0' || c > '9' {
+			return 0, ErrInvalidNumber
+		}
+		if n > (limit-uint64(c-'0'))/10 {
+			return 0, ErrInvalidNumber
+		}
+		n = n*10 + uint64(c-'0')
+	}
+	if neg {
+		return -int64(n), nil
+	}
+	return int64(n), nil
+}
+
+// parseFloat64 parses a decimal field value without copying the bytes.
+func parseFloat64(b []byte) (float64, error) {
+	return strconv.ParseFloat(bytesToString(b), 64)
+}
+
+// parseUTCTimestamp parses a FIX UTCTimestamp of seconds, millis, micros or nanos precision.
+func parseUTCTimestamp(b []byte) (time.Time, error) {
+	layout := utcTimestampSecondsFmt
+	switch len(b) {
+	case len(utcTimestampMillisFmt):
+		layout = utcTimestampMillisFmt
+	case len(utcTimestampMicrosFmt):
+		layout = utcTimestampMicrosFmt
+	case len(utcTimestampNanosFmt):
+		layout = utcTimestampNanosFmt
+	}
+	return time.Parse(layout, bytesToString(b))
+}
+
+// maxInternedSymbols bounds symbolCache. Binance lists a few thousand
+// symbols; once full, unseen symbols are allocated per message instead.
+const maxInternedSymbols = 8192
+
+// symbolCache interns symbol strings so hot decoding paths don't allocate a
+// new string for every message of an already seen symbol.
+var symbolCache = struct {
+	sync.RWMutex
+	m map[string]string
+}{m: make(map[string]string)}
+
+func internSymbol(b []byte) string {
+	symbolCache.RLock()
+	s, ok := symbolCache.m[string(b)]
+	symbolCache.RUnlock()
+	if ok {
+		return s
+	}
+
+	s = string(b)
+	symbolCache.Lock()
+	if len(symbolCache.m) < maxInternedSymbols {
+		symbolCache.m[s] = s
+	}
+	symbolCache.Unlock()
+	return s
+}
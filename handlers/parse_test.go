@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/quickfix"
+)
+
+func TestParseInt64(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want int64
+		err  bool
+	}{
+		{in: "0"},
+		{in: "12345", want: 12345},
+		{in: "-42", want: -42},
+		{in: strconv.FormatInt(math.MaxInt64, 10), want: math.MaxInt64},
+		{in: strconv.FormatInt(math.MinInt64, 10), want: math.MinInt64},
+		{in: "9223372036854775808", err: true},
+		{in: "-9223372036854775809", err: true},
+		{in: "99999999999999999999", err: true},
+		{in: "", err: true},
+		{in: "-", err: true},
+		{in: "1.5", err: true},
+	} {
+		got, err := parseInt64([]byte(tc.in))
+		if tc.err {
+			if !errors.Is(err, ErrInvalidNumber) {
+				t.Errorf("parseInt64(%q) = %d, %v, want ErrInvalidNumber", tc.in, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tc.want {
+			t.Errorf("parseInt64(%q) = %d, %v, want %d", tc.in, got, err, tc.want)
+		}
+	}
+}
+
+func TestInternSymbolBounded(t *testing.T) {
+	t.Cleanup(func() {
+		symbolCache.Lock()
+		symbolCache.m = make(map[string]string)
+		symbolCache.Unlock()
+	})
+	for i := 0; i < maxInternedSymbols+100; i++ {
+		if s := internSymbol([]byte("SYM" + strconv.Itoa(i))); s != "SYM"+strconv.Itoa(i) {
+			t.Fatalf("internSymbol returned %q", s)
+		}
+	}
+	symbolCache.RLock()
+	n := len(symbolCache.m)
+	symbolCache.RUnlock()
+	if n > maxInternedSymbols {
+		t.Fatalf("%d symbols interned, want at most %d", n, maxInternedSymbols)
+	}
+}
+
+func tradeMessage() *quickfix.Message {
+	msg := quickfix.NewMessage()
+	msg.Header.Set(field.NewSendingTime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)))
+	msg.Body.Set(field.NewSymbol("BTCUSDT"))
+	msg.Body.Set(field.NewTradeID("123456789"))
+	msg.Body.SetString(270, "43000.12")
+	msg.Body.SetString(271, "0.015")
+	msg.Body.SetString(60, "20240102-03:04:05.123")
+	msg.Body.SetString(6010, "1001")
+	msg.Body.SetString(6011, "1002")
+	msg.Body.SetString(6012, "Y")
+	return msg
+}
+
+func TestDecodeTradeMessage(t *testing.T) {
+	trade, err := DecodeTradeMessage(tradeMessage())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trade.Symbol != "BTCUSDT" || trade.TradeID != 123456789 || trade.Price != 43000.12 ||
+		trade.Quantity != 0.015 || trade.BuyerOrderID != 1001 || trade.SellerOrderID != 1002 || !trade.IsBuyerMaker {
+		t.Fatalf("decoded %+v", trade)
+	}
+	if want := time.Date(2024, 1, 2, 3, 4, 5, 123e6, time.UTC); !trade.TradeTime.Equal(want) {
+		t.Fatalf("trade time %v, want %v", trade.TradeTime, want)
+	}
+}
+
+func BenchmarkDecodeTradeMessage(b *testing.B) {
+	msg := tradeMessage()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := DecodeTradeMessage(msg); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeTradeMessageInto(b *testing.B) {
+	msg := tradeMessage()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		trade := AcquireTrade()
+		if err := DecodeTradeMessageInto(msg, trade); err != nil {
+			b.Fatal(err)
+		}
+		ReleaseTrade(trade)
+	}
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Error codes of Binance rejects for exceeding a rate limit.
+const (
+	ErrorCodeTooManyRequests = "-1003"
+	ErrorCodeTooManyOrders   = "-1015"
+)
+
+var (
+	// "IP banned until 1567154880000."
+	bannedUntilRe = regexp.MustCompile(`(?i)banned until (\d{13})`)
+	// "retry after 250ms", "Retry after 3 seconds."
+	retryAfterRe = regexp.MustCompile(`(?i)retry after (\d+)\s*(ms|milliseconds?|s|secs?|seconds?|m|mins?|minutes?)\b`)
+	// "current limit is 50 orders per 10 SECOND.", "... per DAY."
+	rateLimitRe = regexp.MustCompile(`(?i)current limit is (\d+) [a-z ]+? per (?:(\d+) )?(second|minute|hour|day)`)
+)
+
+var rateLimitUnits = map[string]time.Duration{
+	"second": time.Second,
+	"minute": time.Minute,
+	"hour":   time.Hour,
+	"day":    24 * time.Hour,
+}
+
+// IsRateLimited reports whether errorCode is that of a reject for exceeding
+// a rate limit.
+func IsRateLimited(errorCode string) bool {
+	return errorCode == ErrorCodeTooManyRequests || errorCode == ErrorCodeTooManyOrders
+}
+
+// ParseRateLimit extracts the exceeded limit from the text of a rate limit
+// reject, e.g. 50 per 10s from "current limit is 50 orders per 10 SECOND".
+func ParseRateLimit(text string) (count int, interval time.Duration, ok bool) {
+	m := rateLimitRe.FindStringSubmatch(text)
+	if m == nil {
+		return 0, 0, false
+	}
+	count, err := strconv.Atoi(m[1])
+	if err != nil || count <= 0 {
+		return 0, 0, false
+	}
+	n := 1
+	if m[2] != "" {
+		if n, err = strconv.Atoi(m[2]); err != nil || n <= 0 {
+			return 0, 0, false
+		}
+	}
+	return count, time.Duration(n) * rateLimitUnits[strings.ToLower(m[3])], true
+}
+
+// ParseRetryAfter extracts how long to wait before sending again from the
+// errorCode and text of a reject, zero when it gives no hint. It reads IP
+// bans ("banned until <ms>"), explicit "retry after" delays and, for rate
+// limit rejects, the exceeded limit, whose counter resets at the next
+// multiple of its interval.
+func ParseRetryAfter(errorCode, text string, now time.Time) time.Duration {
+	if m := bannedUntilRe.FindStringSubmatch(text); m != nil {
+		ms, err := strconv.ParseInt(m[1], 10, 64)
+		if err == nil {
+			return max(time.UnixMilli(ms).Sub(now), 0)
+		}
+	}
+	if m := retryAfterRe.FindStringSubmatch(text); m != nil {
+		n, err := strconv.Atoi(m[1])
+		if err == nil {
+			unit := time.Second
+			switch u := strings.ToLower(m[2]); {
+			case strings.HasPrefix(u, "ms"), strings.HasPrefix(u, "milli"):
+				unit = time.Millisecond
+			case strings.HasPrefix(u, "m"):
+				unit = time.Minute
+			}
+			return time.Duration(n) * unit
+		}
+	}
+	if IsRateLimited(errorCode) {
+		if _, interval, ok := ParseRateLimit(text); ok {
+			return now.Truncate(interval).Add(interval).Sub(now)
+		}
+	}
+	return 0
+}
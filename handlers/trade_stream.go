@@ -2,24 +2,27 @@ package handlers
 
 import (
 	"errors"
-	"strconv"
 	"sync"
 	"time"
 
-	"github.com/quickfixgo/field"
 	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
 )
 
 // Trade represents a trade from the market data stream
 type Trade struct {
-	Symbol      string
-	TradeID     int64
-	Price       float64
-	Quantity    float64
-	TradeTime   time.Time
-	BuyerOrderID  int64
-	SellerOrderID int64
-	IsBuyerMaker  bool
+	Symbol        string    `json:"symbol"`
+	TradeID       int64     `json:"id"`
+	Price         float64   `json:"price"`
+	Quantity      float64   `json:"qty"`
+	TradeTime     time.Time `json:"time"`      // exchange transaction time
+	EventTime     time.Time `json:"eventTime"` // time the market data entry was published
+	BuyerOrderID  int64     `json:"buyerOrderId"`
+	SellerOrderID int64     `json:"sellerOrderId"`
+	IsBuyerMaker  bool      `json:"isBuyerMaker"`
+
+	// Raw is the message the trade was decoded from, for tags not mapped above.
+	Raw *quickfix.Message `json:"-"`
 }
 
 // TradeStreamHandler manages trade data subscriptions
@@ -42,7 +45,7 @@ func NewTradeStreamHandler() *TradeStreamHandler {
 func (h *TradeStreamHandler) Subscribe(symbol string, callback TradeCallback) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
-	
+
 	h.subscriptions[symbol] = append(h.subscriptions[symbol], callback)
 }
 
@@ -50,7 +53,7 @@ func (h *TradeStreamHandler) Subscribe(symbol string, callback TradeCallback) {
 func (h *TradeStreamHandler) Unsubscribe(symbol string) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
-	
+
 	delete(h.subscriptions, symbol)
 }
 
@@ -79,138 +82,195 @@ func (h *TradeStreamHandler) HandleTradeMessage(msg *quickfix.Message) error {
 
 // DecodeTradeMessage parses a FIX trade message into a Trade struct
 func DecodeTradeMessage(msg *quickfix.Message) (Trade, error) {
+	var trade Trade
+	if err := DecodeTradeMessageInto(msg, &trade); err != nil {
+		return Trade{}, err
+	}
+	return trade, nil
+}
+
+// DecodeTradeMessageInto parses a FIX trade message into an existing Trade.
+// It reads field bytes directly and does not allocate once the symbol has been
+// seen, so it can be combined with AcquireTrade/ReleaseTrade on hot paths.
+func DecodeTradeMessageInto(msg *quickfix.Message, trade *Trade) error {
 	symbol, err := getTradeSymbol(msg)
 	if err != nil {
-		return Trade{}, err
+		return err
 	}
 
 	tradeID, err := getTradeID(msg)
 	if err != nil {
-		return Trade{}, err
+		return err
 	}
 
 	price, err := getTradePrice(msg)
 	if err != nil {
-		return Trade{}, err
+		return err
 	}
 
 	quantity, err := getTradeQuantity(msg)
 	if err != nil {
-		return Trade{}, err
+		return err
 	}
 
 	tradeTime, err := getTradeTime(msg)
 	if err != nil {
-		return Trade{}, err
+		return err
 	}
 
 	buyerOrderID, _ := getBuyerOrderID(msg)
 	sellerOrderID, _ := getSellerOrderID(msg)
 	isBuyerMaker, _ := getIsBuyerMaker(msg)
+	eventTime, _ := getTradeEventTime(msg, tradeTime)
 
-	return Trade{
-		Symbol:        symbol,
-		TradeID:       tradeID,
-		Price:         price,
-		Quantity:      quantity,
-		TradeTime:     tradeTime,
-		BuyerOrderID:  buyerOrderID,
-		SellerOrderID: sellerOrderID,
-		IsBuyerMaker:  isBuyerMaker,
-	}, nil
+	trade.Symbol = symbol
+	trade.TradeID = tradeID
+	trade.Price = price
+	trade.Quantity = quantity
+	trade.TradeTime = tradeTime
+	trade.EventTime = eventTime
+	trade.BuyerOrderID = buyerOrderID
+	trade.SellerOrderID = sellerOrderID
+	trade.IsBuyerMaker = isBuyerMaker
+	trade.Raw = msg
+	return nil
 }
 
+var tradePool = sync.Pool{
+	New: func() interface{} { return new(Trade) },
+}
+
+// AcquireTrade returns a zeroed Trade from the pool.
+func AcquireTrade() *Trade {
+	return tradePool.Get().(*Trade)
+}
+
+// ReleaseTrade returns a Trade to the pool. The trade must not be used afterwards.
+func ReleaseTrade(trade *Trade) {
+	*trade = Trade{}
+	tradePool.Put(trade)
+}
+
+var (
+	ErrTradeSymbolNotFound   = errors.New("trade symbol not found")
+	ErrTradeIDNotFound       = errors.New("trade ID not found")
+	ErrTradePriceNotFound    = errors.New("trade price not found")
+	ErrTradeQuantityNotFound = errors.New("trade quantity not found")
+	ErrTradeTimeNotFound     = errors.New("trade time not found")
+)
+
 // Trade field extraction functions optimized for performance
 
 func getTradeSymbol(msg *quickfix.Message) (string, error) {
-	var f field.SymbolField
-	if err := msg.Body.Get(&f); err != nil {
+	if !msg.Body.Has(tag.Symbol) {
+		return "", ErrTradeSymbolNotFound
+	}
+	b, err := msg.Body.GetBytes(tag.Symbol)
+	if err != nil {
 		return "", err
 	}
-	return f.Value(), nil
+	return internSymbol(b), nil
 }
 
 func getTradeID(msg *quickfix.Message) (int64, error) {
-	// Using TradeID field (Tag 1003) for Binance
-	if msg.Body.Has(1003) {
-		str, err := msg.Body.GetString(1003)
-		if err != nil {
-			return 0, err
-		}
-		return strconv.ParseInt(str, 10, 64)
-	}
-	// Fallback to TradeReportID field (Tag 571)
-	if msg.Body.Has(571) {
-		str, err := msg.Body.GetString(571)
-		if err != nil {
-			return 0, err
+	// Using TradeID field (Tag 1003) for Binance, fallback to TradeReportID field (Tag 571)
+	for _, t := range [...]quickfix.Tag{tag.TradeID, tag.TradeReportID} {
+		if msg.Body.Has(t) {
+			b, err := msg.Body.GetBytes(t)
+			if err != nil {
+				return 0, err
+			}
+			return parseInt64(b)
 		}
-		return strconv.ParseInt(str, 10, 64)
 	}
-	return 0, errors.New("trade ID not found")
+	return 0, ErrTradeIDNotFound
 }
 
 func getTradePrice(msg *quickfix.Message) (float64, error) {
-	// Use MDEntryPx field (Tag 270) for market data
-	if msg.Body.Has(270) {
-		str, err := msg.Body.GetString(270)
-		if err != nil {
-			return 0, err
+	// Use MDEntryPx field (Tag 270) for market data, fallback to LastPx field (Tag 31)
+	for _, t := range [...]quickfix.Tag{tag.MDEntryPx, tag.LastPx} {
+		if msg.Body.Has(t) {
+			b, err := msg.Body.GetBytes(t)
+			if err != nil {
+				return 0, err
+			}
+			return parseFloat64(b)
 		}
-		return strconv.ParseFloat(str, 64)
 	}
-	// Fallback to LastPx field (Tag 31)
-	var f field.LastPxField
-	if msg.Body.Has(f.Tag()) {
-		if err := msg.Body.Get(&f); err != nil {
-			return 0, err
+	return 0, ErrTradePriceNotFound
+}
+
+func getTradeQuantity(msg *quickfix.Message) (float64, error) {
+	// Use MDEntrySize field (Tag 271) for market data, fallback to LastQty field (Tag 32)
+	for _, t := range [...]quickfix.Tag{tag.MDEntrySize, tag.LastQty} {
+		if msg.Body.Has(t) {
+			b, err := msg.Body.GetBytes(t)
+			if err != nil {
+				return 0, err
+			}
+			return parseFloat64(b)
 		}
-		return f.InexactFloat64(), nil
 	}
-	return 0, errors.New("trade price not found")
+	return 0, ErrTradeQuantityNotFound
 }
 
-func getTradeQuantity(msg *quickfix.Message) (float64, error) {
-	// Use MDEntrySize field (Tag 271) for market data
-	if msg.Body.Has(271) {
-		str, err := msg.Body.GetString(271)
+func getTradeTime(msg *quickfix.Message) (time.Time, error) {
+	// Use TransactTime field (Tag 60)
+	if msg.Body.Has(tag.TransactTime) {
+		b, err := msg.Body.GetBytes(tag.TransactTime)
 		if err != nil {
-			return 0, err
+			return time.Time{}, err
 		}
-		return strconv.ParseFloat(str, 64)
+		return parseUTCTimestamp(b)
 	}
-	// Fallback to LastQty field (Tag 32)
-	var f field.LastQtyField
-	if msg.Body.Has(f.Tag()) {
-		if err := msg.Body.Get(&f); err != nil {
-			return 0, err
+
+	return time.Time{}, ErrTradeTimeNotFound
+}
+
+// getTradeEventTime reads MDEntryDate (Tag 272) and MDEntryTime (Tag 273),
+// taking the date from tradeTime if only the time is given, and falls back to
+// the SendingTime (Tag 52) of the message. Fractional seconds are kept up to
+// nanoseconds.
+func getTradeEventTime(msg *quickfix.Message, tradeTime time.Time) (time.Time, error) {
+	if msg.Body.Has(tag.MDEntryTime) {
+		entryTime, err := msg.Body.GetBytes(tag.MDEntryTime)
+		if err != nil {
+			return time.Time{}, err
+		}
+		var buf [32]byte
+		timestamp := buf[:0]
+		if msg.Body.Has(tag.MDEntryDate) {
+			date, err := msg.Body.GetBytes(tag.MDEntryDate)
+			if err != nil {
+				return time.Time{}, err
+			}
+			timestamp = append(timestamp, date...)
+		} else {
+			timestamp = tradeTime.UTC().AppendFormat(timestamp, "20060102")
 		}
-		return f.InexactFloat64(), nil
+		timestamp = append(timestamp, '-')
+		timestamp = append(timestamp, entryTime...)
+		return parseUTCTimestamp(timestamp)
 	}
-	return 0, errors.New("trade quantity not found")
-}
 
-func getTradeTime(msg *quickfix.Message) (time.Time, error) {
-	// Use TransactTime field (Tag 60)
-	var f field.TransactTimeField
-	if msg.Body.Has(f.Tag()) {
-		if err := msg.Body.Get(&f); err != nil {
+	if msg.Header.Has(tag.SendingTime) {
+		b, err := msg.Header.GetBytes(tag.SendingTime)
+		if err != nil {
 			return time.Time{}, err
 		}
-		return f.Value(), nil
+		return parseUTCTimestamp(b)
 	}
-	
-	return time.Time{}, errors.New("trade time not found")
+	return time.Time{}, nil
 }
 
 func getBuyerOrderID(msg *quickfix.Message) (int64, error) {
 	// Custom tag for buyer order ID (may vary by exchange)
 	if msg.Body.Has(6010) {
-		str, err := msg.Body.GetString(6010)
+		b, err := msg.Body.GetBytes(6010)
 		if err != nil {
 			return 0, err
 		}
-		return strconv.ParseInt(str, 10, 64)
+		return parseInt64(b)
 	}
 	return 0, nil
 }
@@ -218,11 +278,11 @@ func getBuyerOrderID(msg *quickfix.Message) (int64, error) {
 func getSellerOrderID(msg *quickfix.Message) (int64, error) {
 	// Custom tag for seller order ID (may vary by exchange)
 	if msg.Body.Has(6011) {
-		str, err := msg.Body.GetString(6011)
+		b, err := msg.Body.GetBytes(6011)
 		if err != nil {
 			return 0, err
 		}
-		return strconv.ParseInt(str, 10, 64)
+		return parseInt64(b)
 	}
 	return 0, nil
 }
@@ -230,11 +290,65 @@ func getSellerOrderID(msg *quickfix.Message) (int64, error) {
 func getIsBuyerMaker(msg *quickfix.Message) (bool, error) {
 	// Custom tag for buyer maker flag (may vary by exchange)
 	if msg.Body.Has(6012) {
-		str, err := msg.Body.GetString(6012)
+		b, err := msg.Body.GetBytes(6012)
 		if err != nil {
 			return false, err
 		}
+		str := bytesToString(b)
 		return str == "true" || str == "Y" || str == "1", nil
 	}
 	return false, nil
-}
\ No newline at end of file
+}
+
+// AggTrade is a run of trades of the same taker order at the same price, as
+// in Binance's WebSocket aggTrade stream.
+type AggTrade struct {
+	Symbol       string
+	Price        float64
+	Quantity     float64
+	FirstTradeID int64
+	LastTradeID  int64
+	Count        int
+	TradeTime    time.Time
+	IsBuyerMaker bool
+
+	takerOrderID int64
+}
+
+// NewAggTrade starts an aggregate from trade.
+func NewAggTrade(trade *Trade) AggTrade {
+	return AggTrade{
+		Symbol:       trade.Symbol,
+		Price:        trade.Price,
+		Quantity:     trade.Quantity,
+		FirstTradeID: trade.TradeID,
+		LastTradeID:  trade.TradeID,
+		Count:        1,
+		TradeTime:    trade.TradeTime,
+		IsBuyerMaker: trade.IsBuyerMaker,
+		takerOrderID: takerOrderID(trade),
+	}
+}
+
+// takerOrderID returns the order ID of the taker of trade, zero if the trade
+// stream doesn't carry order IDs.
+func takerOrderID(trade *Trade) int64 {
+	if trade.IsBuyerMaker {
+		return trade.SellerOrderID
+	}
+	return trade.BuyerOrderID
+}
+
+// Add adds trade to the aggregate if it is the next trade of the same taker
+// order at the same price, and reports whether it did.
+func (a *AggTrade) Add(trade *Trade) bool {
+	if trade.Symbol != a.Symbol || trade.TradeID != a.LastTradeID+1 ||
+		trade.Price != a.Price || trade.IsBuyerMaker != a.IsBuyerMaker ||
+		!trade.TradeTime.Equal(a.TradeTime) || takerOrderID(trade) != a.takerOrderID {
+		return false
+	}
+	a.Quantity += trade.Quantity
+	a.LastTradeID = trade.TradeID
+	a.Count++
+	return true
+}
@@ -70,4 +70,79 @@ const (
 var mappedSideType = map[enum.Side]SideType{
 	enum.Side_BUY:  SideTypeBuy,
 	enum.Side_SELL: SideTypeSell,
-}
\ No newline at end of file
+}
+
+// Commission types
+type CommissionType string
+
+const (
+	CommissionTypePerUnit  CommissionType = "PER_UNIT"
+	CommissionTypePercent  CommissionType = "PERCENT"
+	CommissionTypeAbsolute CommissionType = "ABSOLUTE"
+)
+
+var mappedCommissionType = map[enum.CommType]CommissionType{
+	enum.CommType_PER_UNIT: CommissionTypePerUnit,
+	enum.CommType_PERCENT:  CommissionTypePercent,
+	enum.CommType_ABSOLUTE: CommissionTypeAbsolute,
+}
+
+// Fee types
+type FeeType string
+
+const (
+	FeeTypeExchangeFees    FeeType = "EXCHANGE_FEES"
+	FeeTypeLocalCommission FeeType = "LOCAL_COMMISSION"
+	FeeTypeOther           FeeType = "OTHER"
+)
+
+var mappedFeeType = map[enum.MiscFeeType]FeeType{
+	enum.MiscFeeType_EXCHANGE_FEES:    FeeTypeExchangeFees,
+	enum.MiscFeeType_LOCAL_COMMISSION: FeeTypeLocalCommission,
+	enum.MiscFeeType_OTHER:            FeeTypeOther,
+}
+
+// Contingency types of order lists
+type ContingencyType string
+
+const (
+	ContingencyTypeOCO ContingencyType = "OCO"
+	ContingencyTypeOTO ContingencyType = "OTO"
+)
+
+var mappedContingencyType = map[enum.ContingencyType]ContingencyType{
+	enum.ContingencyType_ONE_CANCELS_THE_OTHER:  ContingencyTypeOCO,
+	enum.ContingencyType_ONE_TRIGGERS_THE_OTHER: ContingencyTypeOTO,
+}
+
+// List status types
+type ListStatusType string
+
+const (
+	ListStatusTypeResponse    ListStatusType = "RESPONSE"
+	ListStatusTypeExecStarted ListStatusType = "EXEC_STARTED"
+	ListStatusTypeAllDone     ListStatusType = "ALL_DONE"
+	ListStatusTypeUpdated     ListStatusType = "UPDATED"
+)
+
+var mappedListStatusType = map[string]ListStatusType{
+	string(enum.ListStatusType_RESPONSE):     ListStatusTypeResponse,
+	string(enum.ListStatusType_EXEC_STARTED): ListStatusTypeExecStarted,
+	string(enum.ListStatusType_ALL_DONE):     ListStatusTypeAllDone,
+	"100":                                    ListStatusTypeUpdated, // Binance specific
+}
+
+// List order status types
+type ListOrderStatus string
+
+const (
+	ListOrderStatusExecuting ListOrderStatus = "EXECUTING"
+	ListOrderStatusAllDone   ListOrderStatus = "ALL_DONE"
+	ListOrderStatusReject    ListOrderStatus = "REJECT"
+)
+
+var mappedListOrderStatus = map[enum.ListOrderStatus]ListOrderStatus{
+	enum.ListOrderStatus_EXECUTING: ListOrderStatusExecuting,
+	enum.ListOrderStatus_ALL_DONE:  ListOrderStatusAllDone,
+	enum.ListOrderStatus_REJECT:    ListOrderStatusReject,
+}
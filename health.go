@@ -0,0 +1,113 @@
+package fix
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// HealthReport is a point-in-time view of the connection, see Client.Health.
+type HealthReport struct {
+	State     string `json:"state"`
+	SessionID string `json:"session_id"`
+	Connected bool   `json:"connected"`
+
+	// LastReceived is when any message was last received from the server,
+	// LastHeartbeat when the last Heartbeat <0> was. Both are zero before the
+	// first one.
+	LastReceived  time.Time `json:"last_received"`
+	LastHeartbeat time.Time `json:"last_heartbeat"`
+
+	// LastSentSeqNum and LastReceivedSeqNum are the MsgSeqNum <34> of the
+	// last message sent and received in the current session.
+	LastSentSeqNum     int64 `json:"last_sent_seq_num"`
+	LastReceivedSeqNum int64 `json:"last_received_seq_num"`
+
+	// Reconnects counts the logons after the first one.
+	Reconnects int64 `json:"reconnects"`
+	// UnknownMessages counts the inbound messages of a MsgType the client
+	// doesn't handle, see SubscribeToUnknownMessage.
+	UnknownMessages int64 `json:"unknown_messages"`
+	// PendingCalls are the requests awaiting their response.
+	PendingCalls int `json:"pending_calls"`
+	// Subscriptions are the symbols with a trade stream subscription.
+	Subscriptions int `json:"subscriptions"`
+
+	// RateLimits is the usage of the account's limits as of the last
+	// LimitQuery <XLQ> answered, at RateLimitsAt. It is empty before the
+	// first query; Health doesn't send one.
+	RateLimits   []RateLimitUsage `json:"rate_limits,omitempty"`
+	RateLimitsAt time.Time        `json:"rate_limits_at,omitempty"`
+}
+
+// RateLimitUsage is the usage of one rate limit of the account.
+type RateLimitUsage struct {
+	Limit
+	// Utilization is LimitCount / LimitMax, 1 when the limit is reached.
+	Utilization float64 `json:"utilization"`
+}
+
+// healthStats are the counters of the connection not tracked elsewhere.
+type healthStats struct {
+	lastHeartbeat   atomic.Int64 // UnixNano
+	lastSentSeq     atomic.Int64
+	logons          atomic.Int64
+	unknownMessages atomic.Int64
+	limits          atomic.Pointer[limitsSeen]
+}
+
+type limitsSeen struct {
+	at     time.Time
+	limits []Limit
+}
+
+// Health returns the health of the connection without contacting the server,
+// e.g. to serve on a /healthz endpoint.
+func (c *Client) Health() HealthReport {
+	state := c.State()
+	h := HealthReport{
+		State:           state.String(),
+		SessionID:       c.sessionID.String(),
+		Connected:       state == StateActive,
+		LastReceived:    unixNanoTime(c.lastReceived.Load()),
+		LastHeartbeat:   unixNanoTime(c.health.lastHeartbeat.Load()),
+		LastSentSeqNum:  c.health.lastSentSeq.Load(),
+		Reconnects:      max(c.health.logons.Load()-1, 0),
+		UnknownMessages: c.health.unknownMessages.Load(),
+		Subscriptions:   len(c.tradeSymbols.list()),
+	}
+
+	c.gaps.mu.Lock()
+	h.LastReceivedSeqNum = c.gaps.seqNum
+	c.gaps.mu.Unlock()
+
+	h.PendingCalls = c.pending.len()
+
+	if seen := c.health.limits.Load(); seen != nil {
+		h.RateLimitsAt = seen.at
+		for _, l := range seen.limits {
+			u := RateLimitUsage{Limit: l}
+			if l.LimitMax > 0 {
+				u.Utilization = float64(l.LimitCount) / float64(l.LimitMax)
+			}
+			h.RateLimits = append(h.RateLimits, u)
+		}
+	}
+	return h
+}
+
+func unixNanoTime(ns int64) time.Time {
+	if ns == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ns)
+}
+
+// sentSeqNum records the MsgSeqNum quickfix stamped on an outgoing message.
+func (c *Client) sentSeqNum(msg *quickfix.Message) {
+	if seqNum, err := msg.Header.GetInt(tag.MsgSeqNum); err == nil {
+		c.health.lastSentSeq.Store(int64(seqNum))
+	}
+}
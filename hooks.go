@@ -0,0 +1,57 @@
+package fix
+
+import (
+	"github.com/quickfixgo/quickfix"
+)
+
+// SessionHook is called on a session event of quickfix's Application.
+type SessionHook func(sessionID quickfix.SessionID)
+
+// AdminHook is called with an admin message (Logon, Logout, Heartbeat, ...)
+// of quickfix's Application.
+type AdminHook func(msg *quickfix.Message, sessionID quickfix.SessionID)
+
+// WithOnLogonHook appends hooks called after the session logged on and the
+// client handled it. Hooks run in the order they were registered.
+func WithOnLogonHook(hooks ...SessionHook) NewClientOption {
+	return func(o *Options) {
+		o.onLogonHooks = append(o.onLogonHooks, hooks...)
+	}
+}
+
+// WithOnLogoutHook appends hooks called after the session logged out or
+// disconnected and the client handled it.
+func WithOnLogoutHook(hooks ...SessionHook) NewClientOption {
+	return func(o *Options) {
+		o.onLogoutHooks = append(o.onLogoutHooks, hooks...)
+	}
+}
+
+// WithToAdminHook appends hooks called with outgoing admin messages after the
+// client set its own fields, e.g. to stamp custom tags on the Logon. The logon
+// signature covers MsgType, the CompIDs, MsgSeqNum and SendingTime only, so
+// other tags can be added without breaking it.
+func WithToAdminHook(hooks ...AdminHook) NewClientOption {
+	return func(o *Options) {
+		o.toAdminHooks = append(o.toAdminHooks, hooks...)
+	}
+}
+
+// WithFromAdminHook appends hooks called with incoming admin messages.
+func WithFromAdminHook(hooks ...AdminHook) NewClientOption {
+	return func(o *Options) {
+		o.fromAdminHooks = append(o.fromAdminHooks, hooks...)
+	}
+}
+
+func runSessionHooks(hooks []SessionHook, sessionID quickfix.SessionID) {
+	for _, hook := range hooks {
+		hook(sessionID)
+	}
+}
+
+func runAdminHooks(hooks []AdminHook, msg *quickfix.Message, sessionID quickfix.SessionID) {
+	for _, hook := range hooks {
+		hook(msg, sessionID)
+	}
+}
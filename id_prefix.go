@@ -0,0 +1,62 @@
+package fix
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// WithMDReqIDPrefix prefixes the MDReqIDs the client generates for market
+// data subscriptions and snapshots, e.g. with the name of a strategy or
+// deployment, so the traffic can be told apart in logs and support tickets.
+func WithMDReqIDPrefix(prefix string) NewClientOption {
+	return func(o *Options) {
+		o.mdReqIDPrefix = prefix
+	}
+}
+
+// WithClOrdIDPrefix prefixes the ClOrdIDs generated for orders placed without
+// an explicit one. A UUIDClOrdIDGenerator leaves no room for a prefix, so
+// with one the default generator is replaced by a
+// TimestampNonceClOrdIDGenerator and prefix may be up to 9 characters. The
+// prefix may only hold letters, digits, '-' and '_', or NewClient fails with
+// ErrClOrdIDInvalid. ClOrdIDs set by the caller are sent as they are.
+func WithClOrdIDPrefix(prefix string) NewClientOption {
+	return func(o *Options) {
+		o.clOrdIDPrefix = prefix
+	}
+}
+
+// applyClOrdIDPrefix wraps the ClOrdID generator to add the prefix.
+func (o *Options) applyClOrdIDPrefix() error {
+	prefix := o.clOrdIDPrefix
+	if prefix == "" {
+		return nil
+	}
+	if !isClOrdIDChars(prefix) {
+		return fmt.Errorf("ClOrdID prefix %q: %w", prefix, ErrClOrdIDInvalid)
+	}
+	if _, ok := o.clOrdIDGenerator.(UUIDClOrdIDGenerator); ok {
+		g, err := NewTimestampNonceClOrdIDGenerator(prefix)
+		if err != nil {
+			return fmt.Errorf("ClOrdID prefix %q: %w", prefix, err)
+		}
+		o.clOrdIDGenerator = g
+		return nil
+	}
+	next := o.clOrdIDGenerator
+	o.clOrdIDGenerator = ClOrdIDGeneratorFunc(func() (string, error) {
+		id, err := next.NextClOrdID()
+		if err != nil {
+			return "", err
+		}
+		return prefix + id, nil
+	})
+	return nil
+}
+
+// mdReqID returns a new MDReqID of the given format with the client's prefix
+// and sequence number, so IDs generated at the same time of a frozen Clock
+// still differ.
+func (c *Client) mdReqID(format string, args ...any) string {
+	return c.options.mdReqIDPrefix + fmt.Sprintf(format, args...) + "_" + strconv.FormatUint(c.mdReqSeq.Add(1), 10)
+}
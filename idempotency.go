@@ -0,0 +1,157 @@
+package fix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+
+	"github.com/ljm2ya/binance_fix_api/handlers"
+)
+
+// ErrOrderStatusUnknown is returned by a retried placement when it can't be
+// told whether the original order landed, so it is not sent again.
+var ErrOrderStatusUnknown = errors.New("status of the original order unknown")
+
+// WithIdempotentOrders makes it safe to retry NewOrderSingleService with the
+// ClOrdID of a placement that got no response, e.g. after a timeout or a
+// disconnect. Before sending again the client looks for an execution report
+// of the ClOrdID received in the meantime, then asks the exchange with an
+// OrderStatusRequest <H>. An order that landed is returned as is instead of
+// being placed twice; it is only resent when the exchange reports it as
+// unknown. When the status request goes unanswered as well the retry fails
+// with ErrOrderStatusUnknown and can be retried again. NewOrderTemplate is
+// not covered.
+func WithIdempotentOrders() NewClientOption {
+	return func(o *Options) {
+		o.idempotentOrders = true
+	}
+}
+
+// unconfirmedOrders are the ClOrdIDs of placements that got no response,
+// with the execution report received for them since, if any.
+type unconfirmedOrders struct {
+	mu     sync.Mutex
+	orders map[string]*handlers.Order
+}
+
+func newUnconfirmedOrders() *unconfirmedOrders {
+	return &unconfirmedOrders{orders: make(map[string]*handlers.Order)}
+}
+
+// add tracks a placement about to be sent.
+func (u *unconfirmedOrders) add(clOrdID string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.orders[clOrdID] = nil
+}
+
+// settle stops tracking a placement unless it failed without telling whether
+// the order reached the exchange.
+func (u *unconfirmedOrders) settle(clOrdID string, err error) {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) || errors.Is(err, ErrClosed) {
+		return
+	}
+	u.forget(clOrdID)
+}
+
+func (u *unconfirmedOrders) forget(clOrdID string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	delete(u.orders, clOrdID)
+}
+
+// lookup returns the execution report received for clOrdID, if any, and
+// whether it is tracked at all.
+func (u *unconfirmedOrders) lookup(clOrdID string) (*handlers.Order, bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	order, ok := u.orders[clOrdID]
+	return order, ok
+}
+
+// observe records a late execution report of a tracked placement.
+func (u *unconfirmedOrders) observe(msg *quickfix.Message) {
+	clOrdID, ferr := msg.Body.GetString(tag.ClOrdID)
+	if ferr != nil {
+		return
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if order, ok := u.orders[clOrdID]; !ok || order != nil {
+		return
+	}
+	// An answer to the status request of an unknown order says nothing
+	// about the placement.
+	execType, _ := msg.Body.GetString(tag.ExecType)
+	status, _ := msg.Body.GetString(tag.OrdStatus)
+	if enum.ExecType(execType) == enum.ExecType_ORDER_STATUS && enum.OrdStatus(status) == enum.OrdStatus_REJECTED {
+		return
+	}
+	order, err := handlers.DecodeExecutionReport(msg)
+	if err != nil {
+		return
+	}
+	u.orders[clOrdID] = &order
+}
+
+// confirmPlacement checks whether an earlier placement of clOrdID that got
+// no response landed. It returns the order and true if it did, false if the
+// order may be sent.
+func (c *Client) confirmPlacement(
+	ctx context.Context, clOrdID, symbol string, side enum.Side,
+) (handlers.Order, bool, error) {
+	if c.unconfirmed == nil {
+		return handlers.Order{}, false, nil
+	}
+	if order, ok := c.unconfirmed.lookup(clOrdID); !ok {
+		return handlers.Order{}, false, nil
+	} else if order != nil {
+		c.unconfirmed.forget(clOrdID)
+		if order.Status == handlers.OrderStatusRejected {
+			return *order, true, &handlers.OrderReject{Order: *order}
+		}
+		return *order, true, nil
+	}
+
+	order, err := CallAndDecode(ctx, c, clOrdID, orderStatusRequest(clOrdID, symbol, side), c.decodeOrderResponse)
+	switch {
+	case err == nil:
+		c.unconfirmed.forget(clOrdID)
+		c.logger().Infow("Retried order had landed, not resending", "clOrdID", clOrdID, "status", order.Status)
+		return order, true, nil
+	case isUnknownOrder(err):
+		c.unconfirmed.forget(clOrdID)
+		return handlers.Order{}, false, nil
+	default:
+		return handlers.Order{}, true, fmt.Errorf("%w: %w", ErrOrderStatusUnknown, err)
+	}
+}
+
+// orderStatusRequest builds an OrderStatusRequest <H> for clOrdID. side is
+// left out when empty.
+func orderStatusRequest(clOrdID, symbol string, side enum.Side) *quickfix.Message {
+	msg := quickfix.NewMessage()
+	msg.Header.Set(field.NewMsgType(enum.MsgType_ORDER_STATUS_REQUEST))
+	msg.Body.Set(field.NewClOrdID(clOrdID))
+	msg.Body.Set(field.NewSymbol(symbol))
+	if side != "" {
+		msg.Body.Set(field.NewSide(side))
+	}
+	return msg
+}
+
+// isUnknownOrder reports whether err is the reject of a status request for
+// an order the exchange doesn't know.
+func isUnknownOrder(err error) bool {
+	var reject *handlers.OrderReject
+	return errors.As(err, &reject) &&
+		(enum.OrdRejReason(reject.Order.OrdRejReason) == enum.OrdRejReason_UNKNOWN_ORDER || reject.Order.ErrorCode == "-2013")
+}
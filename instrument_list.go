@@ -0,0 +1,132 @@
+package fix
+
+import (
+	"context"
+	"strings"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+
+	"github.com/ljm2ya/binance_fix_api/instruments"
+)
+
+/*
+InstrumentListRequest <x>, answered with InstrumentList <y>:
+
+Tag         Name                            Type            Required
+320         InstrumentReqID                 STRING          Y
+559         InstrumentListRequestType       INT             Y (0: SINGLE_INSTRUMENT, 4: ALL_INSTRUMENTS)
+55          Symbol                          STRING          N
+146         NoRelatedSym                    NUM_IN_GROUP    N (response)
+» 55        Symbol                          STRING          Y
+» 15        Currency                        STRING          N
+» 562       MinTradeVol                     QTY             N
+» 1140      MaxTradeVol                     QTY             N
+» 25039     MinQtyIncrement                 QTY             N
+» 25040     MarketMinTradeVol               QTY             N
+» 25041     MarketMaxTradeVol               QTY             N
+» 25042     MarketMinQtyIncrement           QTY             N
+» 969       MinPriceIncrement               PRICE           N
+» 1202      StartPriceRange                 PRICE           N
+» 1203      EndPriceRange                   PRICE           N
+*/
+const (
+	msgType_INSTRUMENT_LIST_REQUEST = enum.MsgType_SECURITY_LIST_REQUEST
+	msgType_INSTRUMENT_LIST         = enum.MsgType_SECURITY_LIST
+
+	tagInstrumentReqID           quickfix.Tag = 320
+	tagInstrumentListRequestType quickfix.Tag = 559
+	tagMinQtyIncrement           quickfix.Tag = 25039
+	tagMarketMinTradeVol         quickfix.Tag = 25040
+	tagMarketMaxTradeVol         quickfix.Tag = 25041
+	tagMarketMinQtyIncrement     quickfix.Tag = 25042
+	tagStartPriceRange           quickfix.Tag = 1202
+	tagEndPriceRange             quickfix.Tag = 1203
+
+	instrumentListAll = 4
+)
+
+// ListInstruments requests the symbols tradable on the exchange with their
+// price and lot size filters over a market data session. Binance FIX doesn't
+// report minimum notionals, so MinNotional is zero. With
+// instruments.New(instruments.SourceFunc(client.ListInstruments)) the
+// registry is loaded without the REST API.
+func (c *Client) ListInstruments(ctx context.Context) ([]instruments.Instrument, error) {
+	return QueryAndDecode(ctx, c, func(id string) *quickfix.Message {
+		msg := quickfix.NewMessage()
+		msg.Header.Set(field.NewMsgType(msgType_INSTRUMENT_LIST_REQUEST))
+		msg.Body.SetString(tagInstrumentReqID, id)
+		msg.Body.SetInt(tagInstrumentListRequestType, instrumentListAll)
+		return msg
+	}, DecodeInstrumentList)
+}
+
+// DecodeInstrumentList decodes an InstrumentList <y> message.
+func DecodeInstrumentList(msg *quickfix.Message) ([]instruments.Instrument, error) {
+	if !msg.Body.Has(tag.NoRelatedSym) {
+		return nil, nil
+	}
+
+	group := quickfix.NewRepeatingGroup(tag.NoRelatedSym, quickfix.GroupTemplate{
+		quickfix.GroupElement(tag.Symbol),
+		quickfix.GroupElement(tag.Currency),
+		quickfix.GroupElement(tag.MinTradeVol),
+		quickfix.GroupElement(tag.MaxTradeVol),
+		quickfix.GroupElement(tagMinQtyIncrement),
+		quickfix.GroupElement(tagMarketMinTradeVol),
+		quickfix.GroupElement(tagMarketMaxTradeVol),
+		quickfix.GroupElement(tagMarketMinQtyIncrement),
+		quickfix.GroupElement(tag.MinPriceIncrement),
+		quickfix.GroupElement(tagStartPriceRange),
+		quickfix.GroupElement(tagEndPriceRange),
+	})
+	if err := msg.Body.GetGroup(group); err != nil {
+		return nil, err
+	}
+
+	list := make([]instruments.Instrument, 0, group.Len())
+	for i := range group.Len() {
+		entry := group.Get(i)
+
+		symbol, err := entry.GetString(tag.Symbol)
+		if err != nil {
+			return nil, err
+		}
+		instrument := instruments.Instrument{Symbol: symbol, Status: "TRADING"}
+		if entry.Has(tag.Currency) {
+			if instrument.QuoteAsset, err = entry.GetString(tag.Currency); err != nil {
+				return nil, err
+			}
+			if base, ok := strings.CutSuffix(symbol, instrument.QuoteAsset); ok {
+				instrument.BaseAsset = base
+			}
+		}
+
+		for t, v := range map[quickfix.Tag]*float64{
+			tag.MinTradeVol:          &instrument.MinQty,
+			tag.MaxTradeVol:          &instrument.MaxQty,
+			tagMinQtyIncrement:       &instrument.StepSize,
+			tagMarketMinTradeVol:     &instrument.MarketMinQty,
+			tagMarketMaxTradeVol:     &instrument.MarketMaxQty,
+			tagMarketMinQtyIncrement: &instrument.MarketStepSize,
+			tag.MinPriceIncrement:    &instrument.TickSize,
+			tagStartPriceRange:       &instrument.MinPrice,
+			tagEndPriceRange:         &instrument.MaxPrice,
+		} {
+			if !entry.Has(t) {
+				continue
+			}
+			var f quickfix.FIXDecimal
+			if err := entry.GetField(t, &f); err != nil {
+				return nil, err
+			}
+			*v = f.InexactFloat64()
+		}
+
+		list = append(list, instrument)
+	}
+
+	return list, nil
+}
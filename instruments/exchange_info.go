@@ -0,0 +1,98 @@
+package instruments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// DefaultRESTBaseURL is the Binance spot REST API.
+const DefaultRESTBaseURL = "https://api.binance.com"
+
+type exchangeInfo struct {
+	Symbols []struct {
+		Symbol     string           `json:"symbol"`
+		Status     string           `json:"status"`
+		BaseAsset  string           `json:"baseAsset"`
+		QuoteAsset string           `json:"quoteAsset"`
+		Filters    []map[string]any `json:"filters"`
+	} `json:"symbols"`
+}
+
+// ParseExchangeInfo reads instruments from a REST exchangeInfo response, e.g.
+// a file saved from /api/v3/exchangeInfo.
+func ParseExchangeInfo(r io.Reader) ([]Instrument, error) {
+	var info exchangeInfo
+	if err := json.NewDecoder(r).Decode(&info); err != nil {
+		return nil, err
+	}
+
+	instruments := make([]Instrument, 0, len(info.Symbols))
+	for _, s := range info.Symbols {
+		instrument := Instrument{
+			Symbol:     s.Symbol,
+			BaseAsset:  s.BaseAsset,
+			QuoteAsset: s.QuoteAsset,
+			Status:     s.Status,
+		}
+		for _, filter := range s.Filters {
+			switch filter["filterType"] {
+			case "PRICE_FILTER":
+				instrument.MinPrice = filterValue(filter, "minPrice")
+				instrument.MaxPrice = filterValue(filter, "maxPrice")
+				instrument.TickSize = filterValue(filter, "tickSize")
+			case "LOT_SIZE":
+				instrument.MinQty = filterValue(filter, "minQty")
+				instrument.MaxQty = filterValue(filter, "maxQty")
+				instrument.StepSize = filterValue(filter, "stepSize")
+			case "MARKET_LOT_SIZE":
+				instrument.MarketMinQty = filterValue(filter, "minQty")
+				instrument.MarketMaxQty = filterValue(filter, "maxQty")
+				instrument.MarketStepSize = filterValue(filter, "stepSize")
+			case "NOTIONAL", "MIN_NOTIONAL":
+				instrument.MinNotional = filterValue(filter, "minNotional")
+			}
+		}
+		instruments = append(instruments, instrument)
+	}
+
+	return instruments, nil
+}
+
+func filterValue(filter map[string]any, key string) float64 {
+	s, _ := filter[key].(string)
+	v, _ := strconv.ParseFloat(s, 64)
+	return v
+}
+
+// RESTSource loads instruments from the REST exchangeInfo endpoint.
+type RESTSource struct {
+	BaseURL string
+	Client  *http.Client
+}
+
+// NewRESTSource creates a RESTSource for the Binance spot REST API.
+func NewRESTSource() *RESTSource {
+	return &RESTSource{BaseURL: DefaultRESTBaseURL, Client: http.DefaultClient}
+}
+
+func (s *RESTSource) LoadInstruments(ctx context.Context) ([]Instrument, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.BaseURL+"/api/v3/exchangeInfo", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("exchangeInfo: unexpected status %s", resp.Status)
+	}
+	return ParseExchangeInfo(resp.Body)
+}
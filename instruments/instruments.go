@@ -0,0 +1,205 @@
+// Package instruments holds exchange metadata (tick size, lot size, minimum
+// notional) of trading symbols and validates orders against it.
+//
+// Instruments are loaded from a pluggable Source such as the REST exchangeInfo
+// endpoint or, with instruments.SourceFunc(client.ListInstruments), the
+// InstrumentList request of a Binance FIX market data session.
+package instruments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrUnknownSymbol        = errors.New("unknown symbol")
+	ErrPriceTickViolation   = errors.New("price violates the symbol's price filter")
+	ErrLotSizeViolation     = errors.New("quantity violates the symbol's lot size filter")
+	ErrMinNotionalViolation = errors.New("order value is below the symbol's minimum notional")
+)
+
+// Instrument is the metadata of a trading symbol. Zero filter values are not
+// enforced.
+type Instrument struct {
+	Symbol      string
+	BaseAsset   string
+	QuoteAsset  string
+	Status      string
+	TickSize    float64
+	MinPrice    float64
+	MaxPrice    float64
+	StepSize    float64
+	MinQty      float64
+	MaxQty      float64
+	MinNotional float64
+
+	// The lot size filter of market orders, informational only.
+	MarketStepSize float64
+	MarketMinQty   float64
+	MarketMaxQty   float64
+}
+
+// ValidatePrice checks price against the price filter.
+func (i Instrument) ValidatePrice(price float64) error {
+	if i.MinPrice > 0 && price < i.MinPrice {
+		return fmt.Errorf("%w: %s price %v below %v", ErrPriceTickViolation, i.Symbol, price, i.MinPrice)
+	}
+	if i.MaxPrice > 0 && price > i.MaxPrice {
+		return fmt.Errorf("%w: %s price %v above %v", ErrPriceTickViolation, i.Symbol, price, i.MaxPrice)
+	}
+	if !isMultiple(price-i.MinPrice, i.TickSize) {
+		return fmt.Errorf("%w: %s price %v not a multiple of tick size %v", ErrPriceTickViolation, i.Symbol, price, i.TickSize)
+	}
+	return nil
+}
+
+// ValidateQuantity checks quantity against the lot size filter.
+func (i Instrument) ValidateQuantity(quantity float64) error {
+	if i.MinQty > 0 && quantity < i.MinQty {
+		return fmt.Errorf("%w: %s quantity %v below %v", ErrLotSizeViolation, i.Symbol, quantity, i.MinQty)
+	}
+	if i.MaxQty > 0 && quantity > i.MaxQty {
+		return fmt.Errorf("%w: %s quantity %v above %v", ErrLotSizeViolation, i.Symbol, quantity, i.MaxQty)
+	}
+	if !isMultiple(quantity-i.MinQty, i.StepSize) {
+		return fmt.Errorf("%w: %s quantity %v not a multiple of step size %v", ErrLotSizeViolation, i.Symbol, quantity, i.StepSize)
+	}
+	return nil
+}
+
+// ValidateNotional checks price * quantity against the minimum notional.
+func (i Instrument) ValidateNotional(price, quantity float64) error {
+	if i.MinNotional > 0 && price*quantity < i.MinNotional {
+		return fmt.Errorf("%w: %s notional %v below %v", ErrMinNotionalViolation, i.Symbol, price*quantity, i.MinNotional)
+	}
+	return nil
+}
+
+// isMultiple reports whether v is a multiple of step, tolerating float
+// rounding of decimal prices and quantities.
+func isMultiple(v, step float64) bool {
+	if step <= 0 {
+		return true
+	}
+	n := math.Round(v / step)
+	return math.Abs(v-n*step) <= step*1e-6
+}
+
+// Normalize converts common symbol spellings ("btc/usdt", "BTC-USDT",
+// "btc_usdt") to the exchange form ("BTCUSDT").
+func Normalize(symbol string) string {
+	return strings.ToUpper(strings.NewReplacer("/", "", "-", "", "_", "", " ", "").Replace(symbol))
+}
+
+// Source loads instruments, e.g. from the REST exchangeInfo endpoint.
+type Source interface {
+	LoadInstruments(ctx context.Context) ([]Instrument, error)
+}
+
+// SourceFunc adapts a function to the Source interface.
+type SourceFunc func(ctx context.Context) ([]Instrument, error)
+
+func (f SourceFunc) LoadInstruments(ctx context.Context) ([]Instrument, error) {
+	return f(ctx)
+}
+
+// StaticSource serves a fixed list of instruments.
+func StaticSource(instruments ...Instrument) Source {
+	return SourceFunc(func(context.Context) ([]Instrument, error) {
+		return instruments, nil
+	})
+}
+
+// Registry caches instruments by symbol.
+type Registry struct {
+	mu          sync.RWMutex
+	source      Source
+	instruments map[string]Instrument
+	updatedAt   time.Time
+}
+
+// New creates a Registry loading from source. Call Refresh before use.
+func New(source Source) *Registry {
+	return &Registry{
+		source:      source,
+		instruments: make(map[string]Instrument),
+	}
+}
+
+// Refresh reloads all instruments from the source.
+func (r *Registry) Refresh(ctx context.Context) error {
+	list, err := r.source.LoadInstruments(ctx)
+	if err != nil {
+		return err
+	}
+
+	instruments := make(map[string]Instrument, len(list))
+	for _, instrument := range list {
+		instruments[Normalize(instrument.Symbol)] = instrument
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.instruments = instruments
+	r.updatedAt = time.Now()
+
+	return nil
+}
+
+// UpdatedAt returns when the instruments were last loaded.
+func (r *Registry) UpdatedAt() time.Time {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.updatedAt
+}
+
+// Get returns the instrument of a symbol in any spelling accepted by Normalize.
+func (r *Registry) Get(symbol string) (Instrument, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	instrument, ok := r.instruments[Normalize(symbol)]
+	return instrument, ok
+}
+
+// Instruments returns all cached instruments.
+func (r *Registry) Instruments() []Instrument {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list := make([]Instrument, 0, len(r.instruments))
+	for _, instrument := range r.instruments {
+		list = append(list, instrument)
+	}
+	return list
+}
+
+// ValidateOrder checks an order against the filters of its symbol. A zero
+// price (market orders) or quantity skips the checks that need it.
+func (r *Registry) ValidateOrder(symbol string, price, quantity float64) error {
+	instrument, ok := r.Get(symbol)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownSymbol, symbol)
+	}
+
+	if price > 0 {
+		if err := instrument.ValidatePrice(price); err != nil {
+			return err
+		}
+	}
+	if quantity > 0 {
+		if err := instrument.ValidateQuantity(quantity); err != nil {
+			return err
+		}
+	}
+	if price > 0 && quantity > 0 {
+		if err := instrument.ValidateNotional(price, quantity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package fix
+
+import (
+	"github.com/quickfixgo/quickfix"
+)
+
+// SendInterceptor inspects or mutates an outbound application message right
+// before it is queued on the session. Returning an error aborts the send and
+// is returned to the caller.
+type SendInterceptor func(msg *quickfix.Message) error
+
+// ReceiveInterceptor inspects or mutates an inbound application message before
+// it is decoded and dispatched. Returning an error drops the message.
+type ReceiveInterceptor func(msg *quickfix.Message) error
+
+// WithSendInterceptor appends interceptors to the outbound chain. Interceptors
+// run in the order they were registered.
+func WithSendInterceptor(interceptors ...SendInterceptor) NewClientOption {
+	return func(o *Options) {
+		o.sendInterceptors = append(o.sendInterceptors, interceptors...)
+	}
+}
+
+// WithReceiveInterceptor appends interceptors to the inbound chain. Interceptors
+// run in the order they were registered.
+func WithReceiveInterceptor(interceptors ...ReceiveInterceptor) NewClientOption {
+	return func(o *Options) {
+		o.receiveInterceptors = append(o.receiveInterceptors, interceptors...)
+	}
+}
+
+func (c *Client) interceptSend(msg *quickfix.Message) error {
+	for _, intercept := range c.options.sendInterceptors {
+		if err := intercept(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Client) interceptReceive(msg *quickfix.Message) error {
+	for _, intercept := range c.options.receiveInterceptors {
+		if err := intercept(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,45 +1,88 @@
 package fix
 
 import (
-	"strings"
-	
+	"fmt"
+
 	"github.com/quickfixgo/enum"
 	"github.com/quickfixgo/field"
 	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
 	"go.uber.org/zap"
+
+	"github.com/ljm2ya/binance_fix_api/handlers"
 )
 
 /* IMPLEMENT quickfix.Application INTERFACE */
 
 // OnCreate implemented as part of Application interface.
-func (c *Client) OnCreate(quickfix.SessionID) {}
+func (c *Client) OnCreate(sessionID quickfix.SessionID) {
+	c.sessionID = sessionID
+}
 
 // OnLogon notification of a session successfully logging on.
-func (c *Client) OnLogon(quickfix.SessionID) {
-	c.isConnected.Store(true)
+func (c *Client) OnLogon(sessionID quickfix.SessionID) {
+	if logons := c.health.logons.Add(1); logons > 1 {
+		c.logger().Infow("Reconnected", "session", sessionID, "reconnects", logons-1)
+	} else {
+		c.logger().Infow("Logged on", "session", sessionID)
+	}
+	c.setState(StateActive)
+	if c.options.cancelOnDisconnect {
+		c.purgeOpenOrders()
+	}
+	if c.outbox != nil {
+		c.flushOutbox()
+	}
+	c.signalLogon()
+	c.reconcileAfterLogon()
+	c.endMaintenance()
+	c.startStaleWatchdog(sessionID)
+	c.startSymbolWatchdog()
+	runSessionHooks(c.options.onLogonHooks, sessionID)
 }
 
 // OnLogout notification of a session logging off or disconnecting.
 func (c *Client) OnLogout(sessionID quickfix.SessionID) {
-	c.isConnected.Store(false)
-	
+	planned := c.plannedLogout(c.now())
+	switch {
+	case c.State() == StateStopping:
+	case c.pausing.Load():
+		c.logger().Infow("Session logged out for maintenance", "session", sessionID)
+	case planned:
+		c.logger().Infow("Session logged out at the end of its schedule", "session", sessionID)
+	default:
+		c.logger().Warnw("Session logged out, reconnecting", "session", sessionID)
+	}
+	c.setState(StateReconnecting)
+	c.resetLogonSignal()
+	if c.options.cancelOnDisconnect && !c.rotating.Load() {
+		c.openOrders.disconnected()
+	}
+	c.stopStaleWatchdog()
+	c.stopSymbolWatchdog()
+
 	// Clear pending calls
-	c.mu.Lock()
-	for _, call := range c.pending {
+	for _, call := range c.pending.drain() {
 		call.done <- ErrClosed
 		close(call.done)
 	}
-	c.pending = make(map[string]*call) // Reset pending map
-	c.mu.Unlock()
-	
+
+	if !c.rotating.Load() && !planned {
+		c.waiters.notify(waitDisconnect)
+	}
+
 	// For Market Data connections, emit disconnection event
-	if strings.Contains(c.senderCompID, "BMD") {
-		c.emitter.Emit("disconnect", sessionID)
+	if c.endpoint == MarketDataEndpoint {
+		c.emit("disconnect", sessionID)
 	}
+	runSessionHooks(c.options.onLogoutHooks, sessionID)
 }
 
 // ToAdmin notification of admin message being sent to target.
-func (c *Client) ToAdmin(msg *quickfix.Message, _ quickfix.SessionID) {
+func (c *Client) ToAdmin(msg *quickfix.Message, sessionID quickfix.SessionID) {
+	c.sentSeqNum(msg)
+	c.countSent(msg)
+	c.adjustSendingTime(msg)
 	msgType, err := msg.MsgType()
 	if err != nil {
 		// Errorw("Failed to get msg type", "err", err)
@@ -48,75 +91,155 @@ func (c *Client) ToAdmin(msg *quickfix.Message, _ quickfix.SessionID) {
 
 	// Infow("ToAdmin message type", "data", msgType)
 	if enum.MsgType(msgType) == enum.MsgType_LOGON {
-		rawData := GetLogonRawData(c.privateKey, c.senderCompID, c.targetCompID, SendingTimeNow())
+		c.setState(StateLogonSent)
+
+		// Sign the SendingTime quickfix stamped on the header, the server
+		// verifies the signature against it.
+		sendingTime, err := msg.Header.GetString(tag.SendingTime)
+		if err != nil {
+			sendingTime = c.SendingTime()
+		}
+		apiKey, signer, senderCompID := c.credentials()
+		rawData, signErr := SignLogonRawData(signer, senderCompID, c.targetCompID, sendingTime)
+		if signErr != nil {
+			// The logon goes out unsigned and is rejected by the server.
+			c.logger().Errorw("Failed to sign logon", "err", signErr)
+			c.failLogon(fmt.Errorf("sign logon: %w", signErr))
+		}
 		msg.Body.Set(field.NewRawDataLength(len(rawData)))
 		msg.Body.Set(field.NewRawData(rawData))
-		msg.Body.Set(field.NewUsername(c.apiKey))
+		msg.Body.Set(field.NewUsername(apiKey))
 		msg.Body.Set(field.NewResetSeqNumFlag(true))
 		msg.Body.SetInt(tagMessageHandling, int(c.options.messageHandling))
-		
-		// Only set ResponseMode for Order Entry endpoint (not for Market Data)
-		if c.senderCompID == "BOETRADE" || !strings.Contains(c.senderCompID, "BMD") {
+
+		// Only set ResponseMode for Order Entry endpoint (not for Market Data or Drop Copy)
+		if c.endpoint == OrderEntryEndpoint {
 			msg.Body.SetInt(tagResponseMode, int(c.options.responseMode))
 		}
+		for _, f := range c.options.logonFields {
+			msg.Body.SetString(f.tag, f.value)
+		}
+		c.modes.sent(msg)
 	}
+	runAdminHooks(c.options.toAdminHooks, msg, sessionID)
 }
 
 // ToApp notification of app message being sent to target.
 func (c *Client) ToApp(msg *quickfix.Message, _ quickfix.SessionID) error {
+	c.sentSeqNum(msg)
+	c.adjustSendingTime(msg)
+	c.audit(DirectionOutbound, msg)
+	c.countSent(msg)
+	if timings, ok := c.sending.Load(msg); ok {
+		timings.(*handlers.Timings).Sent = c.now()
+	}
 	// Infow("Sending message to server", "msg", msg)
 	return nil
 }
 
 // FromAdmin notification of admin message being received from target.
-func (c *Client) FromAdmin(msg *quickfix.Message, _ quickfix.SessionID) quickfix.MessageRejectError {
+func (c *Client) FromAdmin(msg *quickfix.Message, sessionID quickfix.SessionID) quickfix.MessageRejectError {
+	c.touch()
+	if msgType, err := msg.MsgType(); err == nil {
+		switch enum.MsgType(msgType) {
+		case enum.MsgType_LOGON:
+			c.modes.read(msg)
+		case enum.MsgType_HEARTBEAT:
+			c.health.lastHeartbeat.Store(c.now().UnixNano())
+		}
+	}
+	runAdminHooks(c.options.fromAdminHooks, msg, sessionID)
 	// Infow("FromAdmin message", "msg", msg)
 	return nil
 }
 
 // FromApp notification of app message being received from target.
 func (c *Client) FromApp(msg *quickfix.Message, s quickfix.SessionID) quickfix.MessageRejectError {
+	c.touch()
+
+	if err := c.interceptReceive(msg); err != nil {
+		c.logger().Debugw("Message dropped by receive interceptor", "msg", msg.String(), "err", err)
+		return nil
+	}
+
 	// Process message according to message type.
 	msgType, err := msg.MsgType()
 	if err != nil {
-		// Errorw("Failed to get response message type", "error", err)
+		c.logger().Errorw("Message without MsgType dropped", "msg", msg.String(), "err", err)
 		return err
 	}
 
+	c.audit(DirectionInbound, msg)
+	c.publishRaw(enum.MsgType(msgType), msg)
+	if !knownMsgType(enum.MsgType(msgType)) {
+		c.unknownMessage(msgType, msg)
+		return nil
+	}
+	if enum.MsgType(msgType) == enum.MsgType_EXECUTION_REPORT && c.dedupe != nil && c.dedupe.duplicate(msg) {
+		c.logger().Infow("Duplicate execution report dropped", "msg", msg.String())
+		return nil
+	}
+
+	c.observeRejects(msgType, msg)
+	c.observeBookUpdate(enum.MsgType(msgType), msg)
+	if enum.MsgType(msgType) == enum.MsgType_EXECUTION_REPORT {
+		c.openOrders.observe(msg)
+		if c.unconfirmed != nil {
+			c.unconfirmed.observe(msg)
+		}
+	}
+
 	// Handle News messages for server maintenance
 	if enum.MsgType(msgType) == enum.MsgType_NEWS {
 		c.handleNewsMessage(msg)
 		return nil // News messages don't require response handling
 	}
 
+	if c.options.busyPoll {
+		// A caller spinning on its response gets it before the subscribers.
+		err := c.deliverResponse(msgType, msg)
+		c.handleSubscriptions(msgType, msg)
+		return err
+	}
+
 	c.handleSubscriptions(msgType, msg)
+	return c.deliverResponse(msgType, msg)
+}
 
+// deliverResponse completes the pending call msg answers, if any.
+func (c *Client) deliverResponse(msgType string, msg *quickfix.Message) quickfix.MessageRejectError {
 	reqIDTag, err2 := getReqIDTagFromMsgType(enum.MsgType(msgType))
 	if err2 != nil {
 		// Warnw("Could not get request ID tag", "msgType", msgType, "error", err2)
 		return nil
 	}
 
-	id, err := msg.Body.GetString(reqIDTag)
-	if err != nil {
-		// Errorw("Failed to get request ID", "tag", reqIDTag, "error", err)
-		return err
+	id, call := c.pending.match(enum.MsgType(msgType), reqIDTag, msg)
+	if id == "" && call == nil {
+		c.logger().Warnw("Response without request ID dropped", "msgType", msgType, "tag", reqIDTag)
+		return quickfix.ConditionallyRequiredFieldMissing(reqIDTag)
 	}
 
-	c.mu.Lock()
-	call := c.pending[id]
-	delete(c.pending, id)
-	c.mu.Unlock()
-
 	if call != nil {
+		if call.timings != nil {
+			call.timings.Received = c.now()
+		}
 		// Matching response message
 		response, err2 := copyMessage(msg)
 		if err2 != nil {
+			c.logger().Errorw("Failed to copy response, message dropped", "msgType", msgType, "reqID", id, "err", err2)
 			return quickfix.UnsupportedMessageType()
 		}
 		call.response = response
 		call.done <- nil
 		close(call.done)
+	} else if late, ok := c.late.take(id); ok {
+		response, err2 := copyMessage(msg)
+		if err2 != nil {
+			c.logger().Errorw("Failed to copy late response, message dropped", "msgType", msgType, "reqID", id, "err", err2)
+			return nil
+		}
+		c.lateResponse(id, late, response)
 	}
 
 	return nil
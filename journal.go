@@ -0,0 +1,211 @@
+package fix
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/quickfix"
+)
+
+// Direction tells whether a journaled message was received or sent.
+type Direction string
+
+const (
+	DirectionInbound  Direction = "I"
+	DirectionOutbound Direction = "O"
+)
+
+// JournalEntry is a single FIX message recorded on the wire.
+type JournalEntry struct {
+	Time      time.Time `json:"time"`
+	Direction Direction `json:"direction"`
+	SessionID string    `json:"session"`
+	Data      []byte    `json:"-"`
+}
+
+// JournalWriter persists journal entries. Implementations must be safe for
+// concurrent use.
+type JournalWriter interface {
+	WriteEntry(entry JournalEntry) error
+}
+
+// JournalReader reads journal entries back in order. It returns io.EOF after
+// the last entry.
+type JournalReader interface {
+	ReadEntry() (JournalEntry, error)
+}
+
+// WithRecorder records every inbound and outbound message, admin messages
+// included, to w. Recording is layered on top of the configured log factory.
+// Journals contain the logon message, including the API key.
+func WithRecorder(w JournalWriter) NewClientOption {
+	return func(o *Options) {
+		o.journal = w
+	}
+}
+
+// TextJournalWriter writes one tab separated entry per line:
+// time (RFC 3339), direction, session ID and the raw message.
+type TextJournalWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewTextJournalWriter(w io.Writer) *TextJournalWriter {
+	return &TextJournalWriter{w: w}
+}
+
+func (j *TextJournalWriter) WriteEntry(entry JournalEntry) error {
+	line := make([]byte, 0, len(entry.Data)+64)
+	line = entry.Time.UTC().AppendFormat(line, time.RFC3339Nano)
+	line = append(line, '\t')
+	line = append(line, entry.Direction...)
+	line = append(line, '\t')
+	line = append(line, entry.SessionID...)
+	line = append(line, '\t')
+	line = append(line, entry.Data...)
+	line = append(line, '\n')
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	_, err := j.w.Write(line)
+	return err
+}
+
+// TextJournalReader reads journals written by TextJournalWriter.
+type TextJournalReader struct {
+	scanner *bufio.Scanner
+}
+
+func NewTextJournalReader(r io.Reader) *TextJournalReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	return &TextJournalReader{scanner: scanner}
+}
+
+func (j *TextJournalReader) ReadEntry() (JournalEntry, error) {
+	if !j.scanner.Scan() {
+		if err := j.scanner.Err(); err != nil {
+			return JournalEntry{}, err
+		}
+		return JournalEntry{}, io.EOF
+	}
+
+	parts := bytes.SplitN(j.scanner.Bytes(), []byte{'\t'}, 4)
+	if len(parts) != 4 {
+		return JournalEntry{}, fmt.Errorf("invalid journal line: %q", j.scanner.Text())
+	}
+	t, err := time.Parse(time.RFC3339Nano, string(parts[0]))
+	if err != nil {
+		return JournalEntry{}, err
+	}
+
+	return JournalEntry{
+		Time:      t,
+		Direction: Direction(parts[1]),
+		SessionID: string(parts[2]),
+		Data:      bytes.Clone(parts[3]),
+	}, nil
+}
+
+// jsonJournalEntry keeps the raw message readable in JSON, where []byte
+// would be base64 encoded.
+type jsonJournalEntry struct {
+	JournalEntry
+	Message string `json:"message"`
+}
+
+// JSONJournalWriter writes one JSON object per line.
+type JSONJournalWriter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func NewJSONJournalWriter(w io.Writer) *JSONJournalWriter {
+	return &JSONJournalWriter{enc: json.NewEncoder(w)}
+}
+
+func (j *JSONJournalWriter) WriteEntry(entry JournalEntry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.enc.Encode(jsonJournalEntry{JournalEntry: entry, Message: string(entry.Data)})
+}
+
+// JSONJournalReader reads journals written by JSONJournalWriter.
+type JSONJournalReader struct {
+	dec *json.Decoder
+}
+
+func NewJSONJournalReader(r io.Reader) *JSONJournalReader {
+	return &JSONJournalReader{dec: json.NewDecoder(r)}
+}
+
+func (j *JSONJournalReader) ReadEntry() (JournalEntry, error) {
+	var entry jsonJournalEntry
+	if err := j.dec.Decode(&entry); err != nil {
+		return JournalEntry{}, err
+	}
+	entry.JournalEntry.Data = []byte(entry.Message)
+	return entry.JournalEntry, nil
+}
+
+// journalLogFactory tees the messages seen by quickfix logs into a journal.
+type journalLogFactory struct {
+	quickfix.LogFactory
+	journal JournalWriter
+	clock   Clock
+}
+
+func newJournalLogFactory(factory quickfix.LogFactory, journal JournalWriter, clock Clock) *journalLogFactory {
+	return &journalLogFactory{LogFactory: factory, journal: journal, clock: clock}
+}
+
+func (f *journalLogFactory) Create() (quickfix.Log, error) {
+	log, err := f.LogFactory.Create()
+	if err != nil {
+		return nil, err
+	}
+	return &journalLog{Log: log, journal: f.journal, clock: f.clock}, nil
+}
+
+func (f *journalLogFactory) CreateSessionLog(sessionID quickfix.SessionID) (quickfix.Log, error) {
+	log, err := f.LogFactory.CreateSessionLog(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return &journalLog{Log: log, journal: f.journal, clock: f.clock, sessionID: sessionID.String()}, nil
+}
+
+type journalLog struct {
+	quickfix.Log
+	journal   JournalWriter
+	clock     Clock
+	sessionID string
+}
+
+func (l *journalLog) OnIncoming(data []byte) {
+	l.record(DirectionInbound, data)
+	l.Log.OnIncoming(data)
+}
+
+func (l *journalLog) OnOutgoing(data []byte) {
+	l.record(DirectionOutbound, data)
+	l.Log.OnOutgoing(data)
+}
+
+func (l *journalLog) record(direction Direction, data []byte) {
+	err := l.journal.WriteEntry(JournalEntry{
+		Time:      l.clock.Now(),
+		Direction: direction,
+		SessionID: l.sessionID,
+		Data:      data,
+	})
+	if err != nil {
+		l.Log.OnEventf("journal write failed: %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package fix
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/quickfix/config"
+)
+
+const minStaleCheckInterval = 100 * time.Millisecond
+
+// ConnectionStale is emitted when nothing has been received from the server
+// for longer than the heartbeat interval plus the test request timeout. quickfix
+// only drops the session after roughly two missed heartbeats, so this gives
+// applications a chance to fail over earlier.
+type ConnectionStale struct {
+	SessionID    quickfix.SessionID
+	LastReceived time.Time
+	Silence      time.Duration
+}
+
+// configureSession applies session level options to the quickfix settings,
+// over those of the [SESSION] sections.
+func configureSession(settings *quickfix.Settings, o Options) error {
+	apply := func(s *quickfix.SessionSettings) {
+		// The logon signature covers MsgSeqNum=1, so every logon, including
+		// reconnects, has to start a new sequence.
+		if !s.HasSetting(config.ResetOnLogon) {
+			s.Set(config.ResetOnLogon, "Y")
+		}
+		if o.heartbeatInterval > 0 {
+			seconds := max(int(o.heartbeatInterval/time.Second), 1)
+			s.Set(config.HeartBtInt, strconv.Itoa(seconds))
+		}
+		if o.schedule != nil {
+			o.schedule.apply(s)
+		}
+	}
+	apply(settings.GlobalSettings())
+	return updateSessionSettings(settings, apply)
+}
+
+// touch records that a message was received from the server.
+func (c *Client) touch() {
+	c.lastReceived.Store(c.now().UnixNano())
+}
+
+func (c *Client) startStaleWatchdog(sessionID quickfix.SessionID) {
+	if c.heartbeatInterval <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	if c.watchdogStop != nil {
+		close(c.watchdogStop)
+	}
+	stop := make(chan struct{})
+	c.watchdogStop = stop
+	c.mu.Unlock()
+
+	c.touch()
+	go c.watchStale(c.Context(), sessionID, stop)
+}
+
+func (c *Client) stopStaleWatchdog() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.watchdogStop != nil {
+		close(c.watchdogStop)
+		c.watchdogStop = nil
+	}
+}
+
+// watchStale emits ConnectionStale once nothing was received for the
+// heartbeat interval plus the test request timeout. It only reports: quickfix
+// sends its own TestRequest and drops the session on its own schedule.
+func (c *Client) watchStale(ctx context.Context, sessionID quickfix.SessionID, stop <-chan struct{}) {
+	timeout := c.options.testRequestTimeout
+	if timeout <= 0 {
+		timeout = c.heartbeatInterval / 2
+	}
+	staleAfter := c.heartbeatInterval + timeout
+
+	interval := max(staleAfter/10, minStaleCheckInterval)
+	stale := false
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ctx.Done():
+			return
+		case now := <-c.after(interval):
+			lastReceived := time.Unix(0, c.lastReceived.Load())
+			silence := now.Sub(lastReceived)
+			if silence <= staleAfter {
+				stale = false
+				continue
+			}
+			if !stale {
+				stale = true
+				c.emit(ConnectionStaleTopic, &ConnectionStale{
+					SessionID:    sessionID,
+					LastReceived: lastReceived,
+					Silence:      silence,
+				})
+			}
+		}
+	}
+}
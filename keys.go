@@ -0,0 +1,248 @@
+package fix
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"hash"
+	"os"
+	"strings"
+)
+
+const blockTypeEncryptedPrivateKey = "ENCRYPTED PRIVATE KEY"
+
+var (
+	ErrNoKeySource           = errors.New("no private key source")
+	ErrPassphraseRequired    = errors.New("private key is encrypted, passphrase required")
+	ErrIncorrectPassphrase   = errors.New("incorrect passphrase or corrupt private key")
+	ErrUnsupportedEncryption = errors.New("unsupported private key encryption")
+	ErrInvalidSeed           = errors.New("invalid ed25519 seed")
+)
+
+// PassphraseFunc returns the passphrase of an encrypted private key.
+type PassphraseFunc func() ([]byte, error)
+
+// Source describes where LoadPrivateKey reads an Ed25519 key from. Exactly
+// one of PEM, File and Seed is set.
+type Source struct {
+	// PEM is a PKCS#8 "PRIVATE KEY" or "ENCRYPTED PRIVATE KEY" block.
+	PEM []byte
+	// File is the path of a PEM file.
+	File string
+	// Seed is a 32-byte Ed25519 seed, raw or base64 encoded, as exported by
+	// some key-management systems.
+	Seed []byte
+	// Passphrase is called to decrypt an "ENCRYPTED PRIVATE KEY" block.
+	Passphrase PassphraseFunc
+}
+
+// LoadPrivateKey loads an Ed25519 private key from src. Encrypted PEM keys
+// must use PBES2 with PBKDF2 and AES-CBC, the default of openssl genpkey.
+func LoadPrivateKey(src Source) (ed25519.PrivateKey, error) {
+	switch {
+	case src.Seed != nil:
+		return parseSeed(src.Seed)
+	case src.PEM != nil:
+		return parsePEMKey(src.PEM, src.Passphrase)
+	case src.File != "":
+		data, err := os.ReadFile(src.File)
+		if err != nil {
+			return nil, err
+		}
+		return parsePEMKey(data, src.Passphrase)
+	default:
+		return nil, ErrNoKeySource
+	}
+}
+
+func parseSeed(seed []byte) (ed25519.PrivateKey, error) {
+	if len(seed) != ed25519.SeedSize {
+		s := strings.TrimSpace(string(seed))
+		var err error
+		for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+			if seed, err = enc.DecodeString(s); err == nil {
+				break
+			}
+		}
+		if err != nil || len(seed) != ed25519.SeedSize {
+			return nil, ErrInvalidSeed
+		}
+	}
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+func parsePEMKey(data []byte, passphrase PassphraseFunc) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrNilPrivateKeyValue
+	}
+
+	der := block.Bytes
+	switch block.Type {
+	case blockTypePrivateKey:
+	case blockTypeEncryptedPrivateKey:
+		if passphrase == nil {
+			return nil, ErrPassphraseRequired
+		}
+		password, err := passphrase()
+		if err != nil {
+			return nil, err
+		}
+		if der, err = decryptPKCS8(der, password); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrNilPrivateKeyValue
+	}
+
+	privateKey, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		if block.Type == blockTypeEncryptedPrivateKey {
+			return nil, ErrIncorrectPassphrase
+		}
+		return nil, err
+	}
+	ret, ok := privateKey.(ed25519.PrivateKey)
+	if !ok {
+		return nil, ErrInvalidEd25519Key
+	}
+	return ret, nil
+}
+
+var (
+	oidPBES2      = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2     = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidHMACSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidHMACSHA512 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 11}
+	oidAES128CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+)
+
+type algorithmIdentifier struct {
+	Algorithm  asn1.ObjectIdentifier
+	Parameters asn1.RawValue `asn1:"optional"`
+}
+
+type encryptedPrivateKeyInfo struct {
+	Algorithm     algorithmIdentifier
+	EncryptedData []byte
+}
+
+type pbes2Params struct {
+	KeyDerivationFunc algorithmIdentifier
+	EncryptionScheme  algorithmIdentifier
+}
+
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                 `asn1:"optional"`
+	PRF            algorithmIdentifier `asn1:"optional"`
+}
+
+// decryptPKCS8 decrypts an EncryptedPrivateKeyInfo (RFC 5958) protected with
+// PBES2 (RFC 8018).
+func decryptPKCS8(der, password []byte) ([]byte, error) {
+	var info encryptedPrivateKeyInfo
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, err
+	}
+	if !info.Algorithm.Algorithm.Equal(oidPBES2) {
+		return nil, ErrUnsupportedEncryption
+	}
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, err
+	}
+	if !params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) {
+		return nil, ErrUnsupportedEncryption
+	}
+	var kdf pbkdf2Params
+	if _, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdf); err != nil {
+		return nil, err
+	}
+
+	var prf func() hash.Hash
+	switch {
+	case kdf.PRF.Algorithm == nil, kdf.PRF.Algorithm.Equal(oidHMACSHA1):
+		prf = sha1.New
+	case kdf.PRF.Algorithm.Equal(oidHMACSHA256):
+		prf = sha256.New
+	case kdf.PRF.Algorithm.Equal(oidHMACSHA512):
+		prf = sha512.New
+	default:
+		return nil, ErrUnsupportedEncryption
+	}
+
+	var keyLen int
+	switch scheme := params.EncryptionScheme.Algorithm; {
+	case scheme.Equal(oidAES128CBC):
+		keyLen = 16
+	case scheme.Equal(oidAES192CBC):
+		keyLen = 24
+	case scheme.Equal(oidAES256CBC):
+		keyLen = 32
+	default:
+		return nil, ErrUnsupportedEncryption
+	}
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(pbkdf2Key(prf, password, kdf.Salt, kdf.IterationCount, keyLen))
+	if err != nil {
+		return nil, err
+	}
+	data := info.EncryptedData
+	if len(iv) != block.BlockSize() || len(data) == 0 || len(data)%block.BlockSize() != 0 {
+		return nil, ErrIncorrectPassphrase
+	}
+	plain := make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plain, data)
+
+	// Strip the PKCS#7 padding, a wrong passphrase usually breaks it.
+	pad := int(plain[len(plain)-1])
+	if pad == 0 || pad > block.BlockSize() || !bytes.Equal(plain[len(plain)-pad:], bytes.Repeat([]byte{byte(pad)}, pad)) {
+		return nil, ErrIncorrectPassphrase
+	}
+	return plain[:len(plain)-pad], nil
+}
+
+// pbkdf2Key derives a key as specified by RFC 8018, section 5.2.
+func pbkdf2Key(prf func() hash.Hash, password, salt []byte, iterations, keyLen int) []byte {
+	mac := hmac.New(prf, password)
+	var key []byte
+	var counter [4]byte
+	for i := uint32(1); len(key) < keyLen; i++ {
+		mac.Reset()
+		mac.Write(salt)
+		binary.BigEndian.PutUint32(counter[:], i)
+		mac.Write(counter[:])
+		u := mac.Sum(nil)
+		t := bytes.Clone(u)
+		for n := 1; n < iterations; n++ {
+			mac.Reset()
+			mac.Write(u)
+			u = mac.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+		key = append(key, t...)
+	}
+	return key[:keyLen]
+}
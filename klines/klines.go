@@ -0,0 +1,157 @@
+// Package klines aggregates the trade stream into OHLCV candlesticks.
+//
+// Bars are aligned to multiples of their interval in UTC and are closed by
+// the first trade of a later interval, or by Flush when the market is quiet.
+// Intervals without trades produce no bar.
+package klines
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ljm2ya/binance_fix_api/handlers"
+)
+
+// Bar is an OHLCV candlestick of a symbol.
+type Bar struct {
+	Symbol      string
+	Interval    time.Duration
+	OpenTime    time.Time
+	CloseTime   time.Time
+	Open        float64
+	High        float64
+	Low         float64
+	Close       float64
+	Volume      float64
+	QuoteVolume float64
+	Trades      int
+}
+
+// BarHandler is called with every closed bar.
+type BarHandler func(bar Bar)
+
+type Option func(b *Builder)
+
+// WithIntervals sets the bar intervals built for every symbol, 1m by default.
+func WithIntervals(intervals ...time.Duration) Option {
+	return func(b *Builder) {
+		b.intervals = intervals
+	}
+}
+
+type barKey struct {
+	symbol   string
+	interval time.Duration
+}
+
+// Builder builds bars from trades.
+type Builder struct {
+	mu        sync.Mutex
+	intervals []time.Duration
+	onClose   BarHandler
+	bars      map[barKey]*Bar
+}
+
+// New creates a Builder calling onClose with every closed bar.
+func New(onClose BarHandler, opts ...Option) *Builder {
+	b := &Builder{
+		intervals: []time.Duration{time.Minute},
+		onClose:   onClose,
+		bars:      make(map[barKey]*Bar),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// HandleTrade adds a trade to the bars of its symbol. Its signature matches
+// fix.TradeStreamHandler so it can be passed to Client.SubscribeToTradeStream
+// directly. Trades older than the current bar are ignored.
+func (b *Builder) HandleTrade(t *handlers.Trade) {
+	tradeTime := t.TradeTime
+	if tradeTime.IsZero() {
+		tradeTime = time.Now()
+	}
+
+	var closed []Bar
+
+	b.mu.Lock()
+	for _, interval := range b.intervals {
+		key := barKey{t.Symbol, interval}
+		openTime := tradeTime.Truncate(interval)
+
+		bar := b.bars[key]
+		if bar != nil && openTime.Before(bar.OpenTime) {
+			continue
+		}
+		if bar != nil && openTime.After(bar.OpenTime) {
+			closed = append(closed, *bar)
+			bar = nil
+		}
+		if bar == nil {
+			bar = &Bar{
+				Symbol:    t.Symbol,
+				Interval:  interval,
+				OpenTime:  openTime,
+				CloseTime: openTime.Add(interval),
+				Open:      t.Price,
+				High:      t.Price,
+				Low:       t.Price,
+			}
+			b.bars[key] = bar
+		}
+
+		bar.High = max(bar.High, t.Price)
+		bar.Low = min(bar.Low, t.Price)
+		bar.Close = t.Price
+		bar.Volume += t.Quantity
+		bar.QuoteVolume += t.Price * t.Quantity
+		bar.Trades++
+	}
+	b.mu.Unlock()
+
+	b.emit(closed)
+}
+
+// Flush closes all bars whose interval ended before now. Call it periodically
+// to receive bars of symbols that stopped trading.
+func (b *Builder) Flush(now time.Time) {
+	var closed []Bar
+
+	b.mu.Lock()
+	for key, bar := range b.bars {
+		if !now.Before(bar.CloseTime) {
+			closed = append(closed, *bar)
+			delete(b.bars, key)
+		}
+	}
+	b.mu.Unlock()
+
+	sort.Slice(closed, func(i, j int) bool {
+		return closed[i].CloseTime.Before(closed[j].CloseTime)
+	})
+	b.emit(closed)
+}
+
+// Current returns the open bar of a symbol and interval.
+func (b *Builder) Current(symbol string, interval time.Duration) (Bar, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	bar, ok := b.bars[barKey{symbol, interval}]
+	if !ok {
+		return Bar{}, false
+	}
+	return *bar, true
+}
+
+func (b *Builder) emit(closed []Bar) {
+	if b.onClose == nil {
+		return
+	}
+	for _, bar := range closed {
+		b.onClose(bar)
+	}
+}
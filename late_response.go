@@ -0,0 +1,76 @@
+package fix
+
+import (
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/quickfix"
+)
+
+// lateResponseRetention is how long a call that gave up waiting is
+// remembered, so its response can still be told apart from an unsolicited
+// message.
+const lateResponseRetention = 10 * time.Minute
+
+// LateResponse is emitted for the response of a call that returned before it
+// arrived, e.g. because the call's context timed out. The application can
+// reconcile the request with it, e.g. learn that a timed out order landed.
+type LateResponse struct {
+	RequestID string // correlation ID of the request, e.g. the ClOrdID
+	MsgType   string // MsgType of the request
+	Response  *quickfix.Message
+	// Delay is how long after the call gave up the response arrived.
+	Delay time.Duration
+}
+
+type lateCall struct {
+	msgType  string
+	gaveUpAt time.Time
+}
+
+// lateCalls are the calls that gave up waiting, by request ID.
+type lateCalls struct {
+	mu    sync.Mutex
+	calls map[string]lateCall
+}
+
+// add remembers a call that gave up and forgets the ones older than
+// lateResponseRetention.
+func (l *lateCalls) add(id, msgType string, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.calls == nil {
+		l.calls = make(map[string]lateCall)
+	}
+	for otherID, call := range l.calls {
+		if now.Sub(call.gaveUpAt) > lateResponseRetention {
+			delete(l.calls, otherID)
+		}
+	}
+	l.calls[id] = lateCall{msgType: msgType, gaveUpAt: now}
+}
+
+// take returns and forgets the call of id that gave up, if any.
+func (l *lateCalls) take(id string) (lateCall, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	call, ok := l.calls[id]
+	if ok {
+		delete(l.calls, id)
+	}
+	return call, ok
+}
+
+// lateResponse emits the response to a call that gave up.
+func (c *Client) lateResponse(id string, call lateCall, msg *quickfix.Message) {
+	e := &LateResponse{
+		RequestID: id,
+		MsgType:   call.msgType,
+		Response:  msg,
+		Delay:     max(c.since(call.gaveUpAt), 0),
+	}
+	c.logger().Warnw("Response arrived after its call gave up", "reqID", id, "msgType", call.msgType, "delay", e.Delay)
+	c.emit(LateResponseTopic, e)
+}
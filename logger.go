@@ -0,0 +1,36 @@
+package fix
+
+import "go.uber.org/zap"
+
+// Logger receives the operational logs of the client itself: logons and
+// reconnects, messages it failed to decode or dropped, maintenance
+// announcements and the like. *zap.SugaredLogger implements it.
+type Logger interface {
+	Debugw(msg string, keysAndValues ...interface{})
+	Infow(msg string, keysAndValues ...interface{})
+	Warnw(msg string, keysAndValues ...interface{})
+	Errorw(msg string, keysAndValues ...interface{})
+}
+
+// WithLogger sets the logger of the client internals, the global zap logger
+// (zap.S()) by default. WithZapLogFactory and WithFixLogFactoryOpt only cover
+// the FIX wire log.
+func WithLogger(logger Logger) NewClientOption {
+	return func(o *Options) {
+		o.logger = logger
+	}
+}
+
+// NewZapLogger adapts a zap logger for WithLogger.
+func NewZapLogger(logger *zap.Logger) Logger {
+	return logger.Sugar()
+}
+
+// logger returns the logger of WithLogger. The global zap logger is looked up
+// on every call so zap.ReplaceGlobals after NewClient takes effect.
+func (c *Client) logger() Logger {
+	if c.options.logger != nil {
+		return c.options.logger
+	}
+	return zap.S()
+}
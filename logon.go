@@ -0,0 +1,102 @@
+package fix
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// LogonError is returned by Start when the server answers the logon with a
+// Logout or Reject, e.g. for a bad signature, an unknown API key or an IP
+// address that is not whitelisted.
+type LogonError struct {
+	MsgType string // "5" (Logout) or "3" (Reject)
+	Reason  string // Text sent by the server
+}
+
+func (e *LogonError) Error() string {
+	if e.Reason == "" {
+		return "logon rejected"
+	}
+	return fmt.Sprintf("logon rejected: %s", e.Reason)
+}
+
+// failLogon hands a logon failure to a waiting Start.
+func (c *Client) failLogon(err error) {
+	select {
+	case c.logonErr <- err:
+	default:
+	}
+}
+
+// drainLogonError drops a failure left over from a previous logon attempt.
+func (c *Client) drainLogonError() {
+	select {
+	case <-c.logonErr:
+	default:
+	}
+}
+
+// sessionWatchLogFactory wraps session logs to see the raw wire traffic: to
+// catch the server's answer to a rejected logon, to sample the server clock
+// and to validate messages against a data dictionary. quickfix drops messages
+// other than Logon while waiting for the logon response, so the application
+// callbacks never see them.
+type sessionWatchLogFactory struct {
+	quickfix.LogFactory
+	c *Client
+}
+
+func (f *sessionWatchLogFactory) CreateSessionLog(sessionID quickfix.SessionID) (quickfix.Log, error) {
+	log, err := f.LogFactory.CreateSessionLog(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return &sessionWatchLog{Log: log, c: f.c}, nil
+}
+
+type sessionWatchLog struct {
+	quickfix.Log
+	c *Client
+}
+
+var (
+	logoutMsgType = []byte("\x0135=5\x01")
+	rejectMsgType = []byte("\x0135=3\x01")
+)
+
+func (l *sessionWatchLog) OnIncoming(data []byte) {
+	l.Log.OnIncoming(data)
+	l.c.observeClock(data, l.c.now())
+	l.c.observeSeqNum(data)
+	if l.c.validator != nil {
+		l.c.validate(data, false)
+	}
+
+	if l.c.IsConnected() || !(bytes.Contains(data, logoutMsgType) || bytes.Contains(data, rejectMsgType)) {
+		return
+	}
+
+	msg := quickfix.NewMessage()
+	if err := quickfix.ParseMessage(msg, bytes.NewBuffer(bytes.Clone(data))); err != nil {
+		return
+	}
+	msgType, _ := msg.MsgType()
+	if enum.MsgType(msgType) != enum.MsgType_LOGOUT && enum.MsgType(msgType) != enum.MsgType_REJECT {
+		return
+	}
+	reason, _ := msg.Body.GetString(tag.Text)
+
+	l.c.setState(StateReconnecting)
+	l.c.failLogon(&LogonError{MsgType: msgType, Reason: reason})
+}
+
+func (l *sessionWatchLog) OnOutgoing(data []byte) {
+	l.Log.OnOutgoing(data)
+	if l.c.validator != nil {
+		l.c.validate(data, true)
+	}
+}
@@ -0,0 +1,159 @@
+package fix
+
+import (
+	"context"
+	"runtime"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+
+	"github.com/ljm2ya/binance_fix_api/fixerr"
+	"github.com/ljm2ya/binance_fix_api/handlers"
+)
+
+// spinCheckInterval is how many polls of a spinning waiter pass between
+// checks of its context.
+const spinCheckInterval = 1024
+
+// WithBusyPoll makes calls spin on their response on a goroutine locked to
+// its OS thread instead of parking, and hands responses to their callers
+// before the subscribers see them. Every waiting call keeps a core busy, so
+// it only pays off with spare cores and GOMAXPROCS > 1.
+func WithBusyPoll() NewClientOption {
+	return func(o *Options) {
+		o.busyPoll = true
+	}
+}
+
+// OrderTemplate is a NewOrderSingle <D> built once for a symbol, side, type
+// and time in force. Send copies it and patches the ClOrdID, quantity and
+// price in, skipping the builder, the local order checks and the tracing of
+// NewOrderSingleService. Maintenance quiescing, the circuit breaker,
+// WithInstruments and WithSymbolInFlightLimit still apply.
+type OrderTemplate struct {
+	c      *Client
+	symbol string
+	msg    *quickfix.Message
+}
+
+// NewOrderTemplate builds the template of orders of symbol. An empty
+// timeInForce leaves it out, e.g. for market orders.
+func (c *Client) NewOrderTemplate(
+	symbol string, side enum.Side, orderType enum.OrdType, timeInForce enum.TimeInForce,
+) *OrderTemplate {
+	msg := quickfix.NewMessage()
+	msg.Header.Set(field.NewMsgType(enum.MsgType_ORDER_SINGLE))
+	msg.Body.Set(field.NewSymbol(symbol))
+	msg.Body.Set(field.NewSide(side))
+	msg.Body.Set(field.NewOrdType(orderType))
+	if timeInForce != "" {
+		msg.Body.Set(field.NewTimeInForce(timeInForce))
+	}
+
+	return &OrderTemplate{c: c, symbol: symbol, msg: msg}
+}
+
+// Send places an order of quantity at price from the template and waits for
+// its first execution report. A zero price is left out.
+func (t *OrderTemplate) Send(ctx context.Context, quantity, price float64) (order handlers.Order, err error) {
+	start, id := t.c.now(), ""
+	defer func() { err = fixerr.Wrap(err, id, string(enum.MsgType_ORDER_SINGLE), t.c.since(start)) }()
+
+	c := t.c
+	if c.maintenanceQuiesced() {
+		return handlers.Order{}, ErrMaintenance
+	}
+	if c.breaker != nil && !c.breaker.allow(c.now()) {
+		return handlers.Order{}, ErrCircuitOpen
+	}
+
+	id, err = c.resolveClOrdID("")
+	if err != nil {
+		return handlers.Order{}, err
+	}
+	if v := c.options.instruments; v != nil {
+		if err := v.ValidateOrder(t.symbol, price, quantity); err != nil {
+			return handlers.Order{}, err
+		}
+	}
+
+	release, err := c.acquireSymbol(ctx, t.symbol)
+	if err != nil {
+		return handlers.Order{}, err
+	}
+	defer release()
+
+	timings := handlers.Timings{Built: c.now()}
+	msg := quickfix.NewMessage()
+	t.msg.CopyInto(msg)
+	msg.Body.SetString(tag.ClOrdID, id)
+	msg.Body.SetString(tag.OrderQty, floatToString(quantity))
+	if price != 0 {
+		msg.Body.SetString(tag.Price, floatToString(price))
+	}
+
+	if c.options.callTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = c.withTimeout(ctx, c.options.callTimeout)
+		defer cancel()
+	}
+
+	if err := c.pace(ctx); err != nil {
+		return handlers.Order{}, err
+	}
+	call, err := c.send(id, msg, &timings)
+	if err != nil {
+		return handlers.Order{}, err
+	}
+	resp, err := c.await(ctx, call)
+	if err != nil {
+		c.forgetCall(id, call.call)
+		return handlers.Order{}, err
+	}
+
+	order, err = c.decodeOrderResponse(resp)
+	if err != nil {
+		c.backOff(err)
+		return handlers.Order{}, err
+	}
+	order.Timings = timings
+	return order, nil
+}
+
+// await waits for the response of a call, spinning with WithBusyPoll.
+func (c *Client) await(ctx context.Context, w waiter) (*quickfix.Message, error) {
+	if c.options.busyPoll {
+		return w.spin(ctx)
+	}
+	return w.wait(ctx)
+}
+
+// spin polls for the response of a call without parking the goroutine.
+func (w waiter) spin(ctx context.Context) (*quickfix.Message, error) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	for i := 1; ; i++ {
+		select {
+		case err, ok := <-w.call.done:
+			if !ok {
+				err = ErrClosed
+			}
+			if err != nil {
+				return nil, err
+			}
+			return w.call.response, nil
+		default:
+		}
+
+		if i%spinCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			// Lets the receiving goroutine in when it shares the P.
+			runtime.Gosched()
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package fix
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultMaintenanceDuration is assumed for announcements without an end time.
+const defaultMaintenanceDuration = 10 * time.Minute
+
+var ErrMaintenance = errors.New("order sending paused for maintenance")
+
+// MaintenanceWindow is a maintenance announced by the server in a News <B>
+// message. End is zero when the announcement only names the start.
+type MaintenanceWindow struct {
+	Start    time.Time
+	End      time.Time
+	Headline string
+	Text     string
+}
+
+// until returns the end of the window, assuming defaultMaintenanceDuration
+// when it wasn't announced.
+func (w MaintenanceWindow) until() time.Time {
+	if !w.End.IsZero() {
+		return w.End
+	}
+	return w.Start.Add(defaultMaintenanceDuration)
+}
+
+var (
+	// 2024-05-01 02:00, 2024/05/01T02:00:00, 2024年05月01日 10:00 (UTC+8)
+	maintenanceTimeRe = regexp.MustCompile(
+		`(\d{4})[-/.年](\d{1,2})[-/.月](\d{1,2})日?[ T]?\s*(\d{1,2}):(\d{2})(?::(\d{2}))?` +
+			`(?:\s*\(?(?:UTC|GMT)\s*([+-]\d{1,2})(?::?(\d{2}))?\)?)?`)
+	maintenanceRelativeRe    = regexp.MustCompile(`(?i)\bin (\d+) (second|minute|hour)s?\b`)
+	maintenanceRelativeCJKRe = regexp.MustCompile(`(\d+)\s*(秒|分钟|分鐘|分|小时|小時|時間|초|분|시간)\s*[后後후]`)
+)
+
+var maintenanceUnits = map[string]time.Duration{
+	"second": time.Second,
+	"minute": time.Minute,
+	"hour":   time.Hour,
+	"秒":      time.Second,
+	"分钟":     time.Minute,
+	"分鐘":     time.Minute,
+	"分":      time.Minute,
+	"小时":     time.Hour,
+	"小時":     time.Hour,
+	"時間":     time.Hour,
+	"초":      time.Second,
+	"분":      time.Minute,
+	"시간":     time.Hour,
+}
+
+// ParseMaintenanceWindow extracts the maintenance times from the headline and
+// text of a News <B> message. Absolute times ("2024-05-01 02:00 UTC",
+// "2024/05/01 10:00 (UTC+8)", "2024年05月01日 10:00") are read as UTC unless
+// they carry an offset, the first one being the start and the second one the
+// end. Otherwise a relative start ("in 10 minutes", "10分钟后") is resolved
+// against now.
+func ParseMaintenanceWindow(headline, text string, now time.Time) (MaintenanceWindow, bool) {
+	w := MaintenanceWindow{Headline: headline, Text: text}
+	content := headline + "\n" + text
+
+	var times []time.Time
+	for _, m := range maintenanceTimeRe.FindAllStringSubmatch(content, 2) {
+		if t, ok := maintenanceTime(m); ok {
+			times = append(times, t)
+		}
+	}
+	if len(times) > 0 {
+		w.Start = times[0]
+		if len(times) > 1 && times[1].After(times[0]) {
+			w.End = times[1]
+		}
+		return w, true
+	}
+
+	m := maintenanceRelativeRe.FindStringSubmatch(content)
+	if m == nil {
+		m = maintenanceRelativeCJKRe.FindStringSubmatch(content)
+	}
+	if m != nil {
+		n, err := strconv.Atoi(m[1])
+		if err != nil {
+			return w, false
+		}
+		w.Start = now.Add(time.Duration(n) * maintenanceUnits[strings.ToLower(m[2])])
+		return w, true
+	}
+
+	return w, false
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// maintenanceTime converts a match of maintenanceTimeRe to UTC.
+func maintenanceTime(m []string) (time.Time, bool) {
+	var n [8]int
+	for i, s := range m[1:] {
+		if s == "" {
+			continue
+		}
+		v, err := strconv.Atoi(strings.TrimPrefix(s, "+"))
+		if err != nil {
+			return time.Time{}, false
+		}
+		n[i] = v
+	}
+	year, month, day, hour, minute, second, offsetHours, offsetMinutes := n[0], n[1], n[2], n[3], n[4], n[5], n[6], n[7]
+	if month < 1 || month > 12 || day < 1 || day > 31 || hour > 23 || minute > 59 || second > 59 {
+		return time.Time{}, false
+	}
+
+	offset := time.Duration(abs(offsetHours))*time.Hour + time.Duration(offsetMinutes)*time.Minute
+	if strings.HasPrefix(m[7], "-") {
+		offset = -offset
+	}
+	t := time.Date(year, time.Month(month), day, hour, minute, second, 0, time.UTC)
+	return t.Add(-offset), true
+}
+
+// WithMaintenanceQuiesce rejects new orders with ErrMaintenance from lead
+// before an announced maintenance until it is over, i.e. until its end time
+// or, when no end was announced, until the session has logged on again after
+// the start (at most 10 minutes). Cancels are still sent.
+func WithMaintenanceQuiesce(lead time.Duration) NewClientOption {
+	return func(o *Options) {
+		o.maintenanceQuiesce = lead
+	}
+}
+
+// WithMaintenanceFailover reconnects to another gateway lead before an
+// announced maintenance instead of waiting for the server to disconnect. It
+// needs failover gateways (DefaultEndpoints[...].FailoverAddresses); the
+// current gateway is avoided until the maintenance is over.
+func WithMaintenanceFailover(lead time.Duration) NewClientOption {
+	return func(o *Options) {
+		o.maintenanceFailover = lead
+	}
+}
+
+// NextMaintenance returns the announced maintenance that hasn't ended yet.
+func (c *Client) NextMaintenance() (MaintenanceWindow, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.maintenance == nil || !c.now().Before(c.maintenance.until()) {
+		return MaintenanceWindow{}, false
+	}
+	return *c.maintenance, true
+}
+
+// scheduleMaintenance records an announced maintenance and arms the
+// pre-emptive failover or the planned restart. It returns the window, nil if
+// the notice has no time, and whether the failover was armed.
+func (c *Client) scheduleMaintenance(notice MaintenanceNotice) (*MaintenanceWindow, bool) {
+	now := c.now()
+	w, ok := notice.Window()
+	if !ok {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.maintenance = &w
+	announced := w // not shared with c.maintenance
+	if c.maintenanceTimer != nil {
+		c.maintenanceTimer.Stop()
+		c.maintenanceTimer = nil
+	}
+	if c.options.maintenanceFailover > 0 && c.relay != nil {
+		until := w.until()
+		c.maintenanceTimer = c.afterFunc(w.Start.Add(-c.options.maintenanceFailover).Sub(now), func() {
+			c.relay.failover(until)
+		})
+		return &announced, true
+	}
+	if c.options.maintenanceRestart > 0 {
+		until := w.until()
+		c.maintenanceTimer = c.afterFunc(w.Start.Add(-c.options.maintenanceRestart).Sub(now), func() {
+			c.pauseForMaintenance(until)
+		})
+	}
+	return &announced, false
+}
+
+// maintenanceQuiesced reports whether new orders are held back because of an
+// upcoming maintenance.
+func (c *Client) maintenanceQuiesced() bool {
+	if c.options.maintenanceQuiesce <= 0 {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.maintenance == nil {
+		return false
+	}
+	now := c.now()
+	return !now.Before(c.maintenance.Start.Add(-c.options.maintenanceQuiesce)) && now.Before(c.maintenance.until())
+}
+
+// endMaintenance forgets a maintenance without an announced end once the
+// session is logged on again after its start.
+func (c *Client) endMaintenance() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.maintenance != nil && c.maintenance.End.IsZero() && !c.now().Before(c.maintenance.Start) {
+		c.maintenance = nil
+	}
+}
+
+func (c *Client) stopMaintenanceTimer() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.maintenanceTimer != nil {
+		c.maintenanceTimer.Stop()
+		c.maintenanceTimer = nil
+	}
+}
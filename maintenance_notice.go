@@ -0,0 +1,122 @@
+package fix
+
+import (
+	"strings"
+	"time"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// MaintenanceNotice is a News <B> message announcing a maintenance or asking
+// to reconnect, as parsed by ParseMaintenanceNotice.
+type MaintenanceNotice struct {
+	NewsID   string // NewsID <1472>, if sent
+	Headline string
+	Text     string // Text <58> or the LinesOfText <33>, one per line
+	Start    time.Time
+	End      time.Time // zero when only the start was announced
+	// Endpoints are the endpoint types named by the notice, empty when it
+	// names none and so concerns every session.
+	Endpoints []EndpointType
+	// Reconnect is set when the notice asks to reconnect.
+	Reconnect bool
+}
+
+// Affects reports whether the notice concerns sessions of endpoint.
+func (n MaintenanceNotice) Affects(endpoint EndpointType) bool {
+	if len(n.Endpoints) == 0 {
+		return true
+	}
+	for _, e := range n.Endpoints {
+		if e == endpoint {
+			return true
+		}
+	}
+	return false
+}
+
+// Window returns the maintenance window of the notice, false if it announced
+// no time.
+func (n MaintenanceNotice) Window() (MaintenanceWindow, bool) {
+	if n.Start.IsZero() {
+		return MaintenanceWindow{}, false
+	}
+	return MaintenanceWindow{Start: n.Start, End: n.End, Headline: n.Headline, Text: n.Text}, true
+}
+
+// The keywords are matched against the lower-cased headline and text, in the
+// languages Binance publishes announcements in.
+var (
+	maintenanceKeywords = []string{
+		"maintenance", "upgrade", "维护", "維護", "升级", "升級", "メンテナンス", "점검",
+		"mantenimiento", "manutenção", "wartung", "техническ", "bakım",
+	}
+	reconnectKeywords = []string{
+		"reconnect", "will be closed", "重新连接", "重连", "重新連線", "再接続", "재연결",
+		"reconectar", "reconnexion", "переподключ",
+	}
+	endpointKeywords = map[EndpointType][]string{
+		OrderEntryEndpoint: {"fix-oe", "fix oe", "order entry"},
+		MarketDataEndpoint: {"fix-md", "fix md", "market data"},
+		DropCopyEndpoint:   {"fix-dc", "fix dc", "drop copy"},
+	}
+)
+
+// ParseMaintenanceNotice parses a News <B> message. It returns false if the
+// message is neither about a maintenance nor asks to reconnect. Times are
+// read as by ParseMaintenanceWindow, relative ones against now.
+func ParseMaintenanceNotice(msg *quickfix.Message, now time.Time) (MaintenanceNotice, bool) {
+	n := MaintenanceNotice{}
+	n.NewsID, _ = msg.Body.GetString(tag.NewsID)
+	n.Headline, _ = msg.Body.GetString(tag.Headline)
+	n.Text = newsText(msg)
+
+	content := strings.ToLower(n.Headline + "\n" + n.Text)
+	n.Reconnect = containsAny(content, reconnectKeywords)
+	if !n.Reconnect && !containsAny(content, maintenanceKeywords) {
+		return MaintenanceNotice{}, false
+	}
+
+	if w, ok := ParseMaintenanceWindow(n.Headline, n.Text, now); ok {
+		n.Start, n.End = w.Start, w.End
+	}
+	for _, e := range []EndpointType{OrderEntryEndpoint, MarketDataEndpoint, DropCopyEndpoint} {
+		if containsAny(content, endpointKeywords[e]) {
+			n.Endpoints = append(n.Endpoints, e)
+		}
+	}
+	return n, true
+}
+
+// newsText returns the LinesOfText <33> of msg joined by newlines, or its
+// Text <58>.
+func newsText(msg *quickfix.Message) string {
+	if !msg.Body.Has(tag.NoLinesOfText) {
+		text, _ := msg.Body.GetString(tag.Text)
+		return text
+	}
+
+	lines := quickfix.NewRepeatingGroup(tag.NoLinesOfText,
+		quickfix.GroupTemplate{quickfix.GroupElement(tag.Text)})
+	if err := msg.Body.GetGroup(lines); err != nil {
+		text, _ := msg.Body.GetString(tag.Text)
+		return text
+	}
+	texts := make([]string, 0, lines.Len())
+	for i := range lines.Len() {
+		if text, err := lines.Get(i).GetString(tag.Text); err == nil {
+			texts = append(texts, text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+func containsAny(s string, keywords []string) bool {
+	for _, k := range keywords {
+		if strings.Contains(s, k) {
+			return true
+		}
+	}
+	return false
+}
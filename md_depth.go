@@ -0,0 +1,185 @@
+package fix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// ErrNoDepthEntries is returned by DecodeDepthUpdate for an incremental
+// refresh without bid or offer entries, e.g. one carrying trades.
+var ErrNoDepthEntries = errors.New("no depth entries")
+
+// DepthUpdate is an incremental refresh of the order book of a symbol, the
+// FIX counterpart of an event of the WebSocket diff depth stream:
+// FirstUpdateID and LastUpdateID are its "U" and "u", Bids and Asks its "b"
+// and "a", a removed level having a zero quantity. Book sync logic written
+// for the WebSocket stream ports directly, with GetMarketDataSnapshot for the
+// REST depth snapshot and its LastBookUpdateID for "lastUpdateId".
+type DepthUpdate struct {
+	MDReqID       string
+	Symbol        string
+	FirstUpdateID int64       // FirstBookUpdateID <25043>
+	LastUpdateID  int64       // LastBookUpdateID <25044>
+	Bids          []BookLevel // changed levels, in the order received
+	Asks          []BookLevel
+
+	// Raw is the message the update was decoded from.
+	Raw *quickfix.Message
+}
+
+// Continues reports whether u is the next update of a book at lastUpdateID,
+// like the WebSocket sync rule: FirstUpdateID <= lastUpdateID+1 <=
+// LastUpdateID. Updates with LastUpdateID <= lastUpdateID are stale and
+// dropped; any other update that doesn't continue the book means updates were
+// missed and the book has to be synced again.
+func (u *DepthUpdate) Continues(lastUpdateID int64) bool {
+	return u.FirstUpdateID <= lastUpdateID+1 && lastUpdateID+1 <= u.LastUpdateID
+}
+
+// SubscribeToDepth subscribes to the diff depth stream of symbol, the changes
+// to depth levels per side of its order book, delivered to
+// SubscribeToDepthUpdate listeners. It returns the MDReqID to unsubscribe
+// with.
+func (c *Client) SubscribeToDepth(ctx context.Context, symbol string, depth int) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	mdReqID := c.mdReqID("MDD_%s_%d", symbol, c.now().UnixNano())
+	msg := depthRequest(mdReqID, enum.SubscriptionRequestType_SNAPSHOT_PLUS_UPDATES, symbol, depth)
+	if err := c.SendWithoutResponse(msg); err != nil {
+		return "", err
+	}
+	_, _ = c.depthSymbols.addWithin([]string{symbol}, 0)
+	c.mdStreams.add(mdStream{mdReqID: mdReqID, symbol: symbol, request: func() *quickfix.Message {
+		return depthRequest(mdReqID, enum.SubscriptionRequestType_SNAPSHOT_PLUS_UPDATES, symbol, depth)
+	}})
+	return mdReqID, nil
+}
+
+// UnsubscribeFromDepth ends the diff depth stream of symbol subscribed with
+// mdReqID.
+func (c *Client) UnsubscribeFromDepth(ctx context.Context, symbol, mdReqID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	msg := depthRequest(mdReqID, enum.SubscriptionRequestType_DISABLE_PREVIOUS_SNAPSHOT_PLUS_UPDATE_REQUEST, symbol, 0)
+	if err := c.SendWithoutResponse(msg); err != nil {
+		return err
+	}
+	c.depthSymbols.remove([]string{symbol})
+	c.mdStreams.remove(mdReqID)
+	return nil
+}
+
+func depthRequest(mdReqID string, subscriptionType enum.SubscriptionRequestType, symbol string, depth int) *quickfix.Message {
+	msg := snapshotRequest(mdReqID, symbol, depth)
+	msg.Body.Set(field.NewSubscriptionRequestType(subscriptionType))
+	if depth <= 0 {
+		msg.Body.Remove(tag.MarketDepth)
+	}
+	return msg
+}
+
+// DecodeDepthUpdate decodes the bid and offer entries of a
+// MarketDataIncrementalRefresh <X>. Entries without a Symbol <55> belong to
+// the symbol of the entry before them.
+func DecodeDepthUpdate(msg *quickfix.Message) (DepthUpdate, error) {
+	msgType, err := msg.MsgType()
+	if err != nil {
+		return DepthUpdate{}, err
+	}
+	if enum.MsgType(msgType) != enum.MsgType_MARKET_DATA_INCREMENTAL_REFRESH {
+		return DepthUpdate{}, fmt.Errorf("%w: %s", ErrUnexpectedMsgType, msgType)
+	}
+
+	update := DepthUpdate{Raw: msg}
+	update.MDReqID, _ = msg.Body.GetString(tag.MDReqID)
+	if msg.Body.Has(tagFirstBookUpdateID) {
+		first, err := msg.Body.GetInt(tagFirstBookUpdateID)
+		if err != nil {
+			return DepthUpdate{}, err
+		}
+		update.FirstUpdateID = int64(first)
+	}
+	if msg.Body.Has(tagLastBookUpdateID) {
+		last, err := msg.Body.GetInt(tagLastBookUpdateID)
+		if err != nil {
+			return DepthUpdate{}, err
+		}
+		update.LastUpdateID = int64(last)
+	}
+	if !msg.Body.Has(tag.NoMDEntries) {
+		return DepthUpdate{}, ErrNoDepthEntries
+	}
+
+	entries := quickfix.NewRepeatingGroup(tag.NoMDEntries, quickfix.GroupTemplate{
+		quickfix.GroupElement(tag.MDUpdateAction),
+		quickfix.GroupElement(tag.MDEntryType),
+		quickfix.GroupElement(tag.Symbol),
+		quickfix.GroupElement(tag.MDEntryPx),
+		quickfix.GroupElement(tag.MDEntrySize),
+	})
+	if err := msg.Body.GetGroup(entries); err != nil {
+		return DepthUpdate{}, err
+	}
+
+	for i := range entries.Len() {
+		entry := entries.Get(i)
+
+		var (
+			action    field.MDUpdateActionField
+			entryType field.MDEntryTypeField
+			px        field.MDEntryPxField
+			size      field.MDEntrySizeField
+		)
+		if err := entry.Get(&entryType); err != nil {
+			return DepthUpdate{}, err
+		}
+		if t := entryType.Value(); t != enum.MDEntryType_BID && t != enum.MDEntryType_OFFER {
+			continue
+		}
+		if entry.Has(tag.Symbol) {
+			symbol, err := entry.GetString(tag.Symbol)
+			if err != nil {
+				return DepthUpdate{}, err
+			}
+			update.Symbol = symbol
+		}
+		if err := entry.Get(&px); err != nil {
+			return DepthUpdate{}, err
+		}
+		if entry.Has(tag.MDUpdateAction) {
+			if err := entry.Get(&action); err != nil {
+				return DepthUpdate{}, err
+			}
+		}
+		level := BookLevel{Price: px.InexactFloat64()}
+		// A deleted level has no size; the WebSocket stream sends it as 0.
+		if action.Value() != enum.MDUpdateAction_DELETE && entry.Has(tag.MDEntrySize) {
+			if err := entry.Get(&size); err != nil {
+				return DepthUpdate{}, err
+			}
+			level.Quantity = size.InexactFloat64()
+		}
+
+		if entryType.Value() == enum.MDEntryType_BID {
+			update.Bids = append(update.Bids, level)
+		} else {
+			update.Asks = append(update.Asks, level)
+		}
+	}
+
+	if len(update.Bids) == 0 && len(update.Asks) == 0 {
+		return DepthUpdate{}, ErrNoDepthEntries
+	}
+	if update.Symbol == "" {
+		update.Symbol, _ = msg.Body.GetString(tag.Symbol)
+	}
+	return update, nil
+}
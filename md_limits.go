@@ -0,0 +1,90 @@
+package fix
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Default market data limits, Binance's limits for FIX market data sessions
+// when this was written. Use WithMarketDataLimits if they change.
+const (
+	DefaultMaxSymbolsPerRequest = 100
+	DefaultMaxSymbolsPerSession = 1000
+)
+
+// ErrSymbolLimit is returned when a subscription would take the session over
+// its symbol budget, see WithMarketDataLimits.
+var ErrSymbolLimit = errors.New("market data symbol limit exceeded")
+
+// WithMarketDataLimits sets how many symbols a MarketDataRequest <V> may list
+// and how many symbols the session may be subscribed to at once, by default
+// DefaultMaxSymbolsPerRequest and DefaultMaxSymbolsPerSession. Subscriptions
+// with more symbols than perRequest are split over several requests, and a
+// subscription that would exceed perSession fails with ErrSymbolLimit without
+// subscribing any of its symbols. Zero lifts a limit.
+func WithMarketDataLimits(perRequest, perSession int) NewClientOption {
+	return func(o *Options) {
+		o.mdSymbolsPerRequest = perRequest
+		o.mdSymbolsPerSession = perSession
+	}
+}
+
+// addWithin adds symbols to the set unless that would take it over limit,
+// zero for no limit. It returns the symbols that weren't in the set yet.
+func (s *symbolSet) addWithin(symbols []string, limit int) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.symbols == nil {
+		s.symbols = make(map[string]struct{})
+	}
+	added := make([]string, 0, len(symbols))
+	seen := make(map[string]struct{}, len(symbols))
+	for _, symbol := range symbols {
+		if _, ok := s.symbols[symbol]; ok {
+			continue
+		}
+		if _, ok := seen[symbol]; ok {
+			continue
+		}
+		seen[symbol] = struct{}{}
+		added = append(added, symbol)
+	}
+	if limit > 0 && len(s.symbols)+len(added) > limit {
+		return nil, fmt.Errorf("%w: %d subscribed, %d more requested, limit %d",
+			ErrSymbolLimit, len(s.symbols), len(added), limit)
+	}
+	for _, symbol := range added {
+		s.symbols[symbol] = struct{}{}
+	}
+	return added, nil
+}
+
+// chunkSymbols splits symbols into runs of at most size, all of them in one
+// run when size is zero.
+func chunkSymbols(symbols []string, size int) [][]string {
+	if size <= 0 || len(symbols) <= size {
+		return [][]string{symbols}
+	}
+	chunks := make([][]string, 0, (len(symbols)+size-1)/size)
+	for len(symbols) > size {
+		chunks = append(chunks, symbols[:size])
+		symbols = symbols[size:]
+	}
+	return append(chunks, symbols)
+}
+
+// intersect returns the symbols of a that are also in b.
+func intersect(a, b []string) []string {
+	in := make(map[string]struct{}, len(b))
+	for _, symbol := range b {
+		in[symbol] = struct{}{}
+	}
+	var both []string
+	for _, symbol := range a {
+		if _, ok := in[symbol]; ok {
+			both = append(both, symbol)
+		}
+	}
+	return both
+}
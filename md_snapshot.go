@@ -0,0 +1,193 @@
+package fix
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+
+	"github.com/ljm2ya/binance_fix_api/handlers"
+)
+
+// BookLevel is a price level of the order book.
+type BookLevel struct {
+	Price    float64
+	Quantity float64
+}
+
+// MarketDataSnapshot is the answer to GetMarketDataSnapshot.
+type MarketDataSnapshot struct {
+	MDReqID          string
+	Symbol           string
+	LastBookUpdateID int64
+	Bids             []BookLevel // best first
+	Asks             []BookLevel // best first
+	Trades           []handlers.Trade
+}
+
+// MarketDataReject is returned for a MarketDataRequestReject <Y>.
+type MarketDataReject struct {
+	MDReqID   string
+	Reason    string
+	ErrorCode string // ErrorCode <25016>
+	Text      string
+	// RetryAfter is how long the exchange asks to wait before sending again,
+	// see handlers.ParseRetryAfter.
+	RetryAfter time.Duration
+}
+
+func (r *MarketDataReject) Error() string {
+	return fmt.Sprintf("market data request %s rejected: %s (reason %s)", r.MDReqID, r.Text, r.Reason)
+}
+
+// GetMarketDataSnapshot requests a one-off snapshot of the order book of
+// symbol, depth levels per side, and waits for it. No subscription is left
+// behind.
+func (c *Client) GetMarketDataSnapshot(ctx context.Context, symbol string, depth int) (MarketDataSnapshot, error) {
+	return queryAndDecode(ctx, c, c.options.mdReqIDPrefix, func(id string) *quickfix.Message {
+		return snapshotRequest(id, symbol, depth)
+	}, c.decodeMarketDataSnapshot)
+}
+
+func snapshotRequest(id, symbol string, depth int) *quickfix.Message {
+	msg := quickfix.NewMessage()
+	msg.Header.Set(field.NewMsgType(enum.MsgType_MARKET_DATA_REQUEST))
+	msg.Body.Set(field.NewMDReqID(id))
+	msg.Body.Set(field.NewSubscriptionRequestType(enum.SubscriptionRequestType_SNAPSHOT))
+	msg.Body.Set(field.NewMarketDepth(depth))
+
+	symbols := quickfix.NewRepeatingGroup(tag.NoRelatedSym,
+		quickfix.GroupTemplate{quickfix.GroupElement(tag.Symbol)})
+	symbols.Add().Set(field.NewSymbol(symbol))
+	msg.Body.SetGroup(symbols)
+
+	entryTypes := quickfix.NewRepeatingGroup(tag.NoMDEntryTypes,
+		quickfix.GroupTemplate{quickfix.GroupElement(tag.MDEntryType)})
+	entryTypes.Add().Set(field.NewMDEntryType(enum.MDEntryType_BID))
+	entryTypes.Add().Set(field.NewMDEntryType(enum.MDEntryType_OFFER))
+	msg.Body.SetGroup(entryTypes)
+	return msg
+}
+
+// DecodeMarketDataSnapshot decodes a MarketDataSnapshotFullRefresh <W>. A
+// MarketDataRequestReject <Y> is returned as a *MarketDataReject error, its
+// RetryAfter relative to the system clock.
+func DecodeMarketDataSnapshot(msg *quickfix.Message) (MarketDataSnapshot, error) {
+	return decodeMarketDataSnapshotAt(msg, time.Now())
+}
+
+// decodeMarketDataSnapshot decodes a snapshot with the RetryAfter of a reject
+// relative to the client's Clock.
+func (c *Client) decodeMarketDataSnapshot(msg *quickfix.Message) (MarketDataSnapshot, error) {
+	return decodeMarketDataSnapshotAt(msg, c.now())
+}
+
+// decodeMarketDataSnapshotAt is DecodeMarketDataSnapshot with the RetryAfter
+// of a reject relative to now.
+func decodeMarketDataSnapshotAt(msg *quickfix.Message, now time.Time) (MarketDataSnapshot, error) {
+	msgType, err := msg.MsgType()
+	if err != nil {
+		return MarketDataSnapshot{}, err
+	}
+
+	switch enum.MsgType(msgType) {
+	case enum.MsgType_MARKET_DATA_SNAPSHOT_FULL_REFRESH:
+	case enum.MsgType_MARKET_DATA_REQUEST_REJECT:
+		return MarketDataSnapshot{}, decodeMarketDataReject(msg, now)
+	default:
+		return MarketDataSnapshot{}, fmt.Errorf("%w: %s", ErrUnexpectedMsgType, msgType)
+	}
+
+	snapshot := MarketDataSnapshot{}
+	if snapshot.MDReqID, err = msg.Body.GetString(tag.MDReqID); err != nil {
+		return MarketDataSnapshot{}, err
+	}
+	if snapshot.Symbol, err = msg.Body.GetString(tag.Symbol); err != nil {
+		return MarketDataSnapshot{}, err
+	}
+	if msg.Body.Has(tagLastBookUpdateID) {
+		lastBookUpdateID, err := msg.Body.GetInt(tagLastBookUpdateID)
+		if err != nil {
+			return MarketDataSnapshot{}, err
+		}
+		snapshot.LastBookUpdateID = int64(lastBookUpdateID)
+	}
+	if !msg.Body.Has(tag.NoMDEntries) {
+		return snapshot, nil
+	}
+
+	entries := quickfix.NewRepeatingGroup(tag.NoMDEntries, quickfix.GroupTemplate{
+		quickfix.GroupElement(tag.MDEntryType),
+		quickfix.GroupElement(tag.MDEntryPx),
+		quickfix.GroupElement(tag.MDEntrySize),
+		quickfix.GroupElement(tag.TransactTime),
+		quickfix.GroupElement(tag.TradeID),
+	})
+	if err := msg.Body.GetGroup(entries); err != nil {
+		return MarketDataSnapshot{}, err
+	}
+
+	for i := range entries.Len() {
+		entry := entries.Get(i)
+
+		var (
+			entryType field.MDEntryTypeField
+			px        field.MDEntryPxField
+			size      field.MDEntrySizeField
+		)
+		if err := entry.Get(&entryType); err != nil {
+			return MarketDataSnapshot{}, err
+		}
+		if entry.Has(tag.MDEntryPx) {
+			if err := entry.Get(&px); err != nil {
+				return MarketDataSnapshot{}, err
+			}
+		}
+		if entry.Has(tag.MDEntrySize) {
+			if err := entry.Get(&size); err != nil {
+				return MarketDataSnapshot{}, err
+			}
+		}
+		price, quantity := px.InexactFloat64(), size.InexactFloat64()
+
+		switch entryType.Value() {
+		case enum.MDEntryType_BID:
+			snapshot.Bids = append(snapshot.Bids, BookLevel{Price: price, Quantity: quantity})
+		case enum.MDEntryType_OFFER:
+			snapshot.Asks = append(snapshot.Asks, BookLevel{Price: price, Quantity: quantity})
+		case enum.MDEntryType_TRADE:
+			trade := handlers.Trade{Symbol: snapshot.Symbol, Price: price, Quantity: quantity, Raw: msg}
+			if entry.Has(tag.TradeID) {
+				tradeID, err := entry.GetInt(tag.TradeID)
+				if err != nil {
+					return MarketDataSnapshot{}, err
+				}
+				trade.TradeID = int64(tradeID)
+			}
+			if entry.Has(tag.TransactTime) {
+				var transactTime field.TransactTimeField
+				if err := entry.Get(&transactTime); err != nil {
+					return MarketDataSnapshot{}, err
+				}
+				trade.TradeTime = transactTime.Value()
+			}
+			snapshot.Trades = append(snapshot.Trades, trade)
+		}
+	}
+
+	return snapshot, nil
+}
+
+func decodeMarketDataReject(msg *quickfix.Message, now time.Time) error {
+	reject := &MarketDataReject{}
+	reject.MDReqID, _ = msg.Body.GetString(tag.MDReqID)
+	reject.Reason, _ = msg.Body.GetString(tag.MDReqRejReason)
+	reject.ErrorCode, _ = msg.Body.GetString(tagErrorCode)
+	reject.Text, _ = msg.Body.GetString(tag.Text)
+	reject.RetryAfter = handlers.ParseRetryAfter(reject.ErrorCode, reject.Text, now)
+	return reject
+}
@@ -0,0 +1,128 @@
+package fix
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// TradeSubscription is a trade subscription whose symbols can be changed
+// with AddSymbolsToSubscription and RemoveSymbols. Every symbol has its own
+// MarketDataRequest, so a change only touches the symbols added or removed
+// and the streams of the others go on uninterrupted.
+type TradeSubscription struct {
+	mu        sync.Mutex
+	mdReqID   map[string]string // by symbol
+	filters   []TradeFilter
+	listeners []TradeStreamHandler
+}
+
+// Symbols returns the subscribed symbols, sorted.
+func (s *TradeSubscription) Symbols() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	symbols := make([]string, 0, len(s.mdReqID))
+	for symbol := range s.mdReqID {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+// NewTradeSubscription subscribes to the trades of symbols and returns a
+// handle to change them later, and to listen to them with filters.
+func (c *Client) NewTradeSubscription(ctx context.Context, symbols []string) (*TradeSubscription, error) {
+	sub := &TradeSubscription{mdReqID: make(map[string]string)}
+	if err := c.AddSymbolsToSubscription(ctx, sub, symbols); err != nil {
+		return nil, err
+	}
+	c.emitter.On(TradeStreamTopic, sub.deliver)
+	return sub, nil
+}
+
+// AddSymbolsToSubscription subscribes sub to the trades of the symbols it
+// doesn't have yet.
+func (c *Client) AddSymbolsToSubscription(ctx context.Context, sub *TradeSubscription, symbols []string) error {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+
+	for _, symbol := range symbols {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if _, ok := sub.mdReqID[symbol]; ok {
+			continue
+		}
+
+		added, err := c.tradeSymbols.addWithin([]string{symbol}, c.options.mdSymbolsPerSession)
+		if err != nil {
+			return err
+		}
+		mdReqID := c.mdReqID("MDR_%s_%d", symbol, c.now().UnixNano())
+		msg := tradeRequest(mdReqID, enum.SubscriptionRequestType_SNAPSHOT_PLUS_UPDATES, symbol)
+		if err := c.SendWithoutResponse(msg); err != nil {
+			c.tradeSymbols.remove(added)
+			return err
+		}
+		sub.mdReqID[symbol] = mdReqID
+		c.mdStreams.add(mdStream{mdReqID: mdReqID, symbol: symbol, trades: true, request: func() *quickfix.Message {
+			return tradeRequest(mdReqID, enum.SubscriptionRequestType_SNAPSHOT_PLUS_UPDATES, symbol)
+		}})
+	}
+	return nil
+}
+
+// RemoveSymbols unsubscribes sub from the trades of symbols.
+func (c *Client) RemoveSymbols(ctx context.Context, sub *TradeSubscription, symbols []string) error {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+
+	for _, symbol := range symbols {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		mdReqID, ok := sub.mdReqID[symbol]
+		if !ok {
+			continue
+		}
+
+		msg := tradeRequest(mdReqID, enum.SubscriptionRequestType_DISABLE_PREVIOUS_SNAPSHOT_PLUS_UPDATE_REQUEST, symbol)
+		if err := c.SendWithoutResponse(msg); err != nil {
+			return err
+		}
+		delete(sub.mdReqID, symbol)
+		c.mdStreams.remove(mdReqID)
+		c.tradeSymbols.remove([]string{symbol})
+		c.aggTrades.disable([]string{symbol})
+	}
+	return nil
+}
+
+// tradeRequest builds a MarketDataRequest for the trades of symbols.
+func tradeRequest(mdReqID string, subscriptionType enum.SubscriptionRequestType, symbols ...string) *quickfix.Message {
+	msg := quickfix.NewMessage()
+	msg.Header.Set(field.NewMsgType(enum.MsgType_MARKET_DATA_REQUEST))
+	msg.Body.Set(field.NewMDReqID(mdReqID))
+	msg.Body.Set(field.NewSubscriptionRequestType(subscriptionType))
+	msg.Body.Set(field.NewMarketDepth(1))
+
+	related := quickfix.NewRepeatingGroup(tag.NoRelatedSym,
+		quickfix.GroupTemplate{quickfix.GroupElement(tag.Symbol)})
+	for _, symbol := range symbols {
+		related.Add().Set(field.NewSymbol(symbol))
+	}
+	msg.Body.SetGroup(related)
+
+	entryTypes := quickfix.NewRepeatingGroup(tag.NoMDEntryTypes,
+		quickfix.GroupTemplate{quickfix.GroupElement(tag.MDEntryType)})
+	entryTypes.Add().Set(field.NewMDEntryType(enum.MDEntryType_TRADE))
+	msg.Body.SetGroup(entryTypes)
+
+	return msg
+}
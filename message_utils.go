@@ -7,6 +7,8 @@ import (
 	"strconv"
 
 	"github.com/quickfixgo/quickfix"
+
+	"github.com/ljm2ya/binance_fix_api/handlers"
 )
 
 var (
@@ -19,6 +21,8 @@ type call struct {
 	request  *quickfix.Message
 	response *quickfix.Message
 	done     chan error
+	timings  *handlers.Timings // recorded if not nil
+	fallback []correlationKey  // registered in correlations
 }
 
 // waiter wraps a call for waiting on response
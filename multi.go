@@ -0,0 +1,144 @@
+package fix
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/chuckpreslar/emission"
+
+	"github.com/ljm2ya/binance_fix_api/handlers"
+)
+
+// MultiClient runs a session per account in one process, e.g. for a prop
+// desk trading several sub-accounts. Each account has its own credentials and
+// session; their events are merged into the MultiClient's listeners, tagged
+// with the label of the account.
+type MultiClient struct {
+	clients  map[string]*Client
+	accounts []string // sorted labels
+	emitter  *emission.Emitter
+}
+
+type AccountExecutionReportHandler func(account string, o *handlers.Order)
+
+type AccountListStatusHandler func(account string, l *handlers.ListStatus)
+
+type AccountCancelRejectHandler func(account string, r *handlers.CancelReject)
+
+type AccountStateChangeHandler func(account string, e *StateChange)
+
+// NewMultiClient creates a client per account label. The options apply to
+// every session. Configs without Settings connect to the default Binance
+// endpoint of their Endpoint, with a random SenderCompID each.
+func NewMultiClient(accounts map[string]Config, opts ...NewClientOption) (*MultiClient, error) {
+	m := &MultiClient{
+		clients: make(map[string]*Client, len(accounts)),
+		emitter: emission.NewEmitter(),
+	}
+	for account := range accounts {
+		m.accounts = append(m.accounts, account)
+	}
+	sort.Strings(m.accounts)
+
+	for _, account := range m.accounts {
+		c, err := NewClient(accounts[account], opts...)
+		if err != nil {
+			for _, created := range m.clients {
+				created.closeRelay()
+			}
+			return nil, fmt.Errorf("account %s: %w", account, err)
+		}
+		m.clients[account] = c
+		m.forward(account, c)
+	}
+	return m, nil
+}
+
+// forward tags the events of the client of account for the MultiClient's
+// listeners.
+func (m *MultiClient) forward(account string, c *Client) {
+	c.SubscribeToExecutionReport(func(o *handlers.Order) {
+		m.emitter.Emit(ExecutionReportTopic, account, o)
+	})
+	c.SubscribeToListStatus(func(l *handlers.ListStatus) {
+		m.emitter.Emit(ListStatusTopic, account, l)
+	})
+	c.SubscribeToCancelReject(func(r *handlers.CancelReject) {
+		m.emitter.Emit(CancelRejectTopic, account, r)
+	})
+	c.SubscribeToStateChange(func(e *StateChange) {
+		m.emitter.Emit(StateChangeTopic, account, e)
+	})
+}
+
+// Start logs on every account, in the order of their labels. If one fails,
+// the accounts already logged on are stopped again.
+func (m *MultiClient) Start(ctx context.Context) error {
+	for i, account := range m.accounts {
+		if err := m.clients[account].Start(ctx); err != nil {
+			for _, started := range m.accounts[:i+1] {
+				m.clients[started].Stop()
+			}
+			return fmt.Errorf("account %s: %w", account, err)
+		}
+	}
+	return nil
+}
+
+// Stop closes every session.
+func (m *MultiClient) Stop() {
+	for _, account := range m.accounts {
+		m.clients[account].Stop()
+	}
+}
+
+// Accounts returns the account labels, sorted.
+func (m *MultiClient) Accounts() []string {
+	return append([]string(nil), m.accounts...)
+}
+
+// ForAccount returns the client of account, nil for an unknown label, to
+// place orders and query the account:
+//
+//	m.ForAccount("A").NewOrderSingleService().Symbol("BTCUSDT")...Do(ctx)
+func (m *MultiClient) ForAccount(account string) *Client {
+	return m.clients[account]
+}
+
+// IsConnected reports whether every account is logged on.
+func (m *MultiClient) IsConnected() bool {
+	for _, c := range m.clients {
+		if !c.IsConnected() {
+			return false
+		}
+	}
+	return true
+}
+
+// Health returns the HealthReport of every account by label.
+func (m *MultiClient) Health() map[string]HealthReport {
+	reports := make(map[string]HealthReport, len(m.clients))
+	for account, c := range m.clients {
+		reports[account] = c.Health()
+	}
+	return reports
+}
+
+func (m *MultiClient) SubscribeToExecutionReport(listener AccountExecutionReportHandler) {
+	m.emitter.On(ExecutionReportTopic, listener)
+}
+
+func (m *MultiClient) SubscribeToListStatus(listener AccountListStatusHandler) {
+	m.emitter.On(ListStatusTopic, listener)
+}
+
+func (m *MultiClient) SubscribeToCancelReject(listener AccountCancelRejectHandler) {
+	m.emitter.On(CancelRejectTopic, listener)
+}
+
+// SubscribeToStateChange notifies about the session state transitions of
+// every account.
+func (m *MultiClient) SubscribeToStateChange(listener AccountStateChangeHandler) {
+	m.emitter.On(StateChangeTopic, listener)
+}
@@ -3,13 +3,12 @@ package fix
 import (
 	"context"
 
-	"github.com/google/uuid"
 	"github.com/quickfixgo/enum"
 	"github.com/quickfixgo/field"
 	"github.com/quickfixgo/quickfix"
 	"github.com/quickfixgo/tag"
-	"go.uber.org/zap"
-	
+
+	"github.com/ljm2ya/binance_fix_api/fixerr"
 	"github.com/ljm2ya/binance_fix_api/handlers"
 )
 
@@ -37,15 +36,20 @@ Tag     Name                    Type    Required    Description
 25032   SOR                     BOOLEAN N           Whether to activate SOR for this order.
 */
 
-// NewOrderSingleService uses uuid to generate unique ClOrdID.
+// NewOrderSingleService uses the client's ClOrdIDGenerator unless ClOrdID is set.
 type NewOrderSingleService struct {
 	c           *Client
+	clOrdID     string
 	symbol      string
 	side        enum.Side
 	orderType   enum.OrdType
 	timeInForce *enum.TimeInForce
 	quantity    *float64
 	price       *float64
+	stopPrice   *float64
+	triggerDir  *enum.TriggerPriceDirection
+	maxFloor    *float64
+	postOnly    bool
 }
 
 func (c *Client) NewOrderSingleService() *NewOrderSingleService {
@@ -54,6 +58,12 @@ func (c *Client) NewOrderSingleService() *NewOrderSingleService {
 	}
 }
 
+// ClOrdID set clOrdID
+func (s *NewOrderSingleService) ClOrdID(clOrdID string) *NewOrderSingleService {
+	s.clOrdID = clOrdID
+	return s
+}
+
 // Symbol set symbol
 func (s *NewOrderSingleService) Symbol(symbol string) *NewOrderSingleService {
 	s.symbol = symbol
@@ -90,16 +100,132 @@ func (s *NewOrderSingleService) Price(price float64) *NewOrderSingleService {
 	return s
 }
 
-func (s *NewOrderSingleService) Do(ctx context.Context) (handlers.Order, error) {
-	id, err := uuid.NewRandom()
+// StopPrice set the trigger price of STOP and STOP_LIMIT orders
+func (s *NewOrderSingleService) StopPrice(stopPrice float64) *NewOrderSingleService {
+	s.stopPrice = &stopPrice
+	return s
+}
+
+// TriggerPriceDirection set whether a STOP or STOP_LIMIT order triggers when
+// the price goes up (U) or down (D) through the stop price. It defaults to
+// the direction of a stop loss: up for BUY and down for SELL orders. Set the
+// opposite one for a take profit.
+func (s *NewOrderSingleService) TriggerPriceDirection(direction enum.TriggerPriceDirection) *NewOrderSingleService {
+	s.triggerDir = &direction
+	return s
+}
+
+// triggerPriceDirection returns the direction set with TriggerPriceDirection
+// or the one of a stop loss on the order's side.
+func (s *NewOrderSingleService) triggerPriceDirection() enum.TriggerPriceDirection {
+	switch {
+	case s.triggerDir != nil:
+		return *s.triggerDir
+	case s.side == enum.Side_BUY:
+		return triggerPriceUp
+	default:
+		return triggerPriceDown
+	}
+}
+
+// IcebergQuantity set the visible quantity of an iceberg order
+func (s *NewOrderSingleService) IcebergQuantity(quantity float64) *NewOrderSingleService {
+	s.maxFloor = &quantity
+	return s
+}
+
+// PostOnly makes a LIMIT order maker-only: it is rejected instead of taking
+// liquidity (ExecInst PARTICIPATE_DONT_INITIATE)
+func (s *NewOrderSingleService) PostOnly() *NewOrderSingleService {
+	s.postOnly = true
+	return s
+}
+
+// Message validates the order and builds its NewOrderSingle <D> without
+// sending it, e.g. to send it with Call or keep it for later. The ClOrdID
+// must be set.
+func (s *NewOrderSingleService) Message() (*quickfix.Message, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	if s.clOrdID == "" {
+		return nil, invalidOrder("ClOrdID is required")
+	}
+	return s.build(s.clOrdID), nil
+}
+
+func (s *NewOrderSingleService) Do(ctx context.Context) (order handlers.Order, err error) {
+	ctx, span := s.c.startSpan(ctx, "fix.NewOrderSingle", attrSymbol.String(s.symbol))
+	defer func() { endSpan(span, err) }()
+	start, id := s.c.now(), s.clOrdID
+	defer func() { err = fixerr.Wrap(err, id, string(enum.MsgType_ORDER_SINGLE), s.c.since(start)) }()
+
+	if err := s.Validate(); err != nil {
+		return handlers.Order{}, err
+	}
+	if s.c.maintenanceQuiesced() {
+		return handlers.Order{}, ErrMaintenance
+	}
+	if s.c.breaker != nil && !s.c.breaker.allow(s.c.now()) {
+		return handlers.Order{}, ErrCircuitOpen
+	}
+
+	id, err = s.c.resolveClOrdID(s.clOrdID)
 	if err != nil {
 		return handlers.Order{}, err
 	}
+	span.SetAttributes(attrClOrdID.String(id))
+
+	if v := s.c.options.instruments; v != nil {
+		var price, quantity float64
+		if s.price != nil {
+			price = *s.price
+		}
+		if s.quantity != nil {
+			quantity = *s.quantity
+		}
+		if err := v.ValidateOrder(s.symbol, price, quantity); err != nil {
+			return handlers.Order{}, err
+		}
+	}
+
+	release, err := s.c.acquireSymbol(ctx, s.symbol)
+	if err != nil {
+		return handlers.Order{}, err
+	}
+	defer release()
+
+	if order, landed, err := s.c.confirmPlacement(ctx, id, s.symbol, s.side); err != nil || landed {
+		return order, err
+	}
+
+	timings := handlers.Timings{Built: s.c.now()}
+	_, buildSpan := s.c.startSpan(ctx, "fix.build")
+	msg := s.build(id)
+	buildSpan.End()
 
+	if s.c.unconfirmed != nil {
+		s.c.unconfirmed.add(id)
+	}
+	order, err = callAndDecodeTimed(ctx, s.c, id, msg, s.c.decodeOrderResponse, &timings)
+	if s.c.unconfirmed != nil {
+		s.c.unconfirmed.settle(id, err)
+	}
+	if err != nil {
+		s.c.logger().Errorw("Failed to create new order", "request", msg, "err", err)
+		return handlers.Order{}, err
+	}
+
+	order.Timings = timings
+	return order, nil
+}
+
+// build builds the NewOrderSingle <D> of the order under ClOrdID id.
+func (s *NewOrderSingleService) build(id string) *quickfix.Message {
 	msg := quickfix.NewMessage()
 	msg.Header.Set(field.NewMsgType(enum.MsgType_ORDER_SINGLE))
 
-	msg.Body.Set(field.NewClOrdID(id.String()))
+	msg.Body.Set(field.NewClOrdID(id))
 	msg.Body.Set(field.NewSymbol(s.symbol))
 	msg.Body.Set(field.NewSide(s.side))
 	msg.Body.Set(field.NewOrdType(s.orderType))
@@ -112,18 +238,18 @@ func (s *NewOrderSingleService) Do(ctx context.Context) (handlers.Order, error)
 	if s.timeInForce != nil {
 		msg.Body.Set(field.NewTimeInForce(*s.timeInForce))
 	}
-
-	resp, err := s.c.Call(ctx, id.String(), msg)
-	if err != nil {
-		zap.S().Errorw("Failed to create new order", "request", msg, "err", err)
-		return handlers.Order{}, err
+	if s.stopPrice != nil {
+		msg.Body.Set(field.NewTriggerType(enum.TriggerType_PRICE_MOVEMENT))
+		msg.Body.Set(field.NewTriggerAction(enum.TriggerAction_ACTIVATE))
+		msg.Body.SetString(tag.TriggerPrice, floatToString(*s.stopPrice))
+		msg.Body.Set(field.NewTriggerPriceType(enum.TriggerPriceType_LAST_TRADE))
+		msg.Body.Set(field.NewTriggerPriceDirection(s.triggerPriceDirection()))
 	}
-
-	order, err := handlers.DecodeExecutionReport(resp)
-	if err != nil {
-		zap.S().Errorw("Failed to decode ExecutionReport message", "request", msg, "response", resp, "error", err)
-		return handlers.Order{}, err
+	if s.maxFloor != nil {
+		msg.Body.SetString(tag.MaxFloor, floatToString(*s.maxFloor))
 	}
-
-	return order, nil
+	if s.postOnly {
+		msg.Body.Set(field.NewExecInst(enum.ExecInst_PARTICIPANT_DONT_INITIATE))
+	}
+	return msg
 }
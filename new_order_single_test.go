@@ -0,0 +1,54 @@
+package fix
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/tag"
+)
+
+func TestNewOrderSingleTriggerPriceDirection(t *testing.T) {
+	stopOrder := func(side enum.Side) *NewOrderSingleService {
+		return (&NewOrderSingleService{}).Symbol("BTCUSDT").Side(side).
+			Type(enum.OrdType_STOP).Quantity(1).StopPrice(100)
+	}
+
+	for _, tc := range []struct {
+		name  string
+		order *NewOrderSingleService
+		want  enum.TriggerPriceDirection
+	}{
+		{name: "buy stop", order: stopOrder(enum.Side_BUY), want: triggerPriceUp},
+		{name: "sell stop", order: stopOrder(enum.Side_SELL), want: triggerPriceDown},
+		{name: "sell stop limit", order: stopOrder(enum.Side_SELL).Type(enum.OrdType_STOP_LIMIT).
+			Price(99).TimeInForce(enum.TimeInForce_GOOD_TILL_CANCEL), want: triggerPriceDown},
+		{name: "buy take profit", order: stopOrder(enum.Side_BUY).TriggerPriceDirection(triggerPriceDown), want: triggerPriceDown},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.order.Validate(); err != nil {
+				t.Fatalf("Validate = %v", err)
+			}
+			msg := tc.order.build("order-1")
+			got, err := msg.Body.GetString(tag.TriggerPriceDirection)
+			if err != nil {
+				t.Fatalf("no TriggerPriceDirection: %v", err)
+			}
+			if enum.TriggerPriceDirection(got) != tc.want {
+				t.Errorf("TriggerPriceDirection = %s, want %s", got, tc.want)
+			}
+		})
+	}
+
+	limit := (&NewOrderSingleService{}).Symbol("BTCUSDT").Side(enum.Side_BUY).Type(enum.OrdType_LIMIT).
+		Quantity(1).Price(100).TimeInForce(enum.TimeInForce_GOOD_TILL_CANCEL)
+	if msg := limit.build("order-2"); msg.Body.Has(tag.TriggerPriceDirection) {
+		t.Error("LIMIT order has a TriggerPriceDirection")
+	}
+	if err := limit.TriggerPriceDirection(triggerPriceUp).Validate(); !errors.Is(err, ErrInvalidOrder) {
+		t.Errorf("Validate of a LIMIT order with a trigger direction = %v", err)
+	}
+	if err := stopOrder(enum.Side_BUY).TriggerPriceDirection("X").Validate(); !errors.Is(err, ErrInvalidOrder) {
+		t.Errorf("Validate with trigger direction X = %v", err)
+	}
+}
@@ -0,0 +1,166 @@
+// Package order builds new orders with a fluent API that reads like the order:
+//
+//	req, err := order.New("BTCUSDT").Sell().Limit(50000).Qty(0.01).PostOnly().Build()
+//	placed, err := req.Apply(client.NewOrderSingleService()).Do(ctx)
+//
+// Build checks the order against the same rules as
+// NewOrderSingleService.Validate and returns it as a Request, which can also
+// be turned into a NewOrderSingle <D> message with Message.
+package order
+
+import (
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+
+	fix "github.com/ljm2ya/binance_fix_api"
+)
+
+// Request is a validated new order. Zero fields are left out of the order.
+type Request struct {
+	ClOrdID     string // assigned by the client's ClOrdIDGenerator when empty
+	Symbol      string
+	Side        enum.Side
+	Type        enum.OrdType
+	TimeInForce enum.TimeInForce
+	Quantity    float64
+	Price       float64
+	StopPrice   float64
+	IcebergQty  float64 // visible quantity of an iceberg order
+	PostOnly    bool
+}
+
+// Builder builds a Request. Its methods may be called in any order; the
+// last call setting a field wins.
+type Builder struct {
+	req Request
+}
+
+// New starts building an order of symbol.
+func New(symbol string) *Builder {
+	return &Builder{req: Request{Symbol: symbol}}
+}
+
+// ClOrdID sets the ClOrdID of the order.
+func (b *Builder) ClOrdID(clOrdID string) *Builder {
+	b.req.ClOrdID = clOrdID
+	return b
+}
+
+// Buy makes it a buy order.
+func (b *Builder) Buy() *Builder {
+	b.req.Side = enum.Side_BUY
+	return b
+}
+
+// Sell makes it a sell order.
+func (b *Builder) Sell() *Builder {
+	b.req.Side = enum.Side_SELL
+	return b
+}
+
+// Market makes it a MARKET order.
+func (b *Builder) Market() *Builder {
+	b.req.Type, b.req.Price, b.req.StopPrice = enum.OrdType_MARKET, 0, 0
+	return b
+}
+
+// Limit makes it a LIMIT order at price.
+func (b *Builder) Limit(price float64) *Builder {
+	b.req.Type, b.req.Price, b.req.StopPrice = enum.OrdType_LIMIT, price, 0
+	return b
+}
+
+// Stop makes it a STOP order triggered at stopPrice.
+func (b *Builder) Stop(stopPrice float64) *Builder {
+	b.req.Type, b.req.Price, b.req.StopPrice = enum.OrdType_STOP, 0, stopPrice
+	return b
+}
+
+// StopLimit makes it a STOP_LIMIT order at price triggered at stopPrice.
+func (b *Builder) StopLimit(stopPrice, price float64) *Builder {
+	b.req.Type, b.req.Price, b.req.StopPrice = enum.OrdType_STOP_LIMIT, price, stopPrice
+	return b
+}
+
+// Qty sets the quantity of the order.
+func (b *Builder) Qty(quantity float64) *Builder {
+	b.req.Quantity = quantity
+	return b
+}
+
+// Iceberg shows only visible of the quantity on the book.
+func (b *Builder) Iceberg(visible float64) *Builder {
+	b.req.IcebergQty = visible
+	return b
+}
+
+// PostOnly makes a LIMIT order maker-only.
+func (b *Builder) PostOnly() *Builder {
+	b.req.PostOnly = true
+	return b
+}
+
+// GTC makes the order GOOD_TILL_CANCEL, the default of limit orders.
+func (b *Builder) GTC() *Builder {
+	b.req.TimeInForce = enum.TimeInForce_GOOD_TILL_CANCEL
+	return b
+}
+
+// IOC makes the order IMMEDIATE_OR_CANCEL.
+func (b *Builder) IOC() *Builder {
+	b.req.TimeInForce = enum.TimeInForce_IMMEDIATE_OR_CANCEL
+	return b
+}
+
+// FOK makes the order FILL_OR_KILL.
+func (b *Builder) FOK() *Builder {
+	b.req.TimeInForce = enum.TimeInForce_FILL_OR_KILL
+	return b
+}
+
+// Build validates the order and returns it. Limit orders without a time in
+// force are GOOD_TILL_CANCEL. Errors wrap fix.ErrInvalidOrder.
+func (b *Builder) Build() (Request, error) {
+	req := b.req
+	limit := req.Type == enum.OrdType_LIMIT || req.Type == enum.OrdType_STOP_LIMIT
+	if limit && req.TimeInForce == "" {
+		req.TimeInForce = enum.TimeInForce_GOOD_TILL_CANCEL
+	}
+	if err := req.Apply(&fix.NewOrderSingleService{}).Validate(); err != nil {
+		return Request{}, err
+	}
+	return req, nil
+}
+
+// Apply sets the fields of r on s and returns s, e.g.
+// r.Apply(client.NewOrderSingleService()).Do(ctx).
+func (r Request) Apply(s *fix.NewOrderSingleService) *fix.NewOrderSingleService {
+	s.Symbol(r.Symbol).Side(r.Side).Type(r.Type)
+	if r.ClOrdID != "" {
+		s.ClOrdID(r.ClOrdID)
+	}
+	if r.TimeInForce != "" {
+		s.TimeInForce(r.TimeInForce)
+	}
+	if r.Quantity != 0 {
+		s.Quantity(r.Quantity)
+	}
+	if r.Price != 0 {
+		s.Price(r.Price)
+	}
+	if r.StopPrice != 0 {
+		s.StopPrice(r.StopPrice)
+	}
+	if r.IcebergQty != 0 {
+		s.IcebergQuantity(r.IcebergQty)
+	}
+	if r.PostOnly {
+		s.PostOnly()
+	}
+	return s
+}
+
+// Message returns the NewOrderSingle <D> of r, which must have a ClOrdID.
+func (r Request) Message() (*quickfix.Message, error) {
+	return r.Apply(&fix.NewOrderSingleService{}).Message()
+}
@@ -0,0 +1,169 @@
+package fix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+
+	"github.com/ljm2ya/binance_fix_api/fixerr"
+	"github.com/ljm2ya/binance_fix_api/handlers"
+)
+
+/*
+Tag     Name                Type    Required    Description
+11      ClOrdID             STRING  Y           ClOrdID the amended order takes.
+41      OrigClOrdID         STRING  N           ClOrdID of the order to amend.
+37      OrderID             INT     N           OrderID of the order to amend.
+55      Symbol              STRING  Y           Symbol of the order to amend.
+38      OrderQty            QTY     Y           New quantity, below the current one.
+*/
+
+const (
+	msgType_ORDER_AMEND_KEEP_PRIORITY_REQUEST enum.MsgType = "XAK"
+	msgType_ORDER_AMEND_REJECT                enum.MsgType = "XAR"
+)
+
+// OrderAmendReject is returned for an OrderAmendReject <XAR>.
+type OrderAmendReject struct {
+	ClOrdID     string
+	OrigClOrdID string
+	OrderID     int64
+	Symbol      string
+	Quantity    float64 // OrderQty <38> asked for
+	ErrorCode   string  // ErrorCode <25016>
+	Text        string
+}
+
+func (r *OrderAmendReject) Error() string {
+	return fmt.Sprintf("amend of %s rejected: %s (code %s)", r.Symbol, r.Text, r.ErrorCode)
+}
+
+// OrderAmendService lowers the quantity of an order identified by
+// OrigClOrdID or OrderID while it keeps its priority in the book, with an
+// OrderAmendKeepPriorityRequest <XAK>. The order takes the ClOrdID of the
+// request. A rejected amend is returned as an *OrderAmendReject error.
+type OrderAmendService struct {
+	c           *Client
+	clOrdID     string
+	origClOrdID string
+	orderID     *int64
+	symbol      string
+	quantity    float64
+}
+
+func (c *Client) NewOrderAmendService() *OrderAmendService {
+	return &OrderAmendService{
+		c: c,
+	}
+}
+
+// ClOrdID set clOrdID the amended order takes
+func (s *OrderAmendService) ClOrdID(clOrdID string) *OrderAmendService {
+	s.clOrdID = clOrdID
+	return s
+}
+
+// OrigClOrdID set clOrdID of the order to amend
+func (s *OrderAmendService) OrigClOrdID(origClOrdID string) *OrderAmendService {
+	s.origClOrdID = origClOrdID
+	return s
+}
+
+// OrderID set orderID of the order to amend
+func (s *OrderAmendService) OrderID(orderID int64) *OrderAmendService {
+	s.orderID = &orderID
+	return s
+}
+
+// Symbol set symbol
+func (s *OrderAmendService) Symbol(symbol string) *OrderAmendService {
+	s.symbol = symbol
+	return s
+}
+
+// Quantity set the new quantity, which must be below the current one
+func (s *OrderAmendService) Quantity(quantity float64) *OrderAmendService {
+	s.quantity = quantity
+	return s
+}
+
+func (s *OrderAmendService) Do(ctx context.Context) (order handlers.Order, err error) {
+	ctx, span := s.c.startSpan(ctx, "fix.OrderAmend", attrSymbol.String(s.symbol))
+	defer func() { endSpan(span, err) }()
+	start, id := s.c.now(), s.clOrdID
+	defer func() {
+		err = fixerr.Wrap(err, id, string(msgType_ORDER_AMEND_KEEP_PRIORITY_REQUEST), s.c.since(start))
+	}()
+
+	if s.symbol == "" {
+		return handlers.Order{}, invalidOrder("symbol is required")
+	}
+	if s.origClOrdID == "" && s.orderID == nil {
+		return handlers.Order{}, invalidOrder("OrigClOrdID or OrderID is required")
+	}
+	if s.quantity <= 0 {
+		return handlers.Order{}, invalidOrder("positive quantity is required")
+	}
+
+	id, err = s.c.resolveClOrdID(s.clOrdID)
+	if err != nil {
+		return handlers.Order{}, err
+	}
+	span.SetAttributes(attrClOrdID.String(id))
+
+	release, err := s.c.acquireSymbol(ctx, s.symbol)
+	if err != nil {
+		return handlers.Order{}, err
+	}
+	defer release()
+
+	timings := handlers.Timings{Built: s.c.now()}
+	_, buildSpan := s.c.startSpan(ctx, "fix.build")
+	msg := quickfix.NewMessage()
+	msg.Header.Set(field.NewMsgType(msgType_ORDER_AMEND_KEEP_PRIORITY_REQUEST))
+
+	msg.Body.Set(field.NewClOrdID(id))
+	msg.Body.Set(field.NewSymbol(s.symbol))
+	if s.origClOrdID != "" {
+		msg.Body.Set(field.NewOrigClOrdID(s.origClOrdID))
+	}
+	if s.orderID != nil {
+		msg.Body.SetString(tag.OrderID, strconv.FormatInt(*s.orderID, 10))
+	}
+	msg.Body.SetString(tag.OrderQty, floatToString(s.quantity))
+	buildSpan.End()
+
+	order, err = callAndDecodeTimed(ctx, s.c, id, msg, s.c.decodeOrderResponse, &timings)
+	if err != nil {
+		var reject *OrderAmendReject
+		if !errors.As(err, &reject) {
+			s.c.logger().Errorw("Failed to amend order", "request", msg, "err", err)
+		}
+		return handlers.Order{}, err
+	}
+
+	order.Timings = timings
+	return order, nil
+}
+
+func decodeOrderAmendReject(msg *quickfix.Message) *OrderAmendReject {
+	reject := &OrderAmendReject{}
+	reject.ClOrdID, _ = msg.Body.GetString(tag.ClOrdID)
+	reject.OrigClOrdID, _ = msg.Body.GetString(tag.OrigClOrdID)
+	if orderID, err := msg.Body.GetString(tag.OrderID); err == nil {
+		reject.OrderID, _ = strconv.ParseInt(orderID, 10, 64)
+	}
+	reject.Symbol, _ = msg.Body.GetString(tag.Symbol)
+	if qty, err := msg.Body.GetString(tag.OrderQty); err == nil {
+		reject.Quantity, _ = strconv.ParseFloat(qty, 64)
+	}
+	reject.ErrorCode, _ = msg.Body.GetString(tagErrorCode)
+	reject.Text, _ = msg.Body.GetString(tag.Text)
+	return reject
+}
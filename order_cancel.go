@@ -0,0 +1,109 @@
+package fix
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+
+	"github.com/ljm2ya/binance_fix_api/fixerr"
+	"github.com/ljm2ya/binance_fix_api/handlers"
+)
+
+/*
+Tag     Name                Type    Required    Description
+11      ClOrdID             STRING  Y           ClOrdID of this cancel request.
+41      OrigClOrdID         STRING  N           ClOrdID of the order to cancel.
+37      OrderID             INT     N           OrderID of the order to cancel.
+55      Symbol              STRING  Y           Symbol of the order to cancel.
+*/
+
+// OrderCancelService cancels an order identified by OrigClOrdID or OrderID.
+// A rejected cancel is returned as a *handlers.CancelReject error.
+type OrderCancelService struct {
+	c           *Client
+	clOrdID     string
+	origClOrdID string
+	orderID     *int64
+	symbol      string
+}
+
+func (c *Client) NewOrderCancelService() *OrderCancelService {
+	return &OrderCancelService{
+		c: c,
+	}
+}
+
+// ClOrdID set clOrdID of the cancel request
+func (s *OrderCancelService) ClOrdID(clOrdID string) *OrderCancelService {
+	s.clOrdID = clOrdID
+	return s
+}
+
+// OrigClOrdID set clOrdID of the order to cancel
+func (s *OrderCancelService) OrigClOrdID(origClOrdID string) *OrderCancelService {
+	s.origClOrdID = origClOrdID
+	return s
+}
+
+// OrderID set orderID of the order to cancel
+func (s *OrderCancelService) OrderID(orderID int64) *OrderCancelService {
+	s.orderID = &orderID
+	return s
+}
+
+// Symbol set symbol
+func (s *OrderCancelService) Symbol(symbol string) *OrderCancelService {
+	s.symbol = symbol
+	return s
+}
+
+func (s *OrderCancelService) Do(ctx context.Context) (order handlers.Order, err error) {
+	ctx, span := s.c.startSpan(ctx, "fix.OrderCancel", attrSymbol.String(s.symbol))
+	defer func() { endSpan(span, err) }()
+	start, id := s.c.now(), s.clOrdID
+	defer func() { err = fixerr.Wrap(err, id, string(enum.MsgType_ORDER_CANCEL_REQUEST), s.c.since(start)) }()
+
+	id, err = s.c.resolveClOrdID(s.clOrdID)
+	if err != nil {
+		return handlers.Order{}, err
+	}
+	span.SetAttributes(attrClOrdID.String(id))
+
+	release, err := s.c.acquireSymbol(ctx, s.symbol)
+	if err != nil {
+		return handlers.Order{}, err
+	}
+	defer release()
+
+	timings := handlers.Timings{Built: s.c.now()}
+	_, buildSpan := s.c.startSpan(ctx, "fix.build")
+	msg := quickfix.NewMessage()
+	msg.Header.Set(field.NewMsgType(enum.MsgType_ORDER_CANCEL_REQUEST))
+
+	msg.Body.Set(field.NewClOrdID(id))
+	msg.Body.Set(field.NewSymbol(s.symbol))
+	if s.origClOrdID != "" {
+		msg.Body.Set(field.NewOrigClOrdID(s.origClOrdID))
+	}
+	if s.orderID != nil {
+		msg.Body.SetString(tag.OrderID, strconv.FormatInt(*s.orderID, 10))
+	}
+	buildSpan.End()
+
+	order, err = callAndDecodeTimed(ctx, s.c, id, msg, s.c.decodeOrderResponse, &timings)
+	if err != nil {
+		var reject *handlers.CancelReject
+		if !errors.As(err, &reject) {
+			s.c.logger().Errorw("Failed to cancel order", "request", msg, "err", err)
+		}
+		return handlers.Order{}, err
+	}
+
+	order.Timings = timings
+	return order, nil
+}
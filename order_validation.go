@@ -0,0 +1,98 @@
+package fix
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/quickfixgo/enum"
+)
+
+var ErrInvalidOrder = errors.New("invalid order")
+
+// The TriggerPriceDirection <1109> values Binance accepts.
+const (
+	triggerPriceUp   = enum.TriggerPriceDirection_TRIGGER_IF_THE_PRICE_OF_THE_SPECIFIED_TYPE_GOES_UP_TO_OR_THROUGH_THE_SPECIFIED_TRIGGER_PRICE
+	triggerPriceDown = enum.TriggerPriceDirection_TRIGGER_IF_THE_PRICE_OF_THE_SPECIFIED_TYPE_GOES_DOWN_TO_OR_THROUGH_THE_SPECIFIED_TRIGGER_PRICE
+)
+
+func invalidOrder(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidOrder, fmt.Sprintf(format, args...))
+}
+
+// Validate checks the order against Binance's rules for its type before it is
+// sent, so mistakes fail immediately instead of as a server side reject.
+// Do calls it automatically.
+func (s *NewOrderSingleService) Validate() error {
+	if s.symbol == "" {
+		return invalidOrder("symbol is required")
+	}
+	if _, ok := mappedSideType[s.side]; !ok {
+		return invalidOrder("unsupported side %q", s.side)
+	}
+	orderType, ok := mappedOrderType[s.orderType]
+	if !ok {
+		return invalidOrder("unsupported order type %q", s.orderType)
+	}
+	if s.quantity == nil || *s.quantity <= 0 {
+		return invalidOrder("positive quantity is required")
+	}
+
+	limit := s.orderType == enum.OrdType_LIMIT || s.orderType == enum.OrdType_STOP_LIMIT
+	stop := s.orderType == enum.OrdType_STOP || s.orderType == enum.OrdType_STOP_LIMIT
+
+	if limit && (s.price == nil || *s.price <= 0) {
+		return invalidOrder("positive price is required for %s orders", orderType)
+	}
+	if !limit && s.price != nil {
+		return invalidOrder("price is not allowed for %s orders", orderType)
+	}
+
+	if stop && (s.stopPrice == nil || *s.stopPrice <= 0) {
+		return invalidOrder("positive stop price is required for %s orders", orderType)
+	}
+	if !stop && s.stopPrice != nil {
+		return invalidOrder("stop price is not allowed for %s orders", orderType)
+	}
+	if s.triggerDir != nil {
+		if !stop {
+			return invalidOrder("trigger price direction is not allowed for %s orders", orderType)
+		}
+		if *s.triggerDir != triggerPriceUp && *s.triggerDir != triggerPriceDown {
+			return invalidOrder("unsupported trigger price direction %q", *s.triggerDir)
+		}
+	}
+
+	if s.timeInForce != nil {
+		if _, ok := mappedTimeInForce[*s.timeInForce]; !ok {
+			return invalidOrder("unsupported time in force %q", *s.timeInForce)
+		}
+		if !limit {
+			return invalidOrder("time in force is not allowed for %s orders", orderType)
+		}
+	} else if limit {
+		return invalidOrder("time in force is required for %s orders", orderType)
+	}
+
+	if s.postOnly {
+		if s.orderType != enum.OrdType_LIMIT {
+			return invalidOrder("post-only is not allowed for %s orders", orderType)
+		}
+		if *s.timeInForce != enum.TimeInForce_GOOD_TILL_CANCEL {
+			return invalidOrder("post-only orders must be GOOD_TILL_CANCEL")
+		}
+	}
+
+	if s.maxFloor != nil {
+		if !limit {
+			return invalidOrder("iceberg quantity is not allowed for %s orders", orderType)
+		}
+		if *s.timeInForce != enum.TimeInForce_GOOD_TILL_CANCEL {
+			return invalidOrder("iceberg orders must be GOOD_TILL_CANCEL")
+		}
+		if *s.maxFloor <= 0 || *s.maxFloor >= *s.quantity {
+			return invalidOrder("iceberg quantity must be positive and below the order quantity")
+		}
+	}
+
+	return nil
+}
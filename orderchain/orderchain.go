@@ -0,0 +1,146 @@
+// Package orderchain follows the ClOrdID chains built by cancel/replace and
+// amend requests: each request carries a new ClOrdID and names the one it
+// replaces in OrigClOrdID. A Tracker maps every ClOrdID of such a logical
+// order to the chain it belongs to, so the order can be referred to by its
+// first ClOrdID while requests use the current one.
+//
+// Feed a Tracker with Client.SubscribeToExecutionReport(tracker.HandleExecutionReport),
+// and Link replacements when sending them to see them before their reports.
+package orderchain
+
+import (
+	"sync"
+
+	"github.com/ljm2ya/binance_fix_api/handlers"
+)
+
+// Tracker holds the ClOrdID chains of the orders seen.
+type Tracker struct {
+	mu      sync.RWMutex
+	origin  map[string]string   // ClOrdID -> first ClOrdID of its chain
+	chains  map[string][]string // first ClOrdID -> ClOrdIDs, oldest first
+	byOrder map[int64]string    // OrderID -> first ClOrdID of its chain
+}
+
+// New creates an empty Tracker.
+func New() *Tracker {
+	return &Tracker{
+		origin:  make(map[string]string),
+		chains:  make(map[string][]string),
+		byOrder: make(map[int64]string),
+	}
+}
+
+// HandleExecutionReport links the ClOrdID of a report to the chain of its
+// OrigClOrdID, or to the chain of its OrderID when an amendment kept the
+// OrderID. Rejected requests don't extend a chain. Its signature matches
+// fix.ExecutionReportHandler so it can be passed to
+// Client.SubscribeToExecutionReport directly.
+func (t *Tracker) HandleExecutionReport(o *handlers.Order) {
+	if o.ClientOrderID == "" || o.Status == handlers.OrderStatusRejected {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	orig := o.OrigClientOrderID
+	if orig == "" && o.OrderID != 0 {
+		if first, ok := t.byOrder[o.OrderID]; ok {
+			chain := t.chains[first]
+			orig = chain[len(chain)-1]
+		}
+	}
+	first := t.link(orig, o.ClientOrderID)
+	if o.OrderID != 0 {
+		t.byOrder[o.OrderID] = first
+	}
+}
+
+// Link records that clOrdID replaces origClOrdID, e.g. right after sending a
+// cancel/replace request. Linking a pair again is a no-op.
+func (t *Tracker) Link(origClOrdID, clOrdID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.link(origClOrdID, clOrdID)
+}
+
+// link appends clOrdID to the chain of origClOrdID, starting a chain when
+// origClOrdID is empty, and returns the first ClOrdID of the chain.
+func (t *Tracker) link(origClOrdID, clOrdID string) string {
+	if first, ok := t.origin[clOrdID]; ok {
+		return first
+	}
+
+	first := clOrdID
+	if origClOrdID != "" && origClOrdID != clOrdID {
+		var ok bool
+		if first, ok = t.origin[origClOrdID]; !ok {
+			first = origClOrdID
+			t.origin[first] = first
+			t.chains[first] = []string{first}
+		}
+	}
+	t.origin[clOrdID] = first
+	t.chains[first] = append(t.chains[first], clOrdID)
+	return first
+}
+
+// Current returns the latest ClOrdID of the chain clOrdID belongs to, or
+// clOrdID itself when it is unknown.
+func (t *Tracker) Current(clOrdID string) string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	first, ok := t.origin[clOrdID]
+	if !ok {
+		return clOrdID
+	}
+	chain := t.chains[first]
+	return chain[len(chain)-1]
+}
+
+// Origin returns the first ClOrdID of the chain clOrdID belongs to, the ID of
+// the logical order, or clOrdID itself when it is unknown.
+func (t *Tracker) Origin(clOrdID string) string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if first, ok := t.origin[clOrdID]; ok {
+		return first
+	}
+	return clOrdID
+}
+
+// Chain returns the ClOrdIDs of the chain clOrdID belongs to, oldest first,
+// or nil when it is unknown.
+func (t *Tracker) Chain(clOrdID string) []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	first, ok := t.origin[clOrdID]
+	if !ok {
+		return nil
+	}
+	return append([]string(nil), t.chains[first]...)
+}
+
+// Forget drops the chain clOrdID belongs to, e.g. once the order is done.
+func (t *Tracker) Forget(clOrdID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	first, ok := t.origin[clOrdID]
+	if !ok {
+		return
+	}
+	for _, id := range t.chains[first] {
+		delete(t.origin, id)
+	}
+	delete(t.chains, first)
+	for orderID, f := range t.byOrder {
+		if f == first {
+			delete(t.byOrder, orderID)
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package fix
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+var (
+	ErrOutboxFull      = errors.New("outbox is full")
+	errNotQueueable    = errors.New("message cannot be queued")
+	errMalformedOutbox = errors.New("malformed outbox message")
+)
+
+// outboxGroups are the repeating groups of queueable messages. Without a data
+// dictionary quickfix can't tell group members from body fields, so queued
+// messages are rebuilt with these templates.
+var outboxGroups = map[quickfix.Tag]quickfix.GroupTemplate{
+	tag.NoRelatedSym:   {quickfix.GroupElement(tag.Symbol)},
+	tag.NoMDEntryTypes: {quickfix.GroupElement(tag.MDEntryType)},
+}
+
+// orderMsgTypes are never queued: an order sent late is worse than one that
+// fails right away.
+var orderMsgTypes = map[enum.MsgType]bool{
+	enum.MsgType_ORDER_SINGLE:                 true,
+	enum.MsgType_ORDER_CANCEL_REQUEST:         true,
+	enum.MsgType_ORDER_CANCEL_REPLACE_REQUEST: true,
+	enum.MsgType_ORDER_MASS_CANCEL_REQUEST:    true,
+	enum.MsgType_ORDER_LIST:                   true,
+	msgType_ORDER_AMEND_KEEP_PRIORITY_REQUEST: true,
+}
+
+// WithOutbox queues messages sent with SendWithoutResponse while the session
+// is down, such as market data subscriptions, and sends them after the next
+// logon. The queue holds up to capacity messages, is persisted to path so it
+// survives restarts, and drops messages older than ttl. Orders are never
+// queued, and neither are messages with repeating groups other than those of
+// MarketDataRequest <V>.
+func WithOutbox(path string, capacity int, ttl time.Duration) NewClientOption {
+	return func(o *Options) {
+		o.outboxPath = path
+		o.outboxCapacity = capacity
+		o.outboxTTL = ttl
+	}
+}
+
+type outboxEntry struct {
+	Expires time.Time `json:"expires"`
+	Message string    `json:"message"`
+}
+
+// outbox is a bounded store-and-forward queue backed by a JSON-lines file.
+type outbox struct {
+	mu          sync.Mutex
+	beginString string
+	path        string
+	capacity    int
+	ttl         time.Duration
+	clock       Clock
+	entries     []outboxEntry
+}
+
+// openOutbox loads the entries left in path by a previous run.
+func openOutbox(beginString, path string, capacity int, ttl time.Duration, clock Clock) (*outbox, error) {
+	o := &outbox{beginString: beginString, path: path, capacity: capacity, ttl: ttl, clock: clock}
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return o, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e outboxEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, err
+		}
+		o.entries = append(o.entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+// push queues msg, failing with ErrOutboxFull when capacity is reached and
+// with errNotQueueable when msg has repeating groups outboxGroups doesn't know.
+func (o *outbox) push(msg *quickfix.Message) error {
+	// The session stamps the header at send time, BeginString is only needed
+	// to parse the message back.
+	msg.Header.Set(field.NewBeginString(o.beginString))
+	raw := msg.String()
+	if rebuilt, err := decodeOutboxMessage(raw); err != nil || rebuilt.String() != raw {
+		return errNotQueueable
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	now := o.clock.Now()
+	o.dropExpired(now)
+	if o.capacity > 0 && len(o.entries) >= o.capacity {
+		return ErrOutboxFull
+	}
+
+	o.entries = append(o.entries, outboxEntry{Expires: now.Add(o.ttl), Message: raw})
+	return o.persist()
+}
+
+// drain removes and returns the messages that haven't expired, oldest first.
+func (o *outbox) drain() ([]*quickfix.Message, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.dropExpired(o.clock.Now())
+	entries := o.entries
+	o.entries = nil
+	if err := o.persist(); err != nil {
+		return nil, err
+	}
+
+	msgs := make([]*quickfix.Message, 0, len(entries))
+	for _, e := range entries {
+		msg, err := decodeOutboxMessage(e.Message)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+// decodeOutboxMessage rebuilds a queued message, restoring the repeating
+// groups of outboxGroups.
+func decodeOutboxMessage(raw string) (*quickfix.Message, error) {
+	type tagValue struct {
+		tag   quickfix.Tag
+		value string
+	}
+	var fields []tagValue
+	for _, f := range strings.Split(strings.TrimSuffix(raw, "\x01"), "\x01") {
+		k, v, ok := strings.Cut(f, "=")
+		if !ok {
+			return nil, errMalformedOutbox
+		}
+		t, err := strconv.Atoi(k)
+		if err != nil {
+			return nil, errMalformedOutbox
+		}
+		fields = append(fields, tagValue{quickfix.Tag(t), v})
+	}
+
+	msg := quickfix.NewMessage()
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+		switch f.tag {
+		case tag.BodyLength, tag.CheckSum:
+		case tag.BeginString, tag.MsgType:
+			msg.Header.SetString(f.tag, f.value)
+		default:
+			template, ok := outboxGroups[f.tag]
+			if !ok {
+				msg.Body.SetString(f.tag, f.value)
+				continue
+			}
+			count, err := strconv.Atoi(f.value)
+			if err != nil {
+				return nil, errMalformedOutbox
+			}
+			group := quickfix.NewRepeatingGroup(f.tag, template)
+			for n := 0; n < count; n++ {
+				entry := group.Add()
+				for _, element := range template {
+					if i+1 >= len(fields) || fields[i+1].tag != element.Tag() {
+						break
+					}
+					i++
+					entry.SetString(fields[i].tag, fields[i].value)
+				}
+			}
+			msg.Body.SetGroup(group)
+		}
+	}
+	return msg, nil
+}
+
+func (o *outbox) dropExpired(now time.Time) {
+	live := o.entries[:0]
+	for _, e := range o.entries {
+		if o.ttl <= 0 || now.Before(e.Expires) {
+			live = append(live, e)
+		}
+	}
+	o.entries = live
+}
+
+// persist replaces the file with the current entries.
+func (o *outbox) persist() error {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	for _, e := range o.entries {
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(o.path), filepath.Base(o.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(b.Bytes()); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), o.path)
+}
+
+// queueable reports whether msg may wait in the outbox.
+func queueable(msg *quickfix.Message) bool {
+	msgType, err := msg.MsgType()
+	return err == nil && !orderMsgTypes[enum.MsgType(msgType)]
+}
+
+// flushOutbox sends the messages queued while the session was down.
+func (c *Client) flushOutbox() {
+	msgs, err := c.outbox.drain()
+	if err != nil {
+		c.logger().Errorw("Failed to read outbox", "path", c.outbox.path, "err", err)
+		return
+	}
+	for _, msg := range msgs {
+		if err := c.transmit(msg); err != nil {
+			c.logger().Errorw("Failed to send queued message", "msg", msg, "err", err)
+		}
+	}
+}
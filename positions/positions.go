@@ -0,0 +1,199 @@
+// Package positions nets the fills of execution reports per symbol into a
+// running position, its average entry price and the realized PnL.
+//
+// Positions are kept in the quote asset of each symbol and before fees. Feed a
+// Book with Client.SubscribeToExecutionReport(book.HandleExecutionReport); a
+// Snapshot taken on shutdown and restored on start carries the positions over
+// process restarts.
+package positions
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/ljm2ya/binance_fix_api/handlers"
+)
+
+// Position is the net position of a symbol.
+type Position struct {
+	Symbol      string
+	Quantity    float64 // positive long, negative short
+	AvgPrice    float64 // average entry price of Quantity, zero when flat
+	RealizedPnL float64 // in the quote asset, before fees
+}
+
+// UnrealizedPnL returns the PnL of the position if it were closed at price.
+func (p Position) UnrealizedPnL(price float64) float64 {
+	return p.Quantity * (price - p.AvgPrice)
+}
+
+// Snapshot is the state of a Book, see Book.Snapshot.
+type Snapshot struct {
+	Positions []Position
+	// Executed are the cumulative quantities of the orders still working,
+	// by ClOrdID, so their next reports only add what is new.
+	Executed map[string]Executed
+}
+
+// Executed are the cumulative quantities of an order seen so far.
+type Executed struct {
+	CumQty      float64
+	CumQuoteQty float64
+}
+
+// Book holds the positions of every symbol traded.
+type Book struct {
+	mu        sync.RWMutex
+	positions map[string]*Position
+	executed  map[string]Executed // by ClOrdID
+}
+
+// New creates an empty Book.
+func New() *Book {
+	return &Book{
+		positions: make(map[string]*Position),
+		executed:  make(map[string]Executed),
+	}
+}
+
+// HandleExecutionReport applies the fill contained in an execution report.
+// Its signature matches fix.ExecutionReportHandler so it can be passed to
+// Client.SubscribeToExecutionReport directly.
+func (b *Book) HandleExecutionReport(o *handlers.Order) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	prev := b.executed[o.ClientOrderID]
+	qty := o.CumQty - prev.CumQty
+	quoteQty := o.CumQuoteQty - prev.CumQuoteQty
+
+	if isTerminal(o.Status) {
+		delete(b.executed, o.ClientOrderID)
+	} else {
+		b.executed[o.ClientOrderID] = Executed{CumQty: o.CumQty, CumQuoteQty: o.CumQuoteQty}
+	}
+	if qty <= 0 || quoteQty <= 0 {
+		return
+	}
+
+	if o.Side != handlers.SideTypeBuy {
+		qty = -qty
+	}
+	b.apply(o.Symbol, qty, quoteQty/math.Abs(qty))
+}
+
+// apply nets a fill of qty, negative for sells, at price into the position of
+// symbol.
+func (b *Book) apply(symbol string, qty, price float64) {
+	p, ok := b.positions[symbol]
+	if !ok {
+		p = &Position{Symbol: symbol}
+		b.positions[symbol] = p
+	}
+
+	if p.Quantity == 0 || (p.Quantity > 0) == (qty > 0) {
+		// Opening or adding to the position.
+		total := p.Quantity + qty
+		p.AvgPrice = (p.Quantity*p.AvgPrice + qty*price) / total
+		p.Quantity = total
+		return
+	}
+
+	// Reducing, closing or flipping the position.
+	closed := math.Min(math.Abs(qty), math.Abs(p.Quantity))
+	if p.Quantity > 0 {
+		p.RealizedPnL += closed * (price - p.AvgPrice)
+	} else {
+		p.RealizedPnL += closed * (p.AvgPrice - price)
+	}
+	p.Quantity += qty
+	switch {
+	case p.Quantity == 0:
+		p.AvgPrice = 0
+	case (p.Quantity > 0) == (qty > 0):
+		// Flipped, the rest of the fill opened a new position.
+		p.AvgPrice = price
+	}
+}
+
+func isTerminal(status handlers.OrderStatus) bool {
+	switch status {
+	case handlers.OrderStatusFilled, handlers.OrderStatusCanceled,
+		handlers.OrderStatusRejected, handlers.OrderStatusExpired:
+		return true
+	default:
+		return false
+	}
+}
+
+// Position returns the position of symbol.
+func (b *Book) Position(symbol string) Position {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if p, ok := b.positions[symbol]; ok {
+		return *p
+	}
+	return Position{Symbol: symbol}
+}
+
+// Positions returns the positions of all symbols traded, sorted by symbol.
+func (b *Book) Positions() []Position {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.sorted()
+}
+
+func (b *Book) sorted() []Position {
+	positions := make([]Position, 0, len(b.positions))
+	for _, p := range b.positions {
+		positions = append(positions, *p)
+	}
+	sort.Slice(positions, func(i, j int) bool { return positions[i].Symbol < positions[j].Symbol })
+	return positions
+}
+
+// Snapshot returns the state of the book for Restore.
+func (b *Book) Snapshot() Snapshot {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	executed := make(map[string]Executed, len(b.executed))
+	for id, e := range b.executed {
+		executed[id] = e
+	}
+	return Snapshot{Positions: b.sorted(), Executed: executed}
+}
+
+// Restore replaces the state of the book with a snapshot.
+func (b *Book) Restore(s Snapshot) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.positions = make(map[string]*Position, len(s.Positions))
+	for _, p := range s.Positions {
+		b.positions[p.Symbol] = &p
+	}
+	b.executed = make(map[string]Executed, len(s.Executed))
+	for id, e := range s.Executed {
+		b.executed[id] = e
+	}
+}
+
+// MarshalState encodes a Snapshot of the book, so a Book can be passed to
+// Client.TrackState.
+func (b *Book) MarshalState() ([]byte, error) {
+	return json.Marshal(b.Snapshot())
+}
+
+// RestoreState restores the book from the output of MarshalState.
+func (b *Book) RestoreState(data []byte) error {
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	b.Restore(s)
+	return nil
+}
@@ -0,0 +1,66 @@
+package fix
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/quickfixgo/quickfix"
+)
+
+// WithQueryRetry resends idempotent queries, such as NewGetLimitService,
+// ListInstruments and GetMarketDataSnapshot, under a fresh request ID when no
+// response arrives within timeout, up to retries times. Resends go through
+// WithRateLimit like any request. The caller's context still bounds the whole
+// query.
+func WithQueryRetry(timeout time.Duration, retries int) NewClientOption {
+	return func(o *Options) {
+		o.queryTimeout = timeout
+		o.queryRetries = retries
+	}
+}
+
+// QueryAndDecode is CallAndDecode for idempotent queries: build returns the
+// request for a request ID, and with WithQueryRetry the query is rebuilt
+// under a new ID and resent when its response is late.
+func QueryAndDecode[T any](
+	ctx context.Context, c *Client, build func(id string) *quickfix.Message, decode Decoder[T],
+) (T, error) {
+	return queryAndDecode(ctx, c, "", build, decode)
+}
+
+// queryAndDecode is QueryAndDecode with request IDs starting with prefix.
+func queryAndDecode[T any](
+	ctx context.Context, c *Client, prefix string, build func(id string) *quickfix.Message, decode Decoder[T],
+) (T, error) {
+	for attempt := 0; ; attempt++ {
+		uid, err := uuid.NewRandom()
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+		id := prefix + uid.String()
+		msg := build(id)
+
+		if c.options.queryTimeout <= 0 {
+			return CallAndDecode(ctx, c, id, msg, decode)
+		}
+
+		// The timeout is for the response, not for the turn of the request.
+		if err := c.pace(ctx); err != nil {
+			var zero T
+			return zero, err
+		}
+		attemptCtx, cancel := c.withTimeout(context.WithValue(ctx, pacedKey{}, true), c.options.queryTimeout)
+		v, err := CallAndDecode(attemptCtx, c, id, msg, decode)
+		cancel()
+		if err == nil || attempt >= c.options.queryRetries || ctx.Err() != nil ||
+			!errors.Is(err, context.DeadlineExceeded) {
+			return v, err
+		}
+
+		msgType, _ := msg.MsgType()
+		c.logger().Warnw("Query timed out, resending", "msgType", msgType, "reqID", id, "attempt", attempt+1)
+	}
+}
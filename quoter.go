@@ -0,0 +1,248 @@
+package fix
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/quickfixgo/enum"
+
+	"github.com/ljm2ya/binance_fix_api/handlers"
+)
+
+// Quote is a two-sided quote. A zero quantity means no order on that side.
+type Quote struct {
+	BidPrice, BidQty float64
+	AskPrice, AskQty float64
+}
+
+// Quoter keeps a bid and an ask limit order working on a symbol, a common
+// market-making primitive. UpdateQuote diffs the wanted quote against the
+// working orders side by side: an unchanged side is left alone, a side whose
+// quantity only went down is amended so it keeps its priority in the book,
+// and any other change cancels the order and places a new one. Fills and
+// cancels are learnt from the execution reports of the Quoter's ClOrdIDs,
+// which all start with its prefix.
+type Quoter struct {
+	c        *Client
+	symbol   string
+	prefix   string
+	postOnly bool
+	seq      atomic.Uint64
+
+	update   sync.Mutex // serializes UpdateQuote
+	mu       sync.Mutex
+	bid, ask quoteOrder
+}
+
+// quoteOrder is the working order of one side of a Quoter, zero if none.
+type quoteOrder struct {
+	clOrdID  string
+	orderID  int64 // 0 until the order is acknowledged
+	price    float64
+	orderQty float64
+	cumQty   float64
+}
+
+func (o *quoteOrder) leaves() float64 {
+	return o.orderQty - o.cumQty
+}
+
+func (o *quoteOrder) matches(report *handlers.Order) bool {
+	if o.clOrdID == "" {
+		return false
+	}
+	return report.ClientOrderID == o.clOrdID || (o.orderID != 0 && report.OrderID == o.orderID)
+}
+
+// NewQuoter creates a Quoter of symbol whose ClOrdIDs start with prefix,
+// which no other orders of the session may use. Quotes are placed as
+// GOOD_TILL_CANCEL limit orders.
+func (c *Client) NewQuoter(symbol, prefix string) *Quoter {
+	q := &Quoter{c: c, symbol: symbol, prefix: prefix}
+	c.SubscribeToExecutionReportForPrefix(prefix, q.handleExecutionReport)
+	return q
+}
+
+// PostOnly places the quotes as maker-only orders.
+func (q *Quoter) PostOnly() *Quoter {
+	q.postOnly = true
+	return q
+}
+
+// Quote returns the quote currently working.
+func (q *Quoter) Quote() Quote {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return Quote{
+		BidPrice: q.bid.price, BidQty: q.bid.leaves(),
+		AskPrice: q.ask.price, AskQty: q.ask.leaves(),
+	}
+}
+
+// UpdateQuote moves the working orders to the given quote, bid side first.
+// A side that fails doesn't stop the other; the errors are joined.
+func (q *Quoter) UpdateQuote(ctx context.Context, bidPx, bidQty, askPx, askQty float64) error {
+	q.update.Lock()
+	defer q.update.Unlock()
+
+	return errors.Join(
+		q.adjust(ctx, enum.Side_BUY, &q.bid, bidPx, bidQty),
+		q.adjust(ctx, enum.Side_SELL, &q.ask, askPx, askQty),
+	)
+}
+
+// Cancel pulls both sides of the quote.
+func (q *Quoter) Cancel(ctx context.Context) error {
+	return q.UpdateQuote(ctx, 0, 0, 0, 0)
+}
+
+// adjust moves the working order of one side to price and qty.
+func (q *Quoter) adjust(ctx context.Context, side enum.Side, working *quoteOrder, price, qty float64) error {
+	q.mu.Lock()
+	current := *working
+	q.mu.Unlock()
+
+	switch {
+	case current.clOrdID == "" && qty <= 0:
+		return nil
+	case current.clOrdID == "":
+		return q.place(ctx, side, working, price, qty)
+	case qty <= 0:
+		return q.cancel(ctx, working, current)
+	case price == current.price && qty == current.leaves():
+		return nil
+	case price == current.price && qty < current.leaves():
+		return q.amend(ctx, working, current, qty)
+	default:
+		if err := q.cancel(ctx, working, current); err != nil {
+			return err
+		}
+		return q.place(ctx, side, working, price, qty)
+	}
+}
+
+func (q *Quoter) place(ctx context.Context, side enum.Side, working *quoteOrder, price, qty float64) error {
+	id := q.nextClOrdID()
+
+	// The order is tracked before it is sent, so no report of it is missed.
+	q.mu.Lock()
+	*working = quoteOrder{clOrdID: id, price: price, orderQty: qty}
+	q.mu.Unlock()
+
+	s := q.c.NewOrderSingleService().
+		ClOrdID(id).
+		Symbol(q.symbol).
+		Side(side).
+		Type(enum.OrdType_LIMIT).
+		TimeInForce(enum.TimeInForce_GOOD_TILL_CANCEL).
+		Quantity(qty).
+		Price(price)
+	if q.postOnly {
+		s.PostOnly()
+	}
+	order, err := s.Do(ctx)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if working.clOrdID != id {
+		return err
+	}
+	switch {
+	case err != nil:
+		// An order whose fate is unknown stays tracked, so the next update
+		// cancels it.
+		if !errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, context.Canceled) {
+			*working = quoteOrder{}
+		}
+	case isTerminalStatus(order.Status):
+		*working = quoteOrder{}
+	default:
+		working.orderID = order.OrderID
+	}
+	return err
+}
+
+func (q *Quoter) amend(ctx context.Context, working *quoteOrder, current quoteOrder, qty float64) error {
+	id := q.nextClOrdID()
+	s := q.c.NewOrderAmendService().
+		ClOrdID(id).
+		Symbol(q.symbol).
+		OrigClOrdID(current.clOrdID).
+		Quantity(current.cumQty + qty)
+	if current.orderID != 0 {
+		s.OrderID(current.orderID)
+	}
+	if _, err := s.Do(ctx); err != nil {
+		return err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if working.orderID == current.orderID && working.clOrdID == current.clOrdID {
+		working.clOrdID = id
+	}
+	return nil
+}
+
+func (q *Quoter) cancel(ctx context.Context, working *quoteOrder, current quoteOrder) error {
+	s := q.c.NewOrderCancelService().
+		ClOrdID(q.nextClOrdID()).
+		Symbol(q.symbol).
+		OrigClOrdID(current.clOrdID)
+	if current.orderID != 0 {
+		s.OrderID(current.orderID)
+	}
+	_, err := s.Do(ctx)
+	// A rejected cancel means the order is gone already.
+	var reject *handlers.CancelReject
+	if err != nil && !errors.As(err, &reject) {
+		return err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if working.clOrdID == current.clOrdID {
+		*working = quoteOrder{}
+	}
+	return nil
+}
+
+// handleExecutionReport follows the fills and cancels of the working orders.
+func (q *Quoter) handleExecutionReport(o *handlers.Order) {
+	if o.Symbol != q.symbol {
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, working := range []*quoteOrder{&q.bid, &q.ask} {
+		if !working.matches(o) {
+			continue
+		}
+		if isTerminalStatus(o.Status) {
+			*working = quoteOrder{}
+			continue
+		}
+		working.orderID = o.OrderID
+		if o.OrderQty > 0 {
+			working.orderQty, working.cumQty = o.OrderQty, o.CumQty
+		}
+	}
+}
+
+func (q *Quoter) nextClOrdID() string {
+	return q.prefix + strconv.FormatUint(q.seq.Add(1), 10)
+}
+
+func isTerminalStatus(status handlers.OrderStatus) bool {
+	switch status {
+	case handlers.OrderStatusFilled, handlers.OrderStatusCanceled,
+		handlers.OrderStatusRejected, handlers.OrderStatusExpired:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package fix
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/ljm2ya/binance_fix_api/handlers"
+)
+
+// WithRateLimit paces the requests sent with Call, CallAndDecode, the order
+// services and OrderTemplate to n per interval, allowing bursts of n, so the
+// client stays under the exchange's message limit instead of being
+// disconnected for exceeding it. Requests wait for their turn until their
+// context is done.
+//
+// A request rejected for a rate limit or ban pauses the limiter for the
+// RetryAfter of the reject, and the exchange's limit named in the reject
+// lowers the pace when it is below n per interval.
+func WithRateLimit(n int, interval time.Duration) NewClientOption {
+	return func(o *Options) {
+		o.rateLimit = n
+		o.rateInterval = interval
+	}
+}
+
+func newRateLimiter(n int, interval time.Duration) *rate.Limiter {
+	return rate.NewLimiter(rate.Every(interval/time.Duration(n)), n)
+}
+
+// pacedKey marks a context whose request already waited for its turn.
+type pacedKey struct{}
+
+// pace waits for the rate limiter of WithRateLimit to let a request through.
+func (c *Client) pace(ctx context.Context) error {
+	if c.limiter == nil || ctx.Value(pacedKey{}) != nil {
+		return nil
+	}
+	if wait := time.Unix(0, c.pausedUntil.Load()).Sub(c.now()); wait > 0 {
+		select {
+		case <-c.after(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return c.limiter.Wait(ctx)
+}
+
+// RetryAfter returns how long the exchange asked to wait before sending again
+// in the reject err wraps, zero if it carries no such hint. See
+// handlers.ParseRetryAfter.
+func RetryAfter(err error) time.Duration {
+	_, _, retryAfter := rejectHint(err)
+	return retryAfter
+}
+
+// rejectHint returns the ErrorCode, Text and RetryAfter of the reject err
+// wraps.
+func rejectHint(err error) (errorCode, text string, retryAfter time.Duration) {
+	var (
+		order      *handlers.OrderReject
+		cancel     *handlers.CancelReject
+		massCancel *MassCancelReject
+		marketData *MarketDataReject
+	)
+	switch {
+	case errors.As(err, &order):
+		return order.Order.ErrorCode, order.Order.RejectReason, order.RetryAfter
+	case errors.As(err, &cancel):
+		return cancel.ErrorCode, cancel.Text, cancel.RetryAfter
+	case errors.As(err, &massCancel):
+		return massCancel.ErrorCode, massCancel.Text, massCancel.RetryAfter
+	case errors.As(err, &marketData):
+		return marketData.ErrorCode, marketData.Text, marketData.RetryAfter
+	}
+	return "", "", 0
+}
+
+// backOff feeds the retry hint of a reject back into the rate limiter of
+// WithRateLimit: requests pause until the hint has passed, and the pace drops
+// to the exchange's limit when it is lower than the configured one.
+func (c *Client) backOff(err error) {
+	if c.limiter == nil {
+		return
+	}
+	errorCode, text, retryAfter := rejectHint(err)
+	if retryAfter <= 0 {
+		return
+	}
+
+	until := c.now().Add(retryAfter).UnixNano()
+	for {
+		current := c.pausedUntil.Load()
+		if current >= until || c.pausedUntil.CompareAndSwap(current, until) {
+			break
+		}
+	}
+	c.logger().Warnw("Rate limited by the exchange, pausing requests", "retryAfter", retryAfter, "errorCode", errorCode)
+
+	if !handlers.IsRateLimited(errorCode) {
+		return
+	}
+	if n, interval, ok := handlers.ParseRateLimit(text); ok {
+		limit := rate.Every(interval / time.Duration(n))
+		if limit < c.limiter.Limit() {
+			c.limiter.SetLimit(limit)
+			c.limiter.SetBurst(min(n, c.limiter.Burst()))
+			c.logger().Warnw("Lowered the rate limit to the exchange's", "limit", n, "interval", interval)
+		}
+	}
+}
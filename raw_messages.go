@@ -0,0 +1,79 @@
+package fix
+
+import (
+	"sync"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+)
+
+// rawMessagesBuffer is how many messages a RawMessages channel holds before
+// further ones are dropped.
+const rawMessagesBuffer = 1024
+
+// RawMessages returns a channel receiving every inbound application message
+// of msgTypes, or of any type when none are given, e.g. to consume message
+// types the client has no decoder for yet. Messages are copies shared by all
+// channels and must not be modified. They are delivered before the client
+// processes them, except those dropped by a receive interceptor. A message
+// that finds the channel full is dropped and logged rather than holding up
+// the session. The channel stays open for the life of the client.
+func (c *Client) RawMessages(msgTypes ...enum.MsgType) <-chan *quickfix.Message {
+	ch := make(chan *quickfix.Message, rawMessagesBuffer)
+	c.raw.add(rawFeed{msgTypes: msgTypes, ch: ch})
+	return ch
+}
+
+type rawFeed struct {
+	msgTypes []enum.MsgType // all when empty
+	ch       chan *quickfix.Message
+}
+
+func (f rawFeed) wants(msgType enum.MsgType) bool {
+	if len(f.msgTypes) == 0 {
+		return true
+	}
+	for _, t := range f.msgTypes {
+		if t == msgType {
+			return true
+		}
+	}
+	return false
+}
+
+// rawFeeds are the channels returned by RawMessages.
+type rawFeeds struct {
+	mu    sync.RWMutex
+	feeds []rawFeed
+}
+
+func (r *rawFeeds) add(feed rawFeed) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.feeds = append(r.feeds, feed)
+}
+
+// publishRaw hands a copy of msg to the RawMessages channels of its MsgType.
+func (c *Client) publishRaw(msgType enum.MsgType, msg *quickfix.Message) {
+	c.raw.mu.RLock()
+	defer c.raw.mu.RUnlock()
+
+	var raw *quickfix.Message
+	for _, feed := range c.raw.feeds {
+		if !feed.wants(msgType) {
+			continue
+		}
+		if raw == nil {
+			var err error
+			if raw, err = copyMessage(msg); err != nil {
+				c.logger().Errorw("Failed to copy raw message, dropped", "msgType", msgType, "err", err)
+				return
+			}
+		}
+		select {
+		case feed.ch <- raw:
+		default:
+			c.logger().Warnw("Raw message channel full, message dropped", "msgType", msgType)
+		}
+	}
+}
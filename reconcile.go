@@ -0,0 +1,107 @@
+package fix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/quickfixgo/enum"
+
+	"github.com/ljm2ya/binance_fix_api/handlers"
+)
+
+// OrderReconciled is emitted for an order tracked as open whose state on the
+// exchange differs from the last execution report the client received, e.g.
+// because it filled or was canceled while the session was down.
+type OrderReconciled struct {
+	// Local is the order as tracked: its Symbol, OrderID, ClientOrderID,
+	// Side, Status and CumQty.
+	Local handlers.Order
+	// Exchange is the order as reported by the exchange, unless Unknown.
+	Exchange handlers.Order
+	// Unknown is set when the exchange doesn't know the order anymore.
+	Unknown bool
+}
+
+// WithReconcileOnReconnect reconciles the tracked open orders with the
+// exchange every time the session logs on with orders tracked as open, i.e.
+// after a reconnect or after restoring them with WithStateStore. See
+// ReconcileOpenOrders; results are only reported as OrderReconciled events.
+// With WithCancelOnDisconnect the orders are canceled instead.
+func WithReconcileOnReconnect() NewClientOption {
+	return func(o *Options) {
+		o.reconcileOnReconnect = true
+	}
+}
+
+// ReconcileOpenOrders asks the exchange for the status of every order
+// tracked as open, with an OrderStatusRequest <H> by ClOrdID, and returns and
+// emits an OrderReconciled for each that changed. The answers update the
+// tracked orders like any execution report, so strategies resume from the
+// exchange's state rather than from what they saw before a disconnect.
+// Orders placed by other sessions are not discovered. Failed queries are
+// returned joined after all orders were tried.
+func (c *Client) ReconcileOpenOrders(ctx context.Context) ([]OrderReconciled, error) {
+	orders := c.openOrders.all()
+	sort.Slice(orders, func(i, j int) bool { return orders[i].OrderID < orders[j].OrderID })
+
+	var (
+		diffs []OrderReconciled
+		errs  []error
+	)
+	for _, local := range orders {
+		if local.ClientOrderID == "" {
+			continue
+		}
+		msg := orderStatusRequest(local.ClientOrderID, local.Symbol, sideOf(local.Side))
+		exchange, err := CallAndDecode(ctx, c, local.ClientOrderID, msg, c.decodeOrderResponse)
+
+		diff := OrderReconciled{Local: local}
+		switch {
+		case err == nil:
+			if exchange.Status == local.Status && exchange.CumQty == local.CumQty {
+				continue
+			}
+			diff.Exchange = exchange
+		case isUnknownOrder(err):
+			c.openOrders.drop(local)
+			diff.Unknown = true
+		case ctx.Err() != nil:
+			return diffs, ctx.Err()
+		default:
+			errs = append(errs, fmt.Errorf("order %s: %w", local.ClientOrderID, err))
+			continue
+		}
+
+		diffs = append(diffs, diff)
+		c.emit(OrderReconciledTopic, &diff)
+	}
+	return diffs, errors.Join(errs...)
+}
+
+// reconcileAfterLogon runs ReconcileOpenOrders in the background when
+// enabled and orders are tracked as open.
+func (c *Client) reconcileAfterLogon() {
+	if !c.options.reconcileOnReconnect || len(c.openOrders.all()) == 0 {
+		return
+	}
+	ctx := c.Context()
+	go func() {
+		diffs, err := c.ReconcileOpenOrders(ctx)
+		if err != nil {
+			c.logger().Errorw("Failed to reconcile open orders", "err", err)
+		}
+		c.logger().Infow("Reconciled open orders", "changed", len(diffs))
+	}()
+}
+
+// sideOf returns the FIX Side <54> of side, empty if unknown.
+func sideOf(side handlers.SideType) enum.Side {
+	for fixSide, s := range mappedSideType {
+		if string(s) == string(side) {
+			return fixSide
+		}
+	}
+	return ""
+}
@@ -0,0 +1,103 @@
+package fix
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+var redactedValue = []byte("***")
+
+// RedactedTags are the fields masked by Redact and NewRedactingLogFactory.
+var RedactedTags = []quickfix.Tag{
+	tag.RawData,  // logon signature
+	tag.Username, // API key
+	tag.Password,
+	tag.Account,
+}
+
+// Redact returns data, a raw FIX message, with the values of RedactedTags and
+// extraTags masked. BodyLength and CheckSum are left as they were.
+func Redact(data []byte, extraTags ...quickfix.Tag) []byte {
+	return redactFields(data, redactSet(extraTags))
+}
+
+func redactSet(extraTags []quickfix.Tag) map[int]bool {
+	tags := make(map[int]bool, len(RedactedTags)+len(extraTags))
+	for _, t := range RedactedTags {
+		tags[int(t)] = true
+	}
+	for _, t := range extraTags {
+		tags[int(t)] = true
+	}
+	return tags
+}
+
+func redactFields(data []byte, tags map[int]bool) []byte {
+	out := make([]byte, 0, len(data))
+	for len(data) > 0 {
+		field := data
+		if i := bytes.IndexByte(data, '\x01'); i >= 0 {
+			field, data = data[:i+1], data[i+1:]
+		} else {
+			data = nil
+		}
+
+		k, _, ok := bytes.Cut(field, []byte("="))
+		if t, err := strconv.Atoi(string(k)); ok && err == nil && tags[t] {
+			out = append(out, k...)
+			out = append(out, '=')
+			out = append(out, redactedValue...)
+			if field[len(field)-1] == '\x01' {
+				out = append(out, '\x01')
+			}
+			continue
+		}
+		out = append(out, field...)
+	}
+	return out
+}
+
+// NewRedactingLogFactory wraps factory so the messages it logs have the
+// values of RedactedTags and extraTags masked, and wire logs can be shipped
+// to centralized logging safely. Session events are passed through as they
+// are.
+func NewRedactingLogFactory(factory quickfix.LogFactory, extraTags ...quickfix.Tag) quickfix.LogFactory {
+	return &redactingLogFactory{LogFactory: factory, tags: redactSet(extraTags)}
+}
+
+type redactingLogFactory struct {
+	quickfix.LogFactory
+	tags map[int]bool
+}
+
+func (f *redactingLogFactory) Create() (quickfix.Log, error) {
+	log, err := f.LogFactory.Create()
+	if err != nil {
+		return nil, err
+	}
+	return &redactingLog{Log: log, tags: f.tags}, nil
+}
+
+func (f *redactingLogFactory) CreateSessionLog(sessionID quickfix.SessionID) (quickfix.Log, error) {
+	log, err := f.LogFactory.CreateSessionLog(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return &redactingLog{Log: log, tags: f.tags}, nil
+}
+
+type redactingLog struct {
+	quickfix.Log
+	tags map[int]bool
+}
+
+func (l *redactingLog) OnIncoming(data []byte) {
+	l.Log.OnIncoming(redactFields(data, l.tags))
+}
+
+func (l *redactingLog) OnOutgoing(data []byte) {
+	l.Log.OnOutgoing(redactFields(data, l.tags))
+}
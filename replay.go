@@ -0,0 +1,122 @@
+package fix
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+)
+
+type ReplayOption func(o *replayOptions)
+
+type replayOptions struct {
+	speed    float64
+	msgTypes map[enum.MsgType]bool
+}
+
+// WithReplaySpeed scales the delays between recorded messages: 1 replays at
+// the original pace, 10 ten times faster and 0 without any delay.
+func WithReplaySpeed(speed float64) ReplayOption {
+	return func(o *replayOptions) {
+		o.speed = speed
+	}
+}
+
+// WithReplayMsgTypes restricts the replay to the given message types, e.g.
+// enum.MsgType_MARKET_DATA_INCREMENTAL_REFRESH for market data only.
+func WithReplayMsgTypes(msgTypes ...enum.MsgType) ReplayOption {
+	return func(o *replayOptions) {
+		o.msgTypes = make(map[enum.MsgType]bool, len(msgTypes))
+		for _, msgType := range msgTypes {
+			o.msgTypes[msgType] = true
+		}
+	}
+}
+
+// Replay feeds the inbound application messages of a journal through the
+// receive interceptors, decoders and subscriptions of the client, as if they
+// were received from the server. The client does not need to be started.
+// Replay blocks until the journal is exhausted or ctx is done.
+func (c *Client) Replay(ctx context.Context, r JournalReader, opts ...ReplayOption) error {
+	o := replayOptions{speed: 1}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var previous time.Time
+	for {
+		entry, err := r.ReadEntry()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if entry.Direction != DirectionInbound {
+			continue
+		}
+
+		msg := quickfix.NewMessage()
+		if err := quickfix.ParseMessage(msg, bytes.NewBuffer(entry.Data)); err != nil {
+			return err
+		}
+		msgType, err := msg.MsgType()
+		if err != nil {
+			return err
+		}
+		if isAdminMsgType(enum.MsgType(msgType)) {
+			continue
+		}
+		if o.msgTypes != nil && !o.msgTypes[enum.MsgType(msgType)] {
+			continue
+		}
+
+		if o.speed > 0 && !previous.IsZero() {
+			delay := time.Duration(float64(entry.Time.Sub(previous)) / o.speed)
+			if err := sleepCtx(ctx, delay); err != nil {
+				return err
+			}
+		} else if err := ctx.Err(); err != nil {
+			return err
+		}
+		previous = entry.Time
+
+		if err := c.interceptReceive(msg); err != nil {
+			continue
+		}
+		if enum.MsgType(msgType) == enum.MsgType_NEWS {
+			c.handleNewsMessage(msg)
+			continue
+		}
+		c.handleSubscriptions(msgType, msg)
+	}
+}
+
+func isAdminMsgType(msgType enum.MsgType) bool {
+	switch msgType {
+	case enum.MsgType_HEARTBEAT, enum.MsgType_TEST_REQUEST, enum.MsgType_RESEND_REQUEST,
+		enum.MsgType_REJECT, enum.MsgType_SEQUENCE_RESET, enum.MsgType_LOGOUT, enum.MsgType_LOGON:
+		return true
+	default:
+		return false
+	}
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
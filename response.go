@@ -0,0 +1,155 @@
+package fix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+
+	"github.com/ljm2ya/binance_fix_api/fixerr"
+	"github.com/ljm2ya/binance_fix_api/handlers"
+)
+
+var ErrUnexpectedMsgType = errors.New("unexpected response message type")
+
+// Decoder converts a response message into a typed value.
+type Decoder[T any] func(msg *quickfix.Message) (T, error)
+
+// CallAndDecode sends msg like Client.Call and decodes the response with
+// decode, e.g. CallAndDecode(ctx, c, id, msg, DecodeLimitResponse).
+func CallAndDecode[T any](
+	ctx context.Context, c *Client, id string, msg *quickfix.Message, decode Decoder[T],
+) (T, error) {
+	return callAndDecodeTimed(ctx, c, id, msg, decode, nil)
+}
+
+// callAndDecodeTimed is CallAndDecode, recording timings like callTimed.
+func callAndDecodeTimed[T any](
+	ctx context.Context, c *Client, id string, msg *quickfix.Message, decode Decoder[T], timings *handlers.Timings,
+) (T, error) {
+	start := c.now()
+	resp, err := c.callTimed(ctx, id, msg, timings)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	_, span := c.startSpan(ctx, "fix.decode")
+	v, err := decode(resp)
+	endSpan(span, err)
+	if err != nil {
+		c.backOff(err)
+		msgType, _ := msg.MsgType()
+		return v, fixerr.Wrap(err, id, msgType, c.since(start))
+	}
+	return v, nil
+}
+
+// DecodeOrderResponse decodes the response to an order or cancel request. A
+// rejected order is returned with a *handlers.OrderReject error, an
+// OrderCancelReject <9> as a *handlers.CancelReject error and an
+// OrderAmendReject <XAR> as an *OrderAmendReject error. The RetryAfter of a
+// reject is relative to the system clock.
+func DecodeOrderResponse(msg *quickfix.Message) (handlers.Order, error) {
+	return decodeOrderResponseAt(msg, time.Now())
+}
+
+// decodeOrderResponse decodes an order response with the RetryAfter of a
+// reject relative to the client's Clock.
+func (c *Client) decodeOrderResponse(msg *quickfix.Message) (handlers.Order, error) {
+	return decodeOrderResponseAt(msg, c.now())
+}
+
+// decodeOrderResponseAt is DecodeOrderResponse with the RetryAfter of a
+// reject relative to now.
+func decodeOrderResponseAt(msg *quickfix.Message, now time.Time) (handlers.Order, error) {
+	msgType, err := msg.MsgType()
+	if err != nil {
+		return handlers.Order{}, err
+	}
+
+	switch enum.MsgType(msgType) {
+	case enum.MsgType_EXECUTION_REPORT:
+		order, err := handlers.DecodeExecutionReport(msg)
+		if err != nil {
+			return handlers.Order{}, err
+		}
+		if order.Status == handlers.OrderStatusRejected {
+			return order, &handlers.OrderReject{
+				Order:      order,
+				RetryAfter: handlers.ParseRetryAfter(order.ErrorCode, order.RejectReason, now),
+			}
+		}
+		return order, nil
+	case enum.MsgType_ORDER_CANCEL_REJECT:
+		reject, err := handlers.DecodeOrderCancelReject(msg)
+		if err != nil {
+			return handlers.Order{}, err
+		}
+		reject.RetryAfter = handlers.ParseRetryAfter(reject.ErrorCode, reject.Text, now)
+		return handlers.Order{}, &reject
+	case msgType_ORDER_AMEND_REJECT:
+		return handlers.Order{}, decodeOrderAmendReject(msg)
+	default:
+		return handlers.Order{}, fmt.Errorf("%w: %s", ErrUnexpectedMsgType, msgType)
+	}
+}
+
+// DecodeResponse decodes any response the client correlates to a request by
+// its MsgType: *handlers.Order for ExecutionReport <8>,
+// *handlers.CancelReject for OrderCancelReject <9>, *handlers.ListStatus for
+// ListStatus <N>, *LimitResponse for LimitResponse <XLR>,
+// *MarketDataSnapshot for MarketDataSnapshotFullRefresh <W>,
+// []instruments.Instrument for InstrumentList <y> and the number of canceled
+// orders for OrderMassCancelReport <r>. A MarketDataRequestReject <Y> is
+// returned as a *MarketDataReject error, a rejected mass cancel as a
+// *MassCancelReject error. The RetryAfter of a reject is relative to the
+// system clock.
+func DecodeResponse(msg *quickfix.Message) (any, error) {
+	msgType, err := msg.MsgType()
+	if err != nil {
+		return nil, err
+	}
+
+	switch enum.MsgType(msgType) {
+	case enum.MsgType_EXECUTION_REPORT:
+		order, err := handlers.DecodeExecutionReport(msg)
+		if err != nil {
+			return nil, err
+		}
+		return &order, nil
+	case enum.MsgType_ORDER_CANCEL_REJECT:
+		reject, err := handlers.DecodeOrderCancelReject(msg)
+		if err != nil {
+			return nil, err
+		}
+		return &reject, nil
+	case enum.MsgType_LIST_STATUS:
+		listStatus, err := handlers.DecodeListStatus(msg)
+		if err != nil {
+			return nil, err
+		}
+		return &listStatus, nil
+	case msgType_LIMIT_RESPONSE:
+		limits, err := DecodeLimitResponse(msg)
+		if err != nil {
+			return nil, err
+		}
+		return &limits, nil
+	case enum.MsgType_MARKET_DATA_SNAPSHOT_FULL_REFRESH, enum.MsgType_MARKET_DATA_REQUEST_REJECT:
+		snapshot, err := decodeMarketDataSnapshotAt(msg, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		return &snapshot, nil
+	case msgType_INSTRUMENT_LIST:
+		return DecodeInstrumentList(msg)
+	case enum.MsgType_ORDER_MASS_CANCEL_REPORT:
+		return decodeMassCancelReport(msg, time.Now())
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnexpectedMsgType, msgType)
+	}
+}
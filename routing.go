@@ -0,0 +1,73 @@
+package fix
+
+import (
+	"sync"
+
+	"github.com/ljm2ya/binance_fix_api/handlers"
+)
+
+// executionRoutes dispatches execution reports to the handlers registered for
+// their symbol or ClOrdID prefix, so only the interested strategies see them.
+// The zero value is ready to use.
+type executionRoutes struct {
+	mu       sync.RWMutex
+	bySymbol map[string][]ExecutionReportHandler
+	byPrefix map[string][]ExecutionReportHandler
+	lengths  []int // distinct prefix lengths, ascending
+}
+
+func (r *executionRoutes) addSymbol(symbol string, handler ExecutionReportHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.bySymbol == nil {
+		r.bySymbol = make(map[string][]ExecutionReportHandler)
+	}
+	r.bySymbol[symbol] = append(r.bySymbol[symbol], handler)
+}
+
+func (r *executionRoutes) addPrefix(prefix string, handler ExecutionReportHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.byPrefix == nil {
+		r.byPrefix = make(map[string][]ExecutionReportHandler)
+	}
+	if _, ok := r.byPrefix[prefix]; !ok {
+		r.addLength(len(prefix))
+	}
+	r.byPrefix[prefix] = append(r.byPrefix[prefix], handler)
+}
+
+func (r *executionRoutes) addLength(n int) {
+	i := 0
+	for i < len(r.lengths) && r.lengths[i] < n {
+		i++
+	}
+	if i < len(r.lengths) && r.lengths[i] == n {
+		return
+	}
+	r.lengths = append(r.lengths, 0)
+	copy(r.lengths[i+1:], r.lengths[i:])
+	r.lengths[i] = n
+}
+
+// route calls the handlers of the order's symbol, then those of every
+// registered prefix of its ClOrdID. Lookups cost one map access per distinct
+// prefix length rather than one per subscription.
+func (r *executionRoutes) route(o *handlers.Order) {
+	r.mu.RLock()
+	var matched []ExecutionReportHandler
+	matched = append(matched, r.bySymbol[o.Symbol]...)
+	for _, n := range r.lengths {
+		if n > len(o.ClientOrderID) {
+			break
+		}
+		matched = append(matched, r.byPrefix[o.ClientOrderID[:n]]...)
+	}
+	r.mu.RUnlock()
+
+	for _, handler := range matched {
+		handler(o)
+	}
+}
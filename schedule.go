@@ -0,0 +1,245 @@
+package fix
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/quickfix/config"
+)
+
+// SessionSchedule is when the session is logged on, see WithSessionSchedule.
+// Times of day are offsets from midnight in the schedule's location, UTC
+// unless set with In, and are truncated to seconds.
+type SessionSchedule struct {
+	start, end time.Duration
+	weekdays   []time.Weekday
+	weekly     bool
+	startDay   time.Weekday
+	endDay     time.Weekday
+	loc        *time.Location
+}
+
+// DailySchedule logs on at start and out at end every day, or only on
+// weekdays when given. An end before start spans midnight, the weekday being
+// that of the time checked.
+func DailySchedule(start, end time.Duration, weekdays ...time.Weekday) SessionSchedule {
+	return SessionSchedule{start: start, end: end, weekdays: weekdays, loc: time.UTC}
+}
+
+// WeeklySchedule logs on at start on startDay and out at end on endDay, e.g.
+// to stay out of a weekly restart window.
+func WeeklySchedule(startDay time.Weekday, start time.Duration, endDay time.Weekday, end time.Duration) SessionSchedule {
+	return SessionSchedule{start: start, end: end, weekly: true, startDay: startDay, endDay: endDay, loc: time.UTC}
+}
+
+// In returns the schedule with its times of day in loc.
+func (s SessionSchedule) In(loc *time.Location) SessionSchedule {
+	s.loc = loc
+	return s
+}
+
+func timeOfDay(t time.Time) time.Duration {
+	hour, minute, second := t.Clock()
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute + time.Duration(second)*time.Second
+}
+
+func formatTimeOfDay(d time.Duration) string {
+	d = d.Truncate(time.Second)
+	return fmt.Sprintf("%02d:%02d:%02d", int(d/time.Hour), int(d/time.Minute)%60, int(d/time.Second)%60)
+}
+
+// Contains reports whether the session is scheduled to be logged on at t, the
+// way quickfix decides it.
+func (s SessionSchedule) Contains(t time.Time) bool {
+	t = t.In(s.loc)
+	tod := timeOfDay(t)
+	start, end := s.start.Truncate(time.Second), s.end.Truncate(time.Second)
+	day := t.Weekday()
+
+	if !s.weekly {
+		if len(s.weekdays) > 0 && !containsWeekday(s.weekdays, day) {
+			return false
+		}
+		if start < end {
+			return start <= tod && tod <= end
+		}
+		return !(end < tod && tod < start)
+	}
+
+	if s.startDay == s.endDay {
+		if day == s.startDay {
+			if start < end {
+				return start <= tod && tod <= end
+			}
+			return !(end < tod && tod < start)
+		}
+		return start >= end
+	}
+	if s.startDay < s.endDay {
+		if day < s.startDay || s.endDay < day {
+			return false
+		}
+	} else if s.endDay < day && day < s.startDay {
+		return false
+	}
+	switch day {
+	case s.startDay:
+		return tod >= start
+	case s.endDay:
+		return tod <= end
+	default:
+		return true
+	}
+}
+
+func containsWeekday(days []time.Weekday, day time.Weekday) bool {
+	for _, d := range days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// NextStart returns the next time after t the session is scheduled to log on,
+// t itself while it is scheduled to be logged on.
+func (s SessionSchedule) NextStart(t time.Time) time.Time {
+	if s.Contains(t) {
+		return t
+	}
+	local := t.In(s.loc)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, s.loc)
+	for i := 0; i <= 7; i++ {
+		start := midnight.AddDate(0, 0, i).Add(s.start.Truncate(time.Second))
+		if start.After(t) && s.Contains(start) {
+			return start
+		}
+	}
+	// Never scheduled, e.g. a daily schedule without any weekday matching.
+	return time.Time{}
+}
+
+// apply writes the schedule to the quickfix settings of a session.
+func (s SessionSchedule) apply(settings *quickfix.SessionSettings) {
+	settings.Set(config.StartTime, formatTimeOfDay(s.start))
+	settings.Set(config.EndTime, formatTimeOfDay(s.end))
+	settings.Set(config.TimeZone, s.loc.String())
+	if s.weekly {
+		settings.Set(config.StartDay, s.startDay.String())
+		settings.Set(config.EndDay, s.endDay.String())
+	} else if len(s.weekdays) > 0 {
+		days := make([]string, len(s.weekdays))
+		for i, day := range s.weekdays {
+			days[i] = day.String()
+		}
+		settings.Set(config.Weekdays, strings.Join(days, ","))
+	}
+}
+
+// WithSessionSchedule only keeps the session logged on during schedule, e.g.
+// trading hours: quickfix logs out at its end and on again at its next start.
+// Logouts at the end of the schedule are expected and not reported to
+// WaitForDisconnect. Outside of the schedule Start waits for its next start,
+// the logon timeout counting from there.
+func WithSessionSchedule(schedule SessionSchedule) NewClientOption {
+	return func(o *Options) {
+		o.schedule = &schedule
+	}
+}
+
+// WithMaintenanceRestart logs out lead before a maintenance announced by the
+// server and logs on again once it is over, i.e. at its end time or, when no
+// end was announced, 10 minutes after its start. Trade subscriptions are
+// renewed. The planned logout is logged as such and not reported to
+// WaitForDisconnect, so announced restarts don't surface as errors. It is
+// ignored while WithMaintenanceFailover moves the session to another gateway.
+func WithMaintenanceRestart(lead time.Duration) NewClientOption {
+	return func(o *Options) {
+		o.maintenanceRestart = lead
+	}
+}
+
+// plannedLogout reports whether the session logs out on purpose, for a
+// maintenance or at the end of its schedule.
+func (c *Client) plannedLogout(now time.Time) bool {
+	if c.pausing.Load() {
+		return true
+	}
+	return c.options.schedule != nil && !c.options.schedule.Contains(now)
+}
+
+// scheduleDelay returns how long until the session is scheduled to log on.
+func (c *Client) scheduleDelay() time.Duration {
+	if c.options.schedule == nil {
+		return 0
+	}
+	now := c.now()
+	next := c.options.schedule.NextStart(now)
+	if !next.After(now) {
+		return 0
+	}
+	c.logger().Infow("Waiting for the session schedule", "start", next)
+	return next.Sub(now)
+}
+
+// pauseForMaintenance logs out ahead of a maintenance and arms the logon
+// after it.
+func (c *Client) pauseForMaintenance(until time.Time) {
+	c.rotateMu.Lock()
+	defer c.rotateMu.Unlock()
+
+	if state := c.State(); state == StateStopping || state == StateDisconnected {
+		return
+	}
+	c.logger().Infow("Logging out for maintenance", "until", until)
+	c.pausing.Store(true)
+	c.stopStaleWatchdog()
+	c.initiator.Stop()
+
+	initiator, err := c.newInitiator()
+	if err != nil {
+		c.pausing.Store(false)
+		c.logger().Errorw("Failed to recreate the session after maintenance logout", "err", err)
+		return
+	}
+	c.initiator = initiator
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.maintenanceTimer = c.afterFunc(until.Sub(c.now()), c.resumeAfterMaintenance)
+}
+
+// resumeAfterMaintenance logs on again after pauseForMaintenance.
+func (c *Client) resumeAfterMaintenance() {
+	c.rotateMu.Lock()
+	defer c.rotateMu.Unlock()
+
+	// Stop was called during the maintenance.
+	if !c.pausing.Load() || c.State() != StateReconnecting {
+		return
+	}
+	c.logger().Infow("Logging on after maintenance")
+	err := c.Start(context.Background())
+	c.pausing.Store(false)
+	if err != nil {
+		// quickfix keeps dialing, like after any disconnect.
+		c.logger().Warnw("Logon after maintenance failed, retrying", "err", err)
+		return
+	}
+	if err := c.resubscribe(context.Background()); err != nil {
+		c.logger().Errorw("Failed to renew market data subscriptions after maintenance", "err", err)
+	}
+}
+
+// newInitiator creates the quickfix initiator of the client's settings.
+func (c *Client) newInitiator() (*quickfix.Initiator, error) {
+	return quickfix.NewInitiator(
+		c,
+		quickfix.NewMemoryStoreFactory(),
+		c.config.Settings,
+		&sessionWatchLogFactory{LogFactory: c.options.fixLogFactory, c: c},
+	)
+}
@@ -0,0 +1,98 @@
+package fix
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"regexp"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/quickfix/config"
+)
+
+const (
+	maxSenderCompIDLen  = 8
+	senderCompIDCharset = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+)
+
+var ErrInvalidSenderCompID = errors.New("SenderCompID must be 1 to 8 letters, digits, '-' or '_'")
+
+// senderCompIDPattern is the format Binance accepts for SenderCompID.
+var senderCompIDPattern = regexp.MustCompile(`^[a-zA-Z0-9-_]{1,8}$`)
+
+// WithSenderCompID sets the SenderCompID of the session, overriding the one
+// in Config.Settings or the generated one. Sessions of the same API key must
+// use distinct SenderCompIDs. Without Config.Endpoint, keep the "BMD" prefix
+// for market data and "BDC" for drop copy sessions, the client derives the
+// endpoint type from it.
+func WithSenderCompID(senderCompID string) NewClientOption {
+	return func(o *Options) {
+		o.senderCompID = senderCompID
+		o.randomSenderCompID = false
+	}
+}
+
+// WithRandomSenderCompID sets the SenderCompID of the session to prefix
+// followed by random letters and digits up to 8 characters, so any number of
+// clients can share an API key. A new suffix is drawn by RotateCredentials.
+func WithRandomSenderCompID(prefix string) NewClientOption {
+	return func(o *Options) {
+		o.senderCompID = prefix
+		o.randomSenderCompID = true
+	}
+}
+
+// randomSenderCompID fills prefix up to 8 characters with random letters and
+// digits.
+func randomSenderCompID(prefix string) (string, error) {
+	if len(prefix) >= maxSenderCompIDLen {
+		return "", ErrInvalidSenderCompID
+	}
+	b := []byte(prefix)
+	charsetLen := big.NewInt(int64(len(senderCompIDCharset)))
+	for len(b) < maxSenderCompIDLen {
+		n, err := rand.Int(rand.Reader, charsetLen)
+		if err != nil {
+			return "", err
+		}
+		b = append(b, senderCompIDCharset[n.Int64()])
+	}
+	return string(b), nil
+}
+
+// resolveSenderCompID applies WithSenderCompID or WithRandomSenderCompID to
+// settings and validates the resulting SenderCompID.
+func resolveSenderCompID(settings *quickfix.Settings, o Options) (*quickfix.Settings, string, error) {
+	senderCompID := o.senderCompID
+	if o.randomSenderCompID {
+		var err error
+		if senderCompID, err = randomSenderCompID(senderCompID); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if senderCompID != "" {
+		// Sessions are keyed by the SessionID they were parsed with, so they
+		// are added again under the new one.
+		global := settings.GlobalSettings()
+		global.Set(config.SenderCompID, senderCompID)
+		updated := quickfix.NewSettings()
+		*updated.GlobalSettings() = *global
+		for _, session := range settings.SessionSettings() {
+			session.Set(config.SenderCompID, senderCompID)
+			if _, err := updated.AddSession(session); err != nil {
+				return nil, "", err
+			}
+		}
+		settings = updated
+	}
+
+	senderCompID, err := sessionSettings(settings).Setting(config.SenderCompID)
+	if err != nil {
+		return nil, "", err
+	}
+	if !senderCompIDPattern.MatchString(senderCompID) {
+		return nil, "", ErrInvalidSenderCompID
+	}
+	return settings, senderCompID, nil
+}
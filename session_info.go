@@ -0,0 +1,67 @@
+package fix
+
+import (
+	"net"
+	"strconv"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/quickfix/config"
+)
+
+// SessionInfo describes the FIX session of a client, e.g. to label the logs
+// and metrics of an application running several sessions.
+type SessionInfo struct {
+	SessionID    string       `json:"session_id"`
+	BeginString  string       `json:"begin_string"`
+	SenderCompID string       `json:"sender_comp_id"`
+	TargetCompID string       `json:"target_comp_id"`
+	Endpoint     EndpointType `json:"endpoint"`
+
+	// ConnectHost and ConnectPort are the gateway the session is connected
+	// to, or connects to first when it isn't, even through a proxy or with
+	// failover gateways.
+	ConnectHost string `json:"connect_host"`
+	ConnectPort int    `json:"connect_port"`
+	// TLS reports whether the connection is encrypted.
+	TLS bool `json:"tls"`
+}
+
+// SessionID returns the quickfix session ID of the client.
+func (c *Client) SessionID() quickfix.SessionID {
+	return c.sessionID
+}
+
+// SessionInfo returns the session ID, comp IDs, endpoint type and gateway
+// of the client. It never contacts the server.
+func (c *Client) SessionInfo() SessionInfo {
+	c.mu.Lock()
+	senderCompID, settings := c.senderCompID, c.config.Settings
+	c.mu.Unlock()
+
+	info := SessionInfo{
+		SessionID:    c.sessionID.String(),
+		BeginString:  c.beginString,
+		SenderCompID: senderCompID,
+		TargetCompID: c.targetCompID,
+		Endpoint:     c.endpoint,
+	}
+
+	session := sessionSettings(settings)
+	var address string
+	if c.relay != nil {
+		address = c.relay.activeAddress()
+	} else if addresses, err := gatewayAddresses(session); err == nil {
+		address = addresses[0]
+	}
+	if host, port, err := net.SplitHostPort(address); err == nil {
+		info.ConnectHost = host
+		info.ConnectPort, _ = strconv.Atoi(port)
+	}
+
+	if c.relay != nil && c.relay.tls {
+		info.TLS = true
+	} else if session.HasSetting(config.SocketUseSSL) {
+		info.TLS, _ = session.BoolSetting(config.SocketUseSSL)
+	}
+	return info
+}
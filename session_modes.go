@@ -0,0 +1,116 @@
+package fix
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/quickfixgo/quickfix"
+)
+
+var (
+	ErrInvalidMessageHandling = errors.New("invalid MessageHandling")
+	ErrInvalidResponseMode    = errors.New("invalid ResponseMode")
+)
+
+// MessageHandling <25035> tells the exchange how to process the messages of a
+// session, sent in the Logon.
+type MessageHandling int
+
+const (
+	// MessageHandlingUnordered lets the exchange process messages in any
+	// order, possibly concurrently, for more throughput. Responses may come
+	// back in a different order than the requests.
+	MessageHandlingUnordered MessageHandling = 1
+	// MessageHandlingSequential processes messages in the order they are
+	// received. The default.
+	MessageHandlingSequential MessageHandling = 2
+)
+
+func (m MessageHandling) String() string {
+	switch m {
+	case MessageHandlingUnordered:
+		return "UNORDERED"
+	case MessageHandlingSequential:
+		return "SEQUENTIAL"
+	default:
+		return fmt.Sprintf("MessageHandling(%d)", int(m))
+	}
+}
+
+func (m MessageHandling) validate() error {
+	switch m {
+	case MessageHandlingUnordered, MessageHandlingSequential:
+		return nil
+	default:
+		return fmt.Errorf("%w: %d", ErrInvalidMessageHandling, int(m))
+	}
+}
+
+// ResponseMode <25036> tells the exchange which responses an order entry
+// session gets, sent in the Logon. Market data sessions don't send it.
+type ResponseMode int
+
+const (
+	// ResponseModeEverything sends every ExecutionReport. The default.
+	ResponseModeEverything ResponseMode = 1
+	// ResponseModeOnlyAcks sends only the acknowledgements of requests,
+	// whether they succeeded or failed, and no further order updates.
+	ResponseModeOnlyAcks ResponseMode = 2
+)
+
+func (m ResponseMode) String() string {
+	switch m {
+	case ResponseModeEverything:
+		return "EVERYTHING"
+	case ResponseModeOnlyAcks:
+		return "ONLY_ACKS"
+	default:
+		return fmt.Sprintf("ResponseMode(%d)", int(m))
+	}
+}
+
+func (m ResponseMode) validate() error {
+	switch m {
+	case ResponseModeEverything, ResponseModeOnlyAcks:
+		return nil
+	default:
+		return fmt.Errorf("%w: %d", ErrInvalidResponseMode, int(m))
+	}
+}
+
+// sessionModes holds the modes of the current session: those sent in the
+// Logon, replaced by the server's if its Logon echoes them.
+type sessionModes struct {
+	messageHandling atomic.Int32
+	responseMode    atomic.Int32
+}
+
+// sent records the modes of an outgoing Logon.
+func (m *sessionModes) sent(msg *quickfix.Message) {
+	m.messageHandling.Store(0)
+	m.responseMode.Store(0)
+	m.read(msg)
+}
+
+// read records the modes a Logon has, e.g. those echoed by the server.
+func (m *sessionModes) read(msg *quickfix.Message) {
+	if v, err := msg.Body.GetInt(tagMessageHandling); err == nil {
+		m.messageHandling.Store(int32(v))
+	}
+	if v, err := msg.Body.GetInt(tagResponseMode); err == nil {
+		m.responseMode.Store(int32(v))
+	}
+}
+
+// MessageHandling returns the MessageHandling of the current session, zero
+// before the first logon.
+func (c *Client) MessageHandling() MessageHandling {
+	return MessageHandling(c.modes.messageHandling.Load())
+}
+
+// ResponseMode returns the ResponseMode of the current session, zero before
+// the first logon and for market data sessions.
+func (c *Client) ResponseMode() ResponseMode {
+	return ResponseMode(c.modes.responseMode.Load())
+}
@@ -0,0 +1,210 @@
+package fix
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/quickfix/config"
+)
+
+var ErrInvalidSettings = errors.New("invalid settings")
+
+// SettingsBuilder builds quickfix settings for a Binance FIX session, as an
+// alternative to writing the INI format by hand.
+//
+//	settings, err := fix.NewSettingsBuilder(fix.OrderEntryEndpoint).
+//		ReconnectInterval(5 * time.Second).
+//		LogonTimeout(10 * time.Second).
+//		Build()
+//
+// The first invalid value is reported by Build.
+type SettingsBuilder struct {
+	values   map[string]string
+	gateways []string
+	err      error
+}
+
+// NewSettingsBuilder starts from the DefaultEndpoints entry of endpoint, with
+// TLS on and a random SenderCompID.
+func NewSettingsBuilder(endpoint EndpointType) *SettingsBuilder {
+	b := &SettingsBuilder{values: map[string]string{
+		config.BeginString:  quickfix.BeginStringFIX44,
+		"ConnectionType":    "initiator",
+		config.SocketUseSSL: "Y",
+	}}
+
+	defaults, ok := DefaultEndpoints[endpoint]
+	if !ok {
+		b.fail(fmt.Errorf("unknown endpoint type: %s", endpoint))
+		return b
+	}
+	b.Gateway(net.JoinHostPort(defaults.Host, strconv.Itoa(defaults.Port)))
+	for _, address := range defaults.FailoverAddresses {
+		b.Gateway(address)
+	}
+	b.TargetCompID(defaults.TargetCompID)
+	b.HeartBtInt(time.Duration(defaults.HeartbeatInt) * time.Second)
+
+	senderCompID, err := randomSenderCompID(senderCompIDPrefixes[endpoint])
+	if err != nil {
+		b.fail(err)
+		return b
+	}
+	return b.SenderCompID(senderCompID)
+}
+
+func (b *SettingsBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// invalid records an invalid value of setting.
+func (b *SettingsBuilder) invalid(setting string, value any) {
+	b.fail(fmt.Errorf("%w: %s %v", ErrInvalidSettings, setting, value))
+}
+
+// seconds sets a duration setting, quickfix only takes whole seconds.
+func (b *SettingsBuilder) seconds(setting string, d time.Duration) *SettingsBuilder {
+	if d < time.Second || d%time.Second != 0 {
+		b.invalid(setting, d)
+		return b
+	}
+	b.values[setting] = strconv.Itoa(int(d / time.Second))
+	return b
+}
+
+func (b *SettingsBuilder) flag(setting string, on bool) *SettingsBuilder {
+	if on {
+		b.values[setting] = "Y"
+	} else {
+		b.values[setting] = "N"
+	}
+	return b
+}
+
+// Gateway adds a "host:port" failover gateway after those set so far.
+func (b *SettingsBuilder) Gateway(address string) *SettingsBuilder {
+	_, port, err := net.SplitHostPort(address)
+	if err != nil {
+		b.invalid("gateway", address)
+		return b
+	}
+	if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+		b.invalid("gateway", address)
+		return b
+	}
+	b.gateways = append(b.gateways, address)
+	return b
+}
+
+// Host replaces the endpoint's default gateways with address.
+func (b *SettingsBuilder) Host(address string) *SettingsBuilder {
+	b.gateways = nil
+	return b.Gateway(address)
+}
+
+// SenderCompID sets the SenderCompID, see WithSenderCompID.
+func (b *SettingsBuilder) SenderCompID(senderCompID string) *SettingsBuilder {
+	if !senderCompIDPattern.MatchString(senderCompID) {
+		b.fail(ErrInvalidSenderCompID)
+		return b
+	}
+	b.values[config.SenderCompID] = senderCompID
+	return b
+}
+
+// TargetCompID sets the TargetCompID, "SPOT" for Binance spot.
+func (b *SettingsBuilder) TargetCompID(targetCompID string) *SettingsBuilder {
+	if targetCompID == "" {
+		b.invalid(config.TargetCompID, targetCompID)
+		return b
+	}
+	b.values[config.TargetCompID] = targetCompID
+	return b
+}
+
+// HeartBtInt sets the heartbeat interval.
+func (b *SettingsBuilder) HeartBtInt(d time.Duration) *SettingsBuilder {
+	return b.seconds(config.HeartBtInt, d)
+}
+
+// ReconnectInterval sets the wait between reconnect attempts, 30s by default.
+func (b *SettingsBuilder) ReconnectInterval(d time.Duration) *SettingsBuilder {
+	return b.seconds(config.ReconnectInterval, d)
+}
+
+// LogonTimeout sets how long quickfix waits for the Logon answer before
+// dropping the connection, 10s by default.
+func (b *SettingsBuilder) LogonTimeout(d time.Duration) *SettingsBuilder {
+	return b.seconds(config.LogonTimeout, d)
+}
+
+// ResetOnLogon resets sequence numbers on every logon. It defaults to on in
+// NewClient, as the logon signature covers MsgSeqNum=1.
+func (b *SettingsBuilder) ResetOnLogon(on bool) *SettingsBuilder {
+	return b.flag(config.ResetOnLogon, on)
+}
+
+// SSL turns TLS on or off.
+func (b *SettingsBuilder) SSL(on bool) *SettingsBuilder {
+	return b.flag(config.SocketUseSSL, on)
+}
+
+// ValidateCertificates turns verification of the gateway certificate on or
+// off. Only turn it off against test gateways.
+func (b *SettingsBuilder) ValidateCertificates(on bool) *SettingsBuilder {
+	return b.flag(config.SocketInsecureSkipVerify, !on)
+}
+
+// DataDictionary validates messages against the FIX data dictionary at path.
+func (b *SettingsBuilder) DataDictionary(path string) *SettingsBuilder {
+	if _, err := os.Stat(path); err != nil {
+		b.fail(fmt.Errorf("%w: %s: %w", ErrInvalidSettings, config.DataDictionary, err))
+		return b
+	}
+	b.values[config.DataDictionary] = path
+	return b
+}
+
+// Set sets any other quickfix setting, see the quickfix/config package.
+func (b *SettingsBuilder) Set(setting, value string) *SettingsBuilder {
+	b.values[setting] = value
+	return b
+}
+
+// Build returns the settings, or the first invalid value.
+func (b *SettingsBuilder) Build() (*quickfix.Settings, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	if len(b.gateways) == 0 {
+		return nil, fmt.Errorf("%w: no gateway", ErrInvalidSettings)
+	}
+
+	settings := quickfix.NewSettings()
+	global := settings.GlobalSettings()
+	for setting, value := range b.values {
+		global.Set(setting, value)
+	}
+	for i, address := range b.gateways {
+		host, port, _ := net.SplitHostPort(address)
+		hostSetting, portSetting := config.SocketConnectHost, config.SocketConnectPort
+		if i > 0 {
+			hostSetting += strconv.Itoa(i)
+			portSetting += strconv.Itoa(i)
+		}
+		global.Set(hostSetting, host)
+		global.Set(portSetting, port)
+	}
+	if _, err := settings.AddSession(quickfix.NewSessionSettings()); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
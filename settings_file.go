@@ -0,0 +1,154 @@
+package fix
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/quickfix/config"
+)
+
+// fileSettings are the settings written by SaveSettings, in the order
+// written. quickfix.Settings can't list its settings, so others are lost.
+var fileSettings = []string{
+	config.BeginString, config.SenderCompID, config.SenderSubID, config.SenderLocationID,
+	config.TargetCompID, config.TargetSubID, config.TargetLocationID, config.SessionQualifier,
+	config.DefaultApplVerID, "ConnectionType",
+
+	config.StartTime, config.EndTime, config.StartDay, config.EndDay, config.Weekdays, config.TimeZone,
+	config.TimeStampPrecision,
+
+	config.ResetOnLogon, config.RefreshOnLogon, config.ResetOnLogout, config.ResetOnDisconnect,
+	config.DataDictionary, config.TransportDataDictionary, config.AppDataDictionary,
+	config.RejectInvalidMessage, config.AllowUnknownMessageFields, config.CheckUserDefinedFields,
+	config.ValidateFieldsOutOfOrder, config.CheckLatency, config.MaxLatency,
+
+	config.ReconnectInterval, config.LogoutTimeout, config.LogonTimeout, config.HeartBtInt,
+	config.HeartBtIntOverride, config.ResendRequestChunkSize, config.EnableLastMsgSeqNumProcessed,
+
+	config.SocketConnectHost, config.SocketConnectPort, config.SocketTimeout,
+	config.SocketAcceptHost, config.SocketAcceptPort, config.UseTCPProxy,
+	config.ProxyType, config.ProxyHost, config.ProxyPort, config.ProxyUser, config.ProxyPassword,
+	config.SocketUseSSL, config.SocketServerName, config.SocketInsecureSkipVerify, config.SocketMinimumTLSVersion,
+	config.SocketPrivateKeyFile, config.SocketCertificateFile, config.SocketCAFile,
+	config.SocketPrivateKeyBytes, config.SocketCertificateBytes, config.SocketCABytes,
+
+	config.DynamicSessions, config.DynamicQualifier,
+	config.FileLogPath, config.PersistMessages, config.FileStorePath, config.FileStoreSync,
+	config.SQLStoreDriver, config.SQLStoreDataSourceName, config.SQLStoreConnMaxLifetime,
+	config.MongoStoreConnection, config.MongoStoreDatabase, config.MongoStoreReplicaSet,
+}
+
+// LoadSettings reads a classic quickfix settings file, as written by
+// SaveSettings or by hand. Config.SettingsFilePath loads one for NewClient.
+func LoadSettings(path string) (*quickfix.Settings, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	settings, err := quickfix.ParseSettings(f)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return settings, nil
+}
+
+// SaveSettings writes settings to path as a classic quickfix settings file,
+// e.g. the settings of NewSettingsBuilder or GenerateQuickFixSettings, to be
+// edited or loaded again with LoadSettings. Only the settings defined by
+// quickfix, the failover gateways and ConnectionType are written; session
+// sections hold the settings differing from [DEFAULT]. The file may hold
+// secrets such as ProxyPassword and is created readable by the owner only.
+func SaveSettings(path string, settings *quickfix.Settings) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+	global := settings.GlobalSettings()
+	fmt.Fprintln(w, "[DEFAULT]")
+	for _, name := range settingNames(global) {
+		value, _ := global.Setting(name)
+		fmt.Fprintf(w, "%s=%s\n", name, value)
+	}
+
+	sessions := settings.SessionSettings()
+	for _, id := range sortedSessionIDs(sessions) {
+		session := sessions[id]
+		fmt.Fprintln(w, "\n[SESSION]")
+		for _, name := range settingNames(session) {
+			value, _ := session.Setting(name)
+			if global.HasSetting(name) {
+				if globalValue, _ := global.Setting(name); globalValue == value {
+					continue
+				}
+			}
+			fmt.Fprintf(w, "%s=%s\n", name, value)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// settingNames returns the names of the settings of s SaveSettings writes.
+func settingNames(s *quickfix.SessionSettings) []string {
+	var names []string
+	for _, name := range fileSettings {
+		if s.HasSetting(name) {
+			names = append(names, name)
+		}
+	}
+	// Failover gateways, see SettingsBuilder.Gateway.
+	for i := 1; s.HasSetting(config.SocketConnectHost + strconv.Itoa(i)); i++ {
+		names = append(names, config.SocketConnectHost+strconv.Itoa(i), config.SocketConnectPort+strconv.Itoa(i))
+	}
+	return names
+}
+
+// sortedSessionIDs returns the IDs of sessions in a stable order.
+func sortedSessionIDs(sessions map[quickfix.SessionID]*quickfix.SessionSettings) []quickfix.SessionID {
+	ids := make([]quickfix.SessionID, 0, len(sessions))
+	for id := range sessions {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+	return ids
+}
+
+// sessionSettings returns the settings the client's session uses: the global
+// settings overlaid with its [SESSION] section, or the global settings alone
+// when there is no session yet. A client runs one session; of several, the
+// first in ID order is read.
+func sessionSettings(settings *quickfix.Settings) *quickfix.SessionSettings {
+	sessions := settings.SessionSettings()
+	if ids := sortedSessionIDs(sessions); len(ids) > 0 {
+		return sessions[ids[0]]
+	}
+	return settings.GlobalSettings()
+}
+
+// updateSessionSettings applies update to the settings of each session, in
+// place. quickfix doesn't expose the [SESSION] sections, so each is replaced
+// by the global settings overlaid with it, as updated.
+func updateSessionSettings(settings *quickfix.Settings, update func(*quickfix.SessionSettings)) error {
+	updated := quickfix.NewSettings()
+	*updated.GlobalSettings() = *settings.GlobalSettings()
+	for _, session := range settings.SessionSettings() {
+		update(session)
+		if _, err := updated.AddSession(session); err != nil {
+			return err
+		}
+	}
+	*settings = *updated
+	return nil
+}
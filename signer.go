@@ -0,0 +1,46 @@
+package fix
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+
+	"github.com/quickfixgo/enum"
+)
+
+// WithSigner signs the logon with signer instead of a private key in Config,
+// so the key can stay in an HSM, a cloud KMS or a remote signing service.
+// The signer must hold an Ed25519 key and is called from the session
+// goroutine on every logon.
+func WithSigner(signer crypto.Signer) NewClientOption {
+	return func(o *Options) {
+		o.signer = signer
+	}
+}
+
+// SignLogonRawData creates the authentication signature for the FIX logon
+// with signer, which must hold an Ed25519 key.
+func SignLogonRawData(
+	signer crypto.Signer,
+	senderCompID, targetCompID, sendingTime string,
+) (string, error) {
+	if _, ok := signer.Public().(ed25519.PublicKey); !ok {
+		return "", ErrInvalidEd25519Key
+	}
+	// Ed25519 signs the message itself, hence no hash function.
+	data, err := signer.Sign(rand.Reader, logonPayload(senderCompID, targetCompID, sendingTime), crypto.Hash(0))
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// logonPayload is the message Binance verifies the logon signature against.
+func logonPayload(senderCompID, targetCompID, sendingTime string) []byte {
+	method := string(enum.MsgType_LOGON)
+	msgSeqNum := "1" // Logon is the first request of fix protocol.
+	return []byte(strings.Join([]string{method, senderCompID, targetCompID, msgSeqNum, sendingTime}, "\x01"))
+}
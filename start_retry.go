@@ -0,0 +1,109 @@
+package fix
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy is how StartWithRetry backs off between failed attempts to
+// connect and log on. Zero durations and multiplier take the values of
+// DefaultRetryPolicy.
+type RetryPolicy struct {
+	// InitialBackoff is the wait after the first failed attempt, multiplied
+	// by Multiplier after every other one up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	// Jitter randomizes every wait by up to this fraction of it, either way,
+	// e.g. 0.2 for ±20%, so clients restarted together don't retry together.
+	Jitter float64
+	// MaxAttempts gives up after that many attempts; 0 retries until the
+	// context is done.
+	MaxAttempts int
+	// OnRetry, if set, is called with every failed attempt before waiting.
+	OnRetry func(attempt int, err error, wait time.Duration)
+}
+
+// DefaultRetryPolicy retries every second at first, backing off to once a
+// minute.
+var DefaultRetryPolicy = RetryPolicy{
+	InitialBackoff: time.Second,
+	MaxBackoff:     time.Minute,
+	Multiplier:     2,
+	Jitter:         0.2,
+}
+
+// backoff returns the wait after the given failed attempt, counted from 1.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	initial, maxBackoff, multiplier := p.InitialBackoff, p.MaxBackoff, p.Multiplier
+	if initial <= 0 {
+		initial = DefaultRetryPolicy.InitialBackoff
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultRetryPolicy.MaxBackoff
+	}
+	if multiplier < 1 {
+		multiplier = DefaultRetryPolicy.Multiplier
+	}
+
+	wait := float64(initial)
+	for i := 1; i < attempt && wait < float64(maxBackoff); i++ {
+		wait *= multiplier
+	}
+	wait = min(wait, float64(maxBackoff))
+	if p.Jitter > 0 {
+		wait *= 1 + p.Jitter*(2*rand.Float64()-1)
+	}
+	return time.Duration(wait)
+}
+
+// StartWithRetry is Start for long-running daemons: when connecting or
+// logging on fails, e.g. because the gateway is unreachable or rejects the
+// logon, it waits as policy says and tries again until it succeeds, ctx is
+// done or policy.MaxAttempts is reached. It returns the error of the last
+// attempt, or that of ctx. Each attempt is bounded by WithLogonTimeout.
+func (c *Client) StartWithRetry(ctx context.Context, policy RetryPolicy) error {
+	for attempt := 1; ; attempt++ {
+		err := c.Start(ctx)
+		if err == nil {
+			return nil
+		}
+		// quickfix keeps dialing after a failed Start, so the attempt is
+		// stopped for good before the next one.
+		if resetErr := c.resetInitiator(); resetErr != nil {
+			return resetErr
+		}
+		if ctx.Err() != nil || (policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts) {
+			return err
+		}
+
+		wait := policy.backoff(attempt)
+		c.logger().Warnw("Failed to start session, retrying", "attempt", attempt, "wait", wait, "err", err)
+		if policy.OnRetry != nil {
+			policy.OnRetry(attempt, err, wait)
+		}
+		select {
+		case <-c.after(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// resetInitiator stops the session after a failed Start and recreates it, so
+// Start can be called again.
+func (c *Client) resetInitiator() error {
+	c.rotateMu.Lock()
+	defer c.rotateMu.Unlock()
+
+	c.stopStaleWatchdog()
+	c.initiator.Stop()
+	initiator, err := c.newInitiator()
+	c.setState(StateDisconnected)
+	if err != nil {
+		return err
+	}
+	c.initiator = initiator
+	return nil
+}
@@ -0,0 +1,105 @@
+package fix
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/quickfixgo/quickfix"
+)
+
+// SessionState is the lifecycle state of the FIX session.
+type SessionState int32
+
+const (
+	// StateDisconnected is the state before Start and after Stop.
+	StateDisconnected SessionState = iota
+	// StateConnecting means Start was called and the first connection is
+	// being dialed.
+	StateConnecting
+	// StateLogonSent means the Logon was sent and the answer is pending.
+	StateLogonSent
+	// StateActive means the session is logged on and messages can be sent.
+	StateActive
+	// StateReconnecting means the session dropped and quickfix is dialing
+	// again.
+	StateReconnecting
+	// StateStopping means Stop was called and the session is being closed.
+	StateStopping
+)
+
+func (s SessionState) String() string {
+	switch s {
+	case StateDisconnected:
+		return "Disconnected"
+	case StateConnecting:
+		return "Connecting"
+	case StateLogonSent:
+		return "LogonSent"
+	case StateActive:
+		return "Active"
+	case StateReconnecting:
+		return "Reconnecting"
+	case StateStopping:
+		return "Stopping"
+	default:
+		return "Unknown"
+	}
+}
+
+// StateChange is emitted on every session state transition.
+type StateChange struct {
+	SessionID quickfix.SessionID
+	From      SessionState
+	To        SessionState
+	Time      time.Time
+}
+
+// sessionState holds the current state. Transitions are delivered to
+// subscribers in order by whichever goroutine is not already delivering, so
+// a subscriber may call back into the client, e.g. Stop, without deadlocking.
+type sessionState struct {
+	mu       sync.Mutex // serializes transitions
+	current  atomic.Int32
+	queue    []*StateChange
+	draining bool
+}
+
+// State returns the current session state.
+func (c *Client) State() SessionState {
+	return SessionState(c.state.current.Load())
+}
+
+// IsConnected reports whether the session is logged on.
+func (c *Client) IsConnected() bool {
+	return c.State() == StateActive
+}
+
+// setState moves the session to state to, unless it is stopping and to is
+// anything but Disconnected.
+func (c *Client) setState(to SessionState) {
+	s := &c.state
+	s.mu.Lock()
+	from := SessionState(s.current.Load())
+	if from == to || (from == StateStopping && to != StateDisconnected) {
+		s.mu.Unlock()
+		return
+	}
+	s.current.Store(int32(to))
+	s.queue = append(s.queue, &StateChange{SessionID: c.sessionID, From: from, To: to, Time: c.now()})
+	if s.draining {
+		s.mu.Unlock()
+		return
+	}
+	s.draining = true
+
+	for len(s.queue) > 0 {
+		e := s.queue[0]
+		s.queue = s.queue[1:]
+		s.mu.Unlock()
+		c.emit(StateChangeTopic, e)
+		s.mu.Lock()
+	}
+	s.draining = false
+	s.mu.Unlock()
+}
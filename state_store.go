@@ -0,0 +1,277 @@
+package fix
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ljm2ya/binance_fix_api/handlers"
+)
+
+// OpenOrdersStateKey is the key the client checkpoints its open orders under.
+const OpenOrdersStateKey = "open_orders"
+
+var (
+	// ErrStateNotFound is returned by StateStore.Load for a key never saved.
+	ErrStateNotFound = errors.New("state not found")
+	// ErrNoStateStore is returned by TrackState and Checkpoint without
+	// WithStateStore.
+	ErrNoStateStore = errors.New("no state store configured")
+)
+
+// StateStore persists named blobs of state, see WithStateStore.
+type StateStore interface {
+	Save(key string, data []byte) error
+	// Load returns the data last saved under key, or ErrStateNotFound.
+	Load(key string) ([]byte, error)
+}
+
+// Checkpointable is state that can be saved to a StateStore and restored from
+// it, such as a positions.Book.
+type Checkpointable interface {
+	MarshalState() ([]byte, error)
+	RestoreState(data []byte) error
+}
+
+// WithStateStore restores the open orders the client tracks from store in
+// NewClient and checkpoints them, with the state passed to TrackState, every
+// interval while the client is started and once more on Stop. After a crash
+// the client resumes from the last checkpoint instead of replaying the whole
+// session; with WithCancelOnDisconnect the restored orders are purged at the
+// first logon. A zero interval only checkpoints on Stop and Checkpoint.
+func WithStateStore(store StateStore, interval time.Duration) NewClientOption {
+	return func(o *Options) {
+		o.stateStore = store
+		o.checkpointInterval = interval
+	}
+}
+
+// checkpointer saves the tracked state to the store periodically.
+type checkpointer struct {
+	store    StateStore
+	interval time.Duration
+	logger   func() Logger
+
+	mu      sync.Mutex
+	tracked map[string]Checkpointable
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+func newCheckpointer(store StateStore, interval time.Duration, logger func() Logger) *checkpointer {
+	return &checkpointer{store: store, interval: interval, logger: logger, tracked: make(map[string]Checkpointable)}
+}
+
+// track restores s from the store and adds it to the checkpoints.
+func (p *checkpointer) track(key string, s Checkpointable) error {
+	data, err := p.store.Load(key)
+	switch {
+	case errors.Is(err, ErrStateNotFound):
+	case err != nil:
+		return err
+	default:
+		if err := s.RestoreState(data); err != nil {
+			return err
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.tracked[key] = s
+	return nil
+}
+
+// save checkpoints every tracked state, returning the first error.
+func (p *checkpointer) save() error {
+	p.mu.Lock()
+	keys := make([]string, 0, len(p.tracked))
+	states := make(map[string]Checkpointable, len(p.tracked))
+	for key, s := range p.tracked {
+		keys = append(keys, key)
+		states[key] = s
+	}
+	p.mu.Unlock()
+	sort.Strings(keys)
+
+	var first error
+	for _, key := range keys {
+		s := states[key]
+		data, err := s.MarshalState()
+		if err == nil {
+			err = p.store.Save(key, data)
+		}
+		if err != nil {
+			p.logger().Errorw("Failed to checkpoint state", "key", key, "err", err)
+			if first == nil {
+				first = err
+			}
+		}
+	}
+	return first
+}
+
+// start runs the periodic checkpoints unless they already run.
+func (p *checkpointer) start() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.interval <= 0 || p.stop != nil {
+		return
+	}
+
+	p.stop, p.done = make(chan struct{}), make(chan struct{})
+	go func(stop, done chan struct{}) {
+		defer close(done)
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				_ = p.save()
+			case <-stop:
+				return
+			}
+		}
+	}(p.stop, p.done)
+}
+
+// halt stops the periodic checkpoints and saves a last time.
+func (p *checkpointer) halt() {
+	p.mu.Lock()
+	stop, done := p.stop, p.done
+	p.stop, p.done = nil, nil
+	p.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+	_ = p.save()
+}
+
+// TrackState restores s from the StateStore of WithStateStore and includes it
+// in the client's checkpoints under key. It fails with ErrNoStateStore when
+// the client has none.
+func (c *Client) TrackState(key string, s Checkpointable) error {
+	if c.checkpoints == nil {
+		return ErrNoStateStore
+	}
+	return c.checkpoints.track(key, s)
+}
+
+// Checkpoint saves the tracked state now.
+func (c *Client) Checkpoint() error {
+	if c.checkpoints == nil {
+		return ErrNoStateStore
+	}
+	return c.checkpoints.save()
+}
+
+// openOrdersState is the checkpoint of openOrders.
+type openOrdersState struct {
+	Orders []openOrderState `json:"orders"`
+	Purge  []string         `json:"purge,omitempty"`
+}
+
+type openOrderState struct {
+	Symbol        string               `json:"symbol"`
+	OrderID       int64                `json:"order_id"`
+	ClientOrderID string               `json:"client_order_id"`
+	Status        handlers.OrderStatus `json:"status"`
+	Side          handlers.SideType    `json:"side,omitempty"`
+	CumQty        float64              `json:"cum_qty,omitempty"`
+}
+
+// checkpointedOpenOrders adapts openOrders to Checkpointable.
+type checkpointedOpenOrders struct {
+	o                  *openOrders
+	cancelOnDisconnect bool
+}
+
+func (s checkpointedOpenOrders) MarshalState() ([]byte, error) {
+	s.o.mu.Lock()
+	state := openOrdersState{Purge: append([]string(nil), s.o.purge...)}
+	for _, orders := range s.o.bySymbol {
+		for _, order := range orders {
+			state.Orders = append(state.Orders, openOrderState{
+				Symbol:        order.Symbol,
+				OrderID:       order.OrderID,
+				ClientOrderID: order.ClientOrderID,
+				Status:        order.Status,
+				Side:          order.Side,
+				CumQty:        order.CumQty,
+			})
+		}
+	}
+	s.o.mu.Unlock()
+
+	sort.Slice(state.Orders, func(i, j int) bool { return state.Orders[i].OrderID < state.Orders[j].OrderID })
+	return json.Marshal(state)
+}
+
+func (s checkpointedOpenOrders) RestoreState(data []byte) error {
+	var state openOrdersState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+
+	s.o.restore(state)
+	if s.cancelOnDisconnect {
+		// The session of the previous run is gone, like after a disconnect.
+		s.o.disconnected()
+	}
+	return nil
+}
+
+// JSONFileStateStore is a StateStore keeping every key in a file of its own
+// in a directory, replaced atomically on each save.
+type JSONFileStateStore struct {
+	dir string
+}
+
+// NewJSONFileStateStore creates a store in dir, creating the directory if
+// needed.
+func NewJSONFileStateStore(dir string) (*JSONFileStateStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &JSONFileStateStore{dir: dir}, nil
+}
+
+func (s *JSONFileStateStore) path(key string) string {
+	return filepath.Join(s.dir, key+".json")
+}
+
+func (s *JSONFileStateStore) Save(key string, data []byte) error {
+	path := s.path(key)
+	tmp, err := os.CreateTemp(s.dir, filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *JSONFileStateStore) Load(key string) ([]byte, error) {
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrStateNotFound
+	}
+	return data, err
+}
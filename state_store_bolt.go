@@ -0,0 +1,62 @@
+package fix
+
+import (
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var stateBucket = []byte("state")
+
+// BoltStateStore is a StateStore in a BoltDB file, for when the state is saved
+// often or alongside other data of the application in the same database.
+type BoltStateStore struct {
+	db *bolt.DB
+}
+
+// OpenBoltStateStore opens or creates the BoltDB file at path. BoltDB locks
+// the file, so it fails after a second when another process has it open.
+func OpenBoltStateStore(path string) (*BoltStateStore, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+	return NewBoltStateStore(db)
+}
+
+// NewBoltStateStore keeps the state in the "state" bucket of an open db.
+func NewBoltStateStore(db *bolt.DB) (*BoltStateStore, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(stateBucket)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &BoltStateStore{db: db}, nil
+}
+
+func (s *BoltStateStore) Save(key string, data []byte) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(stateBucket).Put([]byte(key), data)
+	})
+}
+
+func (s *BoltStateStore) Load(key string) ([]byte, error) {
+	var data []byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(stateBucket).Get([]byte(key))
+		if v == nil {
+			return ErrStateNotFound
+		}
+		// v is only valid for the life of the transaction.
+		data = append([]byte(nil), v...)
+		return nil
+	})
+	return data, err
+}
+
+// Close closes the database.
+func (s *BoltStateStore) Close() error {
+	return s.db.Close()
+}
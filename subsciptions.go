@@ -8,8 +8,152 @@ func (c *Client) SubscribeToExecutionReport(listener ExecutionReportHandler) {
 	c.emitter.On(ExecutionReportTopic, listener)
 }
 
+// SubscribeToExecutionReportForSymbol notifies about order updates of a single
+// symbol only.
+func (c *Client) SubscribeToExecutionReportForSymbol(symbol string, listener ExecutionReportHandler) {
+	c.execRoutes.addSymbol(symbol, listener)
+}
+
+// SubscribeToExecutionReportForPrefix notifies about order updates whose
+// ClOrdID starts with prefix, e.g. the orders of one strategy.
+func (c *Client) SubscribeToExecutionReportForPrefix(prefix string, listener ExecutionReportHandler) {
+	c.execRoutes.addPrefix(prefix, listener)
+}
+
+type ListStatusHandler func(l *handlers.ListStatus)
+
+// SubscribeToListStatus notifies about order list (OCO, OTO) state changes.
+func (c *Client) SubscribeToListStatus(listener ListStatusHandler) {
+	c.emitter.On(ListStatusTopic, listener)
+}
+
+type CancelRejectHandler func(r *handlers.CancelReject)
+
+// SubscribeToCancelReject notifies about rejected cancel requests.
+func (c *Client) SubscribeToCancelReject(listener CancelRejectHandler) {
+	c.emitter.On(CancelRejectTopic, listener)
+}
+
 type TradeStreamHandler func(trade *handlers.Trade)
 
 func (c *Client) SubscribeToTradeStream(listener TradeStreamHandler) {
 	c.emitter.On(TradeStreamTopic, listener)
 }
+
+type AggTradeHandler func(trade *handlers.AggTrade)
+
+// SubscribeToAggTradeStream notifies about the aggregated trades of the
+// symbols subscribed with SubscribeToAggTrades.
+func (c *Client) SubscribeToAggTradeStream(listener AggTradeHandler) {
+	c.emitter.On(AggTradeTopic, listener)
+}
+
+type CircuitOpenHandler func(e *CircuitOpen)
+
+// SubscribeToCircuitOpen notifies when the circuit breaker starts blocking
+// orders, see WithCircuitBreaker.
+func (c *Client) SubscribeToCircuitOpen(listener CircuitOpenHandler) {
+	c.emitter.On(CircuitOpenTopic, listener)
+}
+
+type StateChangeHandler func(e *StateChange)
+
+// SubscribeToStateChange notifies about every session state transition.
+func (c *Client) SubscribeToStateChange(listener StateChangeHandler) {
+	c.emitter.On(StateChangeTopic, listener)
+}
+
+type ClockDriftHandler func(e *ClockDrift)
+
+// SubscribeToClockDrift notifies when the local clock drifts from the server
+// clock by more than the tolerance, see WithClockDriftTolerance.
+func (c *Client) SubscribeToClockDrift(listener ClockDriftHandler) {
+	c.emitter.On(ClockDriftTopic, listener)
+}
+
+type ValidationErrorHandler func(e *ValidationError)
+
+// SubscribeToValidationError notifies about messages that fail validation
+// against the data dictionary, see WithDataDictionary.
+func (c *Client) SubscribeToValidationError(listener ValidationErrorHandler) {
+	c.emitter.On(ValidationErrorTopic, listener)
+}
+
+type ConnectionStaleHandler func(e *ConnectionStale)
+
+// SubscribeToConnectionStale notifies when heartbeats are missed on a live session.
+func (c *Client) SubscribeToConnectionStale(listener ConnectionStaleHandler) {
+	c.emitter.On(ConnectionStaleTopic, listener)
+}
+
+type GapDetectedHandler func(e *GapDetected)
+
+// SubscribeToGapDetected notifies about missed messages of the session and
+// gaps in the book update IDs of a symbol.
+func (c *Client) SubscribeToGapDetected(listener GapDetectedHandler) {
+	c.emitter.On(GapDetectedTopic, listener)
+}
+
+type MarketDataSnapshotHandler func(s *MarketDataSnapshot)
+
+// SubscribeToMarketDataSnapshot notifies about the order book snapshots
+// requested by WithGapRecovery.
+func (c *Client) SubscribeToMarketDataSnapshot(listener MarketDataSnapshotHandler) {
+	c.emitter.On(MarketDataSnapshotTopic, listener)
+}
+
+type DepthUpdateHandler func(u *DepthUpdate)
+
+// SubscribeToDepthUpdate notifies about the updates of the diff depth streams
+// subscribed with SubscribeToDepth.
+func (c *Client) SubscribeToDepthUpdate(listener DepthUpdateHandler) {
+	c.emitter.On(DepthUpdateTopic, listener)
+}
+
+type SymbolStaleHandler func(e *SymbolStale)
+
+// SubscribeToSymbolStale notifies about subscribed symbols without market
+// data updates, see WithSymbolStaleTimeout.
+func (c *Client) SubscribeToSymbolStale(listener SymbolStaleHandler) {
+	c.emitter.On(SymbolStaleTopic, listener)
+}
+
+type OrderReconciledHandler func(e *OrderReconciled)
+
+// SubscribeToOrderReconciled notifies about orders whose state on the
+// exchange differed from the tracked one, see ReconcileOpenOrders.
+func (c *Client) SubscribeToOrderReconciled(listener OrderReconciledHandler) {
+	c.emitter.On(OrderReconciledTopic, listener)
+}
+
+type BudgetWarningHandler func(e *BudgetWarning)
+
+// SubscribeToBudgetWarning notifies when the session is close to a message
+// budget, see WithBudgetWarning.
+func (c *Client) SubscribeToBudgetWarning(listener BudgetWarningHandler) {
+	c.emitter.On(BudgetWarningTopic, listener)
+}
+
+type DecodeErrorHandler func(e *DecodeError)
+
+// SubscribeToDecodeError notifies about inbound messages that failed to
+// decode and were dropped, e.g. to alert when Binance changes a message.
+func (c *Client) SubscribeToDecodeError(listener DecodeErrorHandler) {
+	c.emitter.On(DecodeErrorTopic, listener)
+}
+
+type UnknownMessageHandler func(m *UnknownMessage)
+
+// SubscribeToUnknownMessage notifies about inbound messages of a MsgType the
+// client doesn't handle, e.g. to notice when Binance extends the protocol.
+func (c *Client) SubscribeToUnknownMessage(listener UnknownMessageHandler) {
+	c.emitter.On(UnknownMessageTopic, listener)
+}
+
+type LateResponseHandler func(r *LateResponse)
+
+// SubscribeToLateResponse notifies about responses that arrived after their
+// call returned, e.g. timed out, with the request ID of the call.
+func (c *Client) SubscribeToLateResponse(listener LateResponseHandler) {
+	c.emitter.On(LateResponseTopic, listener)
+}
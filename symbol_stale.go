@@ -0,0 +1,132 @@
+package fix
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// SymbolStale is emitted when no trade or depth update arrived for a
+// subscribed symbol for longer than the window of WithSymbolStaleTimeout while
+// the session is logged on, e.g. because the exchange silently dropped the
+// subscription. It is emitted once until updates of the symbol resume.
+type SymbolStale struct {
+	Symbol     string
+	LastUpdate time.Time // or when the watch started, without any update
+	Silence    time.Duration
+}
+
+// WithSymbolStaleTimeout watches every symbol subscribed with
+// SubscribeToTrades, NewTradeSubscription or SubscribeToDepth and emits a
+// SymbolStale to SubscribeToSymbolStale listeners when none of its updates
+// arrived for window. Pick a window longer than the quietest symbol trades.
+func WithSymbolStaleTimeout(window time.Duration) NewClientOption {
+	return func(o *Options) {
+		o.symbolStaleWindow = window
+	}
+}
+
+// symbolWatch remembers when the last update of each symbol arrived.
+type symbolWatch struct {
+	mu    sync.Mutex
+	last  map[string]time.Time
+	stale map[string]bool
+	stop  chan struct{}
+}
+
+// symbolUpdated records an update of symbol.
+func (c *Client) symbolUpdated(symbol string) {
+	if c.options.symbolStaleWindow <= 0 {
+		return
+	}
+	w := &c.symbolWatch
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.last == nil {
+		w.last, w.stale = make(map[string]time.Time), make(map[string]bool)
+	}
+	w.last[symbol] = c.now()
+	delete(w.stale, symbol)
+}
+
+// startSymbolWatchdog watches the subscribed symbols while the session is
+// logged on. Silence from before the logon doesn't count.
+func (c *Client) startSymbolWatchdog() {
+	window := c.options.symbolStaleWindow
+	if window <= 0 {
+		return
+	}
+
+	w := &c.symbolWatch
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stop != nil {
+		close(w.stop)
+	}
+	w.stop = make(chan struct{})
+	w.last, w.stale = make(map[string]time.Time), make(map[string]bool)
+	go c.watchSymbols(c.Context(), window, w.stop)
+}
+
+func (c *Client) stopSymbolWatchdog() {
+	w := &c.symbolWatch
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stop != nil {
+		close(w.stop)
+		w.stop = nil
+	}
+}
+
+func (c *Client) watchSymbols(ctx context.Context, window time.Duration, stop <-chan struct{}) {
+	interval := max(window/10, minStaleCheckInterval)
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ctx.Done():
+			return
+		case now := <-c.after(interval):
+			for _, e := range c.staleSymbols(now, window) {
+				c.logger().Warnw("No market data for symbol", "symbol", e.Symbol, "silence", e.Silence)
+				c.emit(SymbolStaleTopic, e)
+			}
+		}
+	}
+}
+
+// staleSymbols returns the subscribed symbols that just went stale at now.
+func (c *Client) staleSymbols(now time.Time, window time.Duration) []*SymbolStale {
+	subscribed := make(map[string]struct{})
+	for _, symbol := range c.tradeSymbols.list() {
+		subscribed[symbol] = struct{}{}
+	}
+	for _, symbol := range c.depthSymbols.list() {
+		subscribed[symbol] = struct{}{}
+	}
+
+	w := &c.symbolWatch
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var stale []*SymbolStale
+	for symbol := range subscribed {
+		last, ok := w.last[symbol]
+		if !ok {
+			// Newly subscribed: the watch starts now.
+			w.last[symbol] = now
+			continue
+		}
+		if silence := now.Sub(last); silence > window && !w.stale[symbol] {
+			w.stale[symbol] = true
+			stale = append(stale, &SymbolStale{Symbol: symbol, LastUpdate: last, Silence: silence})
+		}
+	}
+	for symbol := range w.last {
+		if _, ok := subscribed[symbol]; !ok {
+			delete(w.last, symbol)
+			delete(w.stale, symbol)
+		}
+	}
+	return stale
+}
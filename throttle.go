@@ -0,0 +1,55 @@
+package fix
+
+import (
+	"context"
+	"sync"
+)
+
+// WithSymbolInFlightLimit allows at most n order placements and cancels of a
+// symbol to await their response at once. Further requests of the symbol
+// queue in order until one is answered or their context is done, instead of
+// being rejected by the exchange for sending too many requests for the
+// symbol.
+func WithSymbolInFlightLimit(n int) NewClientOption {
+	return func(o *Options) {
+		o.symbolInFlightLimit = n
+	}
+}
+
+// symbolThrottle holds a semaphore of in-flight requests per symbol.
+type symbolThrottle struct {
+	limit int
+	mu    sync.Mutex
+	slots map[string]chan struct{}
+}
+
+func newSymbolThrottle(limit int) *symbolThrottle {
+	return &symbolThrottle{limit: limit, slots: make(map[string]chan struct{})}
+}
+
+// acquire waits for a free slot of symbol. Waiters are served in order.
+func (t *symbolThrottle) acquire(ctx context.Context, symbol string) (release func(), err error) {
+	t.mu.Lock()
+	slots, ok := t.slots[symbol]
+	if !ok {
+		slots = make(chan struct{}, t.limit)
+		t.slots[symbol] = slots
+	}
+	t.mu.Unlock()
+
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// acquireSymbol takes an in-flight slot of symbol, see
+// WithSymbolInFlightLimit. The returned release gives it back.
+func (c *Client) acquireSymbol(ctx context.Context, symbol string) (release func(), err error) {
+	if c.throttle == nil {
+		return func() {}, nil
+	}
+	return c.throttle.acquire(ctx, symbol)
+}
@@ -0,0 +1,286 @@
+// Package tickrec records the trade stream and order book snapshots to CSV or
+// Parquet files, to collect tick data for research directly from the FIX
+// market data session.
+//
+// Trades and book levels are normalized into one Record layout, so a file can
+// hold both. Files are rotated after an interval, aligned to multiples of it in
+// UTC, or after a number of records, whichever comes first.
+package tickrec
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	fix "github.com/ljm2ya/binance_fix_api"
+	"github.com/ljm2ya/binance_fix_api/handlers"
+)
+
+// ErrClosed is returned when writing to a closed Recorder.
+var ErrClosed = errors.New("recorder closed")
+
+// Kind is what a Record describes.
+type Kind string
+
+const (
+	KindTrade Kind = "trade"
+	KindBid   Kind = "bid"
+	KindAsk   Kind = "ask"
+)
+
+// Record is a normalized trade or book level.
+type Record struct {
+	Time         time.Time // trade time, or time the snapshot was recorded
+	Symbol       string
+	Kind         Kind
+	TradeID      int64 // trades only
+	Level        int   // book levels only, 0 is the best price
+	UpdateID     int64 // book levels only, LastBookUpdateID of the snapshot
+	Price        float64
+	Quantity     float64
+	IsBuyerMaker bool // trades only
+}
+
+// Format is the file format of a Recorder.
+type Format int
+
+const (
+	CSV Format = iota
+	Parquet
+)
+
+func (f Format) ext() string {
+	if f == Parquet {
+		return ".parquet"
+	}
+	return ".csv"
+}
+
+type Option func(r *Recorder)
+
+// WithFormat sets the file format, CSV by default.
+func WithFormat(format Format) Option {
+	return func(r *Recorder) {
+		r.format = format
+	}
+}
+
+// WithPrefix sets the prefix of the file names, "ticks" by default.
+func WithPrefix(prefix string) Option {
+	return func(r *Recorder) {
+		r.prefix = prefix
+	}
+}
+
+// WithRotation starts a new file every interval and after maxRecords records.
+// Zero disables either limit. The default is one file per hour.
+func WithRotation(interval time.Duration, maxRecords int) Option {
+	return func(r *Recorder) {
+		r.interval = interval
+		r.maxRecords = maxRecords
+	}
+}
+
+// WithErrorHandler is called with the errors of HandleTrade and
+// HandleSnapshot, which have no way to return them.
+func WithErrorHandler(onError func(err error)) Option {
+	return func(r *Recorder) {
+		r.onError = onError
+	}
+}
+
+// recordWriter writes the records of one file.
+type recordWriter interface {
+	write(records []Record) error
+	close() error
+}
+
+// Recorder writes records to rotated files in a directory.
+type Recorder struct {
+	dir        string
+	prefix     string
+	format     Format
+	interval   time.Duration
+	maxRecords int
+	onError    func(err error)
+	now        func() time.Time
+
+	mu      sync.Mutex
+	w       recordWriter
+	path    string
+	until   time.Time // end of the interval of the current file
+	records int       // in the current file
+	closed  bool
+}
+
+// New creates a Recorder writing to dir, creating the directory if needed.
+// Files are only created once there is something to write.
+func New(dir string, opts ...Option) (*Recorder, error) {
+	r := &Recorder{
+		dir:      dir,
+		prefix:   "ticks",
+		interval: time.Hour,
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// HandleTrade records a trade. Its signature matches fix.TradeStreamHandler so
+// it can be passed to Client.SubscribeToTradeStream directly.
+func (r *Recorder) HandleTrade(trade *handlers.Trade) {
+	r.handle(r.Write(Record{
+		Time:         trade.TradeTime,
+		Symbol:       trade.Symbol,
+		Kind:         KindTrade,
+		TradeID:      trade.TradeID,
+		Price:        trade.Price,
+		Quantity:     trade.Quantity,
+		IsBuyerMaker: trade.IsBuyerMaker,
+	}))
+}
+
+// HandleSnapshot records every level of a book snapshot. Its signature matches
+// fix.MarketDataSnapshotHandler so it can be passed to
+// Client.SubscribeToMarketDataSnapshot directly.
+func (r *Recorder) HandleSnapshot(s *fix.MarketDataSnapshot) {
+	now := r.now()
+	records := make([]Record, 0, len(s.Bids)+len(s.Asks))
+	records = appendLevels(records, s, KindBid, s.Bids, now)
+	records = appendLevels(records, s, KindAsk, s.Asks, now)
+	r.handle(r.Write(records...))
+}
+
+func appendLevels(records []Record, s *fix.MarketDataSnapshot, kind Kind, levels []fix.BookLevel, now time.Time) []Record {
+	for i, level := range levels {
+		records = append(records, Record{
+			Time:     now,
+			Symbol:   s.Symbol,
+			Kind:     kind,
+			Level:    i,
+			UpdateID: s.LastBookUpdateID,
+			Price:    level.Price,
+			Quantity: level.Quantity,
+		})
+	}
+	return records
+}
+
+func (r *Recorder) handle(err error) {
+	if err != nil && r.onError != nil {
+		r.onError(err)
+	}
+}
+
+// Write appends records to the current file, rotating it first when due.
+func (r *Recorder) Write(records ...Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return ErrClosed
+	}
+	now := r.now()
+	if r.w != nil && (!r.until.IsZero() && !now.Before(r.until) ||
+		r.maxRecords > 0 && r.records >= r.maxRecords) {
+		if err := r.rotate(); err != nil {
+			return err
+		}
+	}
+	if r.w == nil {
+		if err := r.open(now); err != nil {
+			return err
+		}
+	}
+
+	if err := r.w.write(records); err != nil {
+		return fmt.Errorf("write %s: %w", r.path, err)
+	}
+	r.records += len(records)
+	return nil
+}
+
+// open creates the file of the interval containing now.
+func (r *Recorder) open(now time.Time) error {
+	start := now.UTC()
+	r.until = time.Time{}
+	if r.interval > 0 {
+		start = start.Truncate(r.interval)
+		r.until = start.Add(r.interval)
+	}
+
+	base := filepath.Join(r.dir, r.prefix+"-"+start.Format("20060102T150405"))
+	var f *os.File
+	for n := 0; ; n++ {
+		path := base + r.format.ext()
+		if n > 0 {
+			// Rotated by maxRecords within the same interval.
+			path = fmt.Sprintf("%s-%d%s", base, n, r.format.ext())
+		}
+		var err error
+		f, err = os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if errors.Is(err, os.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		r.path = path
+		break
+	}
+
+	var err error
+	if r.format == Parquet {
+		r.w, err = newParquetWriter(f)
+	} else {
+		r.w, err = newCSVWriter(f)
+	}
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("open %s: %w", r.path, err)
+	}
+	r.records = 0
+	return nil
+}
+
+// rotate closes the current file; the next write opens a new one.
+func (r *Recorder) rotate() error {
+	if r.w == nil {
+		return nil
+	}
+	err := r.w.close()
+	r.w = nil
+	if err != nil {
+		return fmt.Errorf("close %s: %w", r.path, err)
+	}
+	return nil
+}
+
+// Rotate closes the current file so the next record starts a new one, e.g.
+// to hand the file over to another process.
+func (r *Recorder) Rotate() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rotate()
+}
+
+// Close closes the current file. A Parquet file is only readable once closed.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.closed = true
+	return r.rotate()
+}
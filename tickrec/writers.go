@@ -0,0 +1,118 @@
+package tickrec
+
+import (
+	"bufio"
+	"encoding/csv"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+var csvHeader = []string{"time", "symbol", "kind", "trade_id", "level", "update_id", "price", "quantity", "is_buyer_maker"}
+
+type csvWriter struct {
+	f   *os.File
+	buf *bufio.Writer
+	w   *csv.Writer
+}
+
+func newCSVWriter(f *os.File) (*csvWriter, error) {
+	buf := bufio.NewWriter(f)
+	w := &csvWriter{f: f, buf: buf, w: csv.NewWriter(buf)}
+	if err := w.w.Write(csvHeader); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *csvWriter) write(records []Record) error {
+	row := make([]string, len(csvHeader))
+	for _, rec := range records {
+		row[0] = rec.Time.UTC().Format(time.RFC3339Nano)
+		row[1] = rec.Symbol
+		row[2] = string(rec.Kind)
+		row[3] = strconv.FormatInt(rec.TradeID, 10)
+		row[4] = strconv.Itoa(rec.Level)
+		row[5] = strconv.FormatInt(rec.UpdateID, 10)
+		row[6] = strconv.FormatFloat(rec.Price, 'f', -1, 64)
+		row[7] = strconv.FormatFloat(rec.Quantity, 'f', -1, 64)
+		row[8] = strconv.FormatBool(rec.IsBuyerMaker)
+		if err := w.w.Write(row); err != nil {
+			return err
+		}
+	}
+	// Flush to the file buffer only, so a crash loses at most a few rows.
+	w.w.Flush()
+	return w.w.Error()
+}
+
+func (w *csvWriter) close() error {
+	w.w.Flush()
+	err := w.w.Error()
+	if err == nil {
+		err = w.buf.Flush()
+	}
+	if cerr := w.f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// parquetRecord is the Parquet schema of Record.
+type parquetRecord struct {
+	Time         int64   `parquet:"name=time, type=INT64, convertedtype=TIMESTAMP_MICROS"`
+	Symbol       string  `parquet:"name=symbol, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	Kind         string  `parquet:"name=kind, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	TradeID      int64   `parquet:"name=trade_id, type=INT64"`
+	Level        int32   `parquet:"name=level, type=INT32"`
+	UpdateID     int64   `parquet:"name=update_id, type=INT64"`
+	Price        float64 `parquet:"name=price, type=DOUBLE"`
+	Quantity     float64 `parquet:"name=quantity, type=DOUBLE"`
+	IsBuyerMaker bool    `parquet:"name=is_buyer_maker, type=BOOLEAN"`
+}
+
+type parquetWriter struct {
+	f  *os.File
+	pw *writer.ParquetWriter
+}
+
+func newParquetWriter(f *os.File) (*parquetWriter, error) {
+	pw, err := writer.NewParquetWriterFromWriter(f, new(parquetRecord), 1)
+	if err != nil {
+		return nil, err
+	}
+	pw.CompressionType = parquet.CompressionCodec_SNAPPY
+	return &parquetWriter{f: f, pw: pw}, nil
+}
+
+func (w *parquetWriter) write(records []Record) error {
+	for _, rec := range records {
+		err := w.pw.Write(parquetRecord{
+			Time:         rec.Time.UnixMicro(),
+			Symbol:       rec.Symbol,
+			Kind:         string(rec.Kind),
+			TradeID:      rec.TradeID,
+			Level:        int32(rec.Level),
+			UpdateID:     rec.UpdateID,
+			Price:        rec.Price,
+			Quantity:     rec.Quantity,
+			IsBuyerMaker: rec.IsBuyerMaker,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// close writes the footer, without which the file is unreadable.
+func (w *parquetWriter) close() error {
+	err := w.pw.WriteStop()
+	if cerr := w.f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
@@ -0,0 +1,144 @@
+package fix
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// Clock is the source of time of a Client: the SendingTime of outgoing
+// messages, the timestamps in generated MDReqIDs, the times of events,
+// journal and audit records, the RetryAfter of rejects, the call and query
+// timeouts, the stale watchdogs, the circuit breaker, the outbox TTL, rate
+// limit pauses, start retries, aggregated trade flushes and maintenance
+// windows. Tests set a fake one with WithClock, e.g. fixtest.Clock, to freeze
+// or advance time and assert on timestamps and IDs. The heartbeats and
+// reconnects of the quickfix session, gateway failover, state checkpoints and
+// ClOrdIDGenerators, which are set with WithClOrdIDGenerator, keep the system
+// clock, as do the package level decoders such as DecodeResponse and
+// AuditLog.Append called directly.
+type Clock interface {
+	Now() time.Time
+	// After sends the time on the returned channel once d has passed.
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// SystemClock is the Clock of a Client without WithClock.
+var SystemClock Clock = systemClock{}
+
+// WithClock sets the Clock of the client, SystemClock by default. A fake
+// clock also stamps the SendingTime of every outgoing message, which a real
+// server rejects once it is off by more than its tolerance.
+func WithClock(clock Clock) NewClientOption {
+	return func(o *Options) {
+		o.clock = clock
+	}
+}
+
+// now returns the time of the client's Clock.
+func (c *Client) now() time.Time {
+	return c.options.clock.Now()
+}
+
+// after is time.After on the client's Clock.
+func (c *Client) after(d time.Duration) <-chan time.Time {
+	return c.options.clock.After(d)
+}
+
+// clockTimer is a timer started by afterFunc, *time.Timer on SystemClock.
+type clockTimer interface {
+	// Stop prevents the function from running and reports whether it did.
+	Stop() bool
+}
+
+// afterFunc is time.AfterFunc on the client's Clock.
+func (c *Client) afterFunc(d time.Duration, f func()) clockTimer {
+	return afterFunc(c.options.clock, d, f)
+}
+
+// afterFunc is time.AfterFunc on clock.
+func afterFunc(clock Clock, d time.Duration, f func()) clockTimer {
+	if _, ok := clock.(systemClock); ok {
+		return time.AfterFunc(d, f)
+	}
+
+	t := &funcTimer{stop: make(chan struct{})}
+	expired := clock.After(d)
+	go func() {
+		select {
+		case <-expired:
+			if t.done.CompareAndSwap(false, true) {
+				f()
+			}
+		case <-t.stop:
+		}
+	}()
+	return t
+}
+
+// funcTimer is the clockTimer of a Clock other than SystemClock.
+type funcTimer struct {
+	stop chan struct{}
+	done atomic.Bool // fired or stopped
+}
+
+func (t *funcTimer) Stop() bool {
+	if !t.done.CompareAndSwap(false, true) {
+		return false
+	}
+	close(t.stop)
+	return true
+}
+
+// since returns the time elapsed on the client's Clock since t.
+func (c *Client) since(t time.Time) time.Duration {
+	return c.now().Sub(t)
+}
+
+// withTimeout is context.WithTimeout timed by the client's Clock.
+func (c *Client) withTimeout(parent context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := c.options.clock.(systemClock); ok {
+		return context.WithTimeout(parent, d)
+	}
+
+	ctx, cancel := context.WithCancel(parent)
+	timed := &clockContext{Context: ctx, deadline: c.now().Add(d)}
+	expired := c.options.clock.After(d)
+	go func() {
+		select {
+		case <-expired:
+			timed.expired.Store(true)
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return timed, cancel
+}
+
+// clockContext is a context whose deadline is kept by a Clock.
+type clockContext struct {
+	context.Context
+	deadline time.Time
+	expired  atomic.Bool
+}
+
+func (c *clockContext) Deadline() (time.Time, bool) {
+	return c.deadline, true
+}
+
+func (c *clockContext) Err() error {
+	if c.expired.Load() {
+		return context.DeadlineExceeded
+	}
+	return c.Context.Err()
+}
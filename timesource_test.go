@@ -0,0 +1,114 @@
+package fix
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ljm2ya/binance_fix_api/fixtest"
+)
+
+type entryRecorder struct {
+	mu      sync.Mutex
+	entries []JournalEntry
+}
+
+func (r *entryRecorder) WriteEntry(entry JournalEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func TestClockStampsJournal(t *testing.T) {
+	// The gateway rejects a SendingTime too far off its own clock.
+	start := time.Now().UTC().Truncate(time.Second)
+	journal := &entryRecorder{}
+	client := startTestClient(t, WithClock(fixtest.NewClock(start)), WithRecorder(journal))
+
+	if got := client.SendingTime(); got != start.Format(utcTimestampMillisFmt) {
+		t.Fatalf("SendingTime %s, want %s", got, start.Format(utcTimestampMillisFmt))
+	}
+
+	journal.mu.Lock()
+	defer journal.mu.Unlock()
+	if len(journal.entries) == 0 {
+		t.Fatal("logon not journaled")
+	}
+	for _, e := range journal.entries {
+		if !e.Time.Equal(start) {
+			t.Fatalf("%s entry at %v, want %v", e.Direction, e.Time, start)
+		}
+	}
+}
+
+func TestClockStaleWatchdog(t *testing.T) {
+	start := time.Now().UTC().Truncate(time.Second)
+	clock := fixtest.NewClock(start)
+	client := startTestClient(t, WithClock(clock),
+		WithHeartbeatInterval(30*time.Second), WithTestRequestTimeout(5*time.Second))
+
+	stale := make(chan *ConnectionStale, 1)
+	client.SubscribeToConnectionStale(func(e *ConnectionStale) {
+		select {
+		case stale <- e:
+		default:
+		}
+	})
+
+	// The session is silent in real time; only the clock moves. The watchdog
+	// waits on the clock again after each check, so keep advancing it.
+	for i := 0; i < 60; i++ {
+		clock.Advance(time.Second)
+		select {
+		case e := <-stale:
+			if !e.LastReceived.Equal(start) {
+				t.Fatalf("last received %v, want %v", e.LastReceived, start)
+			}
+			if e.Silence <= 35*time.Second {
+				t.Fatalf("stale after %v of silence, want more than 35s", e.Silence)
+			}
+			return
+		case <-time.After(20 * time.Millisecond):
+		}
+	}
+	t.Fatal("no ConnectionStale after a minute of silence on the clock")
+}
+
+func TestMDReqIDUniqueOnFrozenClock(t *testing.T) {
+	c := &Client{options: defaultOpts()}
+	c.options.clock = fixtest.NewClock(time.Now())
+
+	first := c.mdReqID("MDD_%s_%d", "BTCUSDT", c.now().UnixNano())
+	second := c.mdReqID("MDD_%s_%d", "BTCUSDT", c.now().UnixNano())
+	if first == second {
+		t.Fatalf("both subscriptions got MDReqID %s", first)
+	}
+}
+
+func TestAfterFuncOnClock(t *testing.T) {
+	clock := fixtest.NewClock(time.Now())
+	fired := make(chan struct{}, 2)
+	afterFunc(clock, time.Minute, func() { fired <- struct{}{} })
+	stopped := afterFunc(clock, time.Minute, func() { fired <- struct{}{} })
+	if !stopped.Stop() {
+		t.Fatal("Stop of a pending timer = false")
+	}
+
+	select {
+	case <-fired:
+		t.Fatal("fired before the clock reached it")
+	case <-time.After(50 * time.Millisecond):
+	}
+	clock.Advance(time.Minute)
+	select {
+	case <-fired:
+	case <-time.After(10 * time.Second):
+		t.Fatal("not fired once the clock reached it")
+	}
+	select {
+	case <-fired:
+		t.Fatal("stopped timer fired")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
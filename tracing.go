@@ -0,0 +1,48 @@
+package fix
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/ljm2ya/binance_fix_api"
+
+// Span attributes.
+const (
+	attrMsgType   = attribute.Key("fix.msg_type")
+	attrRequestID = attribute.Key("fix.request_id")
+	attrClOrdID   = attribute.Key("fix.cl_ord_id")
+	attrSymbol    = attribute.Key("fix.symbol")
+)
+
+// WithTracerProvider traces Call, order placement and cancels with
+// OpenTelemetry spans from tp instead of the global tracer provider. An
+// order gets a span with the ClOrdID as "fix.cl_ord_id" attribute and child
+// spans for building the request, sending it, waiting for the response and
+// decoding it.
+func WithTracerProvider(tp trace.TracerProvider) NewClientOption {
+	return func(o *Options) {
+		o.tracerProvider = tp
+	}
+}
+
+func (c *Client) startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	tp := c.options.tracerProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	return tp.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan ends span, recording err if any.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
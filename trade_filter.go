@@ -0,0 +1,68 @@
+package fix
+
+import (
+	"github.com/ljm2ya/binance_fix_api/handlers"
+)
+
+// TradeFilter tells the trades a TradeSubscription delivers to its OnTrade
+// listeners. Filters run on the client, so the exchange still sends every
+// trade; they only spare the callbacks.
+type TradeFilter func(trade *handlers.Trade) bool
+
+// MinQuantity passes trades of at least qty.
+func MinQuantity(qty float64) TradeFilter {
+	return func(trade *handlers.Trade) bool {
+		return trade.Quantity >= qty
+	}
+}
+
+// MinNotional passes trades whose price times quantity is at least notional.
+func MinNotional(notional float64) TradeFilter {
+	return func(trade *handlers.Trade) bool {
+		return trade.Price*trade.Quantity >= notional
+	}
+}
+
+// PriceBand passes trades priced within [low, high]; a zero bound is open.
+func PriceBand(low, high float64) TradeFilter {
+	return func(trade *handlers.Trade) bool {
+		return trade.Price >= low && (high == 0 || trade.Price <= high)
+	}
+}
+
+// SetFilters replaces the filters of sub. Its OnTrade listeners get the trades
+// of its symbols that pass all of them, every trade without any.
+func (s *TradeSubscription) SetFilters(filters ...TradeFilter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.filters = filters
+}
+
+// OnTrade adds a listener for the trades of the symbols of sub that pass its
+// filters. Listeners of SubscribeToTradeStream still get every trade.
+func (s *TradeSubscription) OnTrade(listener TradeStreamHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, listener)
+}
+
+// deliver passes trade to the OnTrade listeners if it is of a symbol of sub
+// and passes its filters.
+func (s *TradeSubscription) deliver(trade *handlers.Trade) {
+	s.mu.Lock()
+	_, subscribed := s.mdReqID[trade.Symbol]
+	filters, listeners := s.filters, s.listeners
+	s.mu.Unlock()
+
+	if !subscribed || len(listeners) == 0 {
+		return
+	}
+	for _, filter := range filters {
+		if !filter(trade) {
+			return
+		}
+	}
+	for _, listener := range listeners {
+		listener(trade)
+	}
+}
@@ -0,0 +1,212 @@
+// Package tradestats keeps rolling VWAP, volume and trade count per symbol
+// over sliding windows of the trade stream.
+//
+// Windows end at the time of the latest trade of a symbol. Reads never take a
+// lock: every trade publishes an immutable snapshot that Stats loads atomically.
+package tradestats
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ljm2ya/binance_fix_api/handlers"
+)
+
+// Stats are the statistics of a symbol over a window.
+type Stats struct {
+	Symbol      string
+	Window      time.Duration
+	VWAP        float64
+	Volume      float64
+	QuoteVolume float64
+	Trades      int
+	UpdatedAt   time.Time // time of the latest trade
+}
+
+type Option func(t *Tracker)
+
+// WithWindows sets the tracked windows, 1m by default. Windows that aren't
+// positive are ignored; without any window left the default is kept.
+func WithWindows(windows ...time.Duration) Option {
+	return func(t *Tracker) {
+		var valid []time.Duration
+		for _, w := range windows {
+			if w > 0 {
+				valid = append(valid, w)
+			}
+		}
+		if len(valid) > 0 {
+			t.windows = valid
+		}
+	}
+}
+
+// Tracker maintains rolling statistics for every symbol it sees.
+type Tracker struct {
+	mu      sync.Mutex // serializes adding symbols
+	windows []time.Duration
+	symbols atomic.Pointer[map[string]*symbolStats]
+}
+
+// New creates a Tracker.
+func New(opts ...Option) *Tracker {
+	t := &Tracker{
+		windows: []time.Duration{time.Minute},
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	t.windows = slices.Clone(t.windows)
+	slices.Sort(t.windows)
+
+	symbols := make(map[string]*symbolStats)
+	t.symbols.Store(&symbols)
+	return t
+}
+
+// HandleTrade adds a trade to the windows of its symbol. Its signature matches
+// fix.TradeStreamHandler so it can be passed to Client.SubscribeToTradeStream
+// directly. Trades older than the latest one are counted at the latest time.
+func (t *Tracker) HandleTrade(trade *handlers.Trade) {
+	t.symbol(trade.Symbol).add(trade)
+}
+
+// Stats returns the statistics of symbol over window, which must be one of
+// the tracked windows.
+func (t *Tracker) Stats(symbol string, window time.Duration) (Stats, bool) {
+	s, ok := (*t.symbols.Load())[symbol]
+	if !ok {
+		return Stats{}, false
+	}
+	snapshot := s.snapshot.Load()
+	if snapshot == nil {
+		return Stats{}, false
+	}
+	for _, stats := range *snapshot {
+		if stats.Window == window {
+			return stats, true
+		}
+	}
+	return Stats{}, false
+}
+
+func (t *Tracker) symbol(symbol string) *symbolStats {
+	if s, ok := (*t.symbols.Load())[symbol]; ok {
+		return s
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	current := *t.symbols.Load()
+	if s, ok := current[symbol]; ok {
+		return s
+	}
+
+	s := newSymbolStats(symbol, t.windows)
+	next := make(map[string]*symbolStats, len(current)+1)
+	for k, v := range current {
+		next[k] = v
+	}
+	next[symbol] = s
+	t.symbols.Store(&next)
+	return s
+}
+
+type tradePoint struct {
+	time     time.Time
+	price    float64
+	quantity float64
+}
+
+// window holds running sums over trades[start:].
+type window struct {
+	length      time.Duration
+	start       int
+	volume      float64
+	quoteVolume float64
+	trades      int
+}
+
+type symbolStats struct {
+	mu       sync.Mutex
+	symbol   string
+	trades   []tradePoint
+	windows  []window // sorted by length, the last one is the longest
+	snapshot atomic.Pointer[[]Stats]
+}
+
+func newSymbolStats(symbol string, lengths []time.Duration) *symbolStats {
+	s := &symbolStats{symbol: symbol}
+	for _, length := range lengths {
+		s.windows = append(s.windows, window{length: length})
+	}
+	return s
+}
+
+func (s *symbolStats) add(trade *handlers.Trade) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := trade.TradeTime
+	if now.IsZero() {
+		now = time.Now()
+	}
+	if n := len(s.trades); n > 0 && now.Before(s.trades[n-1].time) {
+		now = s.trades[n-1].time
+	}
+
+	s.trades = append(s.trades, tradePoint{now, trade.Price, trade.Quantity})
+	for i := range s.windows {
+		w := &s.windows[i]
+		w.volume += trade.Quantity
+		w.quoteVolume += trade.Price * trade.Quantity
+		w.trades++
+
+		cutoff := now.Add(-w.length)
+		for w.start < len(s.trades) && !s.trades[w.start].time.After(cutoff) {
+			p := s.trades[w.start]
+			w.volume -= p.quantity
+			w.quoteVolume -= p.price * p.quantity
+			w.trades--
+			w.start++
+		}
+		if w.trades == 0 {
+			// drop accumulated rounding errors
+			w.volume, w.quoteVolume = 0, 0
+		}
+	}
+	s.compact()
+
+	snapshot := make([]Stats, len(s.windows))
+	for i, w := range s.windows {
+		stats := Stats{
+			Symbol:      s.symbol,
+			Window:      w.length,
+			Volume:      w.volume,
+			QuoteVolume: w.quoteVolume,
+			Trades:      w.trades,
+			UpdatedAt:   now,
+		}
+		if w.volume > 0 {
+			stats.VWAP = w.quoteVolume / w.volume
+		}
+		snapshot[i] = stats
+	}
+	s.snapshot.Store(&snapshot)
+}
+
+// compact drops trades that left the longest window once they make up half
+// of the buffer.
+func (s *symbolStats) compact() {
+	drop := s.windows[len(s.windows)-1].start
+	if drop == 0 || drop < len(s.trades)/2 {
+		return
+	}
+	s.trades = append(s.trades[:0], s.trades[drop:]...)
+	for i := range s.windows {
+		s.windows[i].start -= drop
+	}
+}
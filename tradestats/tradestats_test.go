@@ -0,0 +1,23 @@
+package tradestats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ljm2ya/binance_fix_api/handlers"
+)
+
+func TestWithWindowsWithoutWindows(t *testing.T) {
+	for name, opt := range map[string]Option{
+		"empty":        WithWindows(),
+		"non-positive": WithWindows(0, -time.Minute),
+	} {
+		tracker := New(opt)
+		tracker.HandleTrade(&handlers.Trade{Symbol: "BTCUSDT", Price: 100, Quantity: 2, TradeTime: time.Now()})
+
+		stats, ok := tracker.Stats("BTCUSDT", time.Minute)
+		if !ok || stats.Trades != 1 || stats.VWAP != 100 {
+			t.Errorf("%s: default window stats %+v, %v", name, stats, ok)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package fix
+
+import (
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/quickfix"
+)
+
+// BenchmarkTransmit compares queuing a message on the cached SessionID with
+// the previous send path, which formatted the session header fields on every
+// send and let quickfix.Send read them back to find the session.
+func BenchmarkTransmit(b *testing.B) {
+	client := startTestClient(b)
+
+	heartbeat := func() *quickfix.Message {
+		msg := quickfix.NewMessage()
+		msg.Header.Set(field.NewMsgType(enum.MsgType_HEARTBEAT))
+		return msg
+	}
+
+	b.Run("headers", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			msg := heartbeat()
+			msg.Header.Set(field.NewBeginString(client.beginString))
+			msg.Header.Set(field.NewTargetCompID(client.targetCompID))
+			msg.Header.Set(field.NewSenderCompID(client.senderCompID))
+			msg.Header.Set(field.NewSendingTime(time.Now().UTC()))
+			if err := quickfix.Send(msg); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("session", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := client.transmit(heartbeat()); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
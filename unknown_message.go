@@ -0,0 +1,41 @@
+package fix
+
+import (
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+)
+
+// handledMsgTypes are the inbound application MsgTypes the client processes
+// besides the responses of mappedMsgTypeTag.
+var handledMsgTypes = map[enum.MsgType]bool{
+	enum.MsgType_LIST_STATUS:                     true,
+	enum.MsgType_MARKET_DATA_INCREMENTAL_REFRESH: true,
+	enum.MsgType_NEWS:                            true,
+	enum.MsgType_BUSINESS_MESSAGE_REJECT:         true,
+}
+
+// UnknownMessage is emitted for an inbound application message of a MsgType
+// the client doesn't handle, e.g. one Binance added to the protocol. Such
+// messages are counted in HealthReport.UnknownMessages; RawMessages consumes
+// them as they come.
+type UnknownMessage struct {
+	MsgType string
+	Message *quickfix.Message
+}
+
+func knownMsgType(msgType enum.MsgType) bool {
+	_, ok := mappedMsgTypeTag[msgType]
+	return ok || handledMsgTypes[msgType]
+}
+
+// unknownMessage counts, logs and emits a message of an unknown MsgType.
+func (c *Client) unknownMessage(msgType string, msg *quickfix.Message) {
+	c.health.unknownMessages.Add(1)
+	c.logger().Warnw("Message of unknown MsgType ignored", "msgType", msgType, "msg", msg.String())
+
+	e := &UnknownMessage{MsgType: msgType, Message: msg}
+	if copied, err := copyMessage(msg); err == nil {
+		e.Message = copied
+	}
+	c.emit(UnknownMessageTopic, e)
+}
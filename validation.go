@@ -0,0 +1,87 @@
+package fix
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/quickfix/datadictionary"
+)
+
+// ValidationError is emitted for a message that doesn't conform to the data
+// dictionary set with WithDataDictionary.
+type ValidationError struct {
+	Outbound bool   // sent by the client, otherwise received
+	MsgType  string // empty when the message couldn't be parsed
+	Err      error  // a quickfix.MessageRejectError unless parsing failed
+	Raw      string
+}
+
+func (e *ValidationError) Error() string {
+	direction := "inbound"
+	if e.Outbound {
+		direction = "outbound"
+	}
+	var rejectErr quickfix.MessageRejectError
+	if errors.As(e.Err, &rejectErr) && rejectErr.RefTagID() != nil {
+		return fmt.Sprintf("invalid %s message %s: %v (tag %d)", direction, e.MsgType, e.Err, *rejectErr.RefTagID())
+	}
+	return fmt.Sprintf("invalid %s message %s: %v", direction, e.MsgType, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// WithDataDictionary validates every inbound and outbound message against
+// the FIX data dictionary at path, such as the OE, MD or DC dictionary
+// Binance publishes with its FIX API documentation; use the one matching the
+// session. Failures are logged and reported with SubscribeToValidationError,
+// messages are still sent and processed. Meant for development, e.g. of new
+// message builders. To have quickfix reject invalid inbound messages
+// instead, use SettingsBuilder.DataDictionary.
+func WithDataDictionary(path string) NewClientOption {
+	return func(o *Options) {
+		o.dataDictionary = path
+	}
+}
+
+// newValidator loads the data dictionary at path.
+func newValidator(path string) (quickfix.Validator, *datadictionary.DataDictionary, error) {
+	dd, err := datadictionary.Parse(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	settings := quickfix.ValidatorSettings{
+		CheckFieldsOutOfOrder:  true,
+		RejectInvalidMessage:   true,
+		CheckUserDefinedFields: true,
+	}
+	return quickfix.NewValidator(settings, dd, nil), dd, nil
+}
+
+// validate checks a raw message against the data dictionary.
+func (c *Client) validate(data []byte, outbound bool) {
+	msg := quickfix.NewMessage()
+	err := quickfix.ParseMessageWithDataDictionary(msg, bytes.NewBuffer(bytes.Clone(data)), c.dictionary, c.dictionary)
+	msgType, _ := msg.MsgType()
+	if err == nil {
+		if rejectErr := c.validator.Validate(msg); rejectErr != nil {
+			err = rejectErr
+		}
+	}
+	if err == nil {
+		return
+	}
+
+	e := &ValidationError{Outbound: outbound, MsgType: msgType, Err: err, Raw: string(data)}
+	c.logger().Warnw("FIX message failed validation", "err", e, "msg", e.Raw)
+	if outbound {
+		// Outgoing messages are logged while quickfix holds the session's send
+		// lock, a listener sending a message would deadlock.
+		go c.emit(ValidationErrorTopic, e)
+		return
+	}
+	c.emit(ValidationErrorTopic, e)
+}
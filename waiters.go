@@ -0,0 +1,85 @@
+package fix
+
+import (
+	"context"
+	"sync"
+)
+
+const (
+	waitDisconnect  = "disconnect"
+	waitMaintenance = "maintenance"
+)
+
+// waiterSet holds the one-shot waiters of the Wait* helpers. They are kept out
+// of the emitter: emission removes listeners by function pointer, which is
+// the same for every closure of a helper, so a waiter can't be removed from
+// it without removing all the others.
+type waiterSet struct {
+	mu      sync.Mutex
+	waiters map[string]map[*eventWaiter]struct{}
+}
+
+type eventWaiter struct {
+	events []string
+	fn     func(event string)
+}
+
+// add calls fn once with the first of events to happen. The returned cancel
+// removes the waiter if it hasn't fired yet.
+func (s *waiterSet) add(fn func(event string), events ...string) (cancel func()) {
+	w := &eventWaiter{events: events, fn: fn}
+
+	s.mu.Lock()
+	if s.waiters == nil {
+		s.waiters = make(map[string]map[*eventWaiter]struct{})
+	}
+	for _, event := range events {
+		if s.waiters[event] == nil {
+			s.waiters[event] = make(map[*eventWaiter]struct{})
+		}
+		s.waiters[event][w] = struct{}{}
+	}
+	s.mu.Unlock()
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.remove(w)
+	}
+}
+
+func (s *waiterSet) remove(w *eventWaiter) {
+	for _, event := range w.events {
+		delete(s.waiters[event], w)
+	}
+}
+
+// notify fires and removes the waiters of event.
+func (s *waiterSet) notify(event string) {
+	s.mu.Lock()
+	fired := make([]*eventWaiter, 0, len(s.waiters[event]))
+	for w := range s.waiters[event] {
+		fired = append(fired, w)
+		s.remove(w)
+	}
+	s.mu.Unlock()
+
+	for _, w := range fired {
+		w.fn(event)
+	}
+}
+
+// WaitForDisconnectCtx blocks until the session is logged out or ctx is done,
+// and returns ctx.Err() in the latter case.
+func (c *Client) WaitForDisconnectCtx(ctx context.Context) error {
+	disconnected := make(chan struct{})
+	cancel := c.waiters.add(func(string) { close(disconnected) }, waitDisconnect)
+	defer cancel()
+
+	select {
+	case <-disconnected:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}